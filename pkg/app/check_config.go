@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui"
+	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/jsonschema"
+)
+
+// Checks the given config files for problems and prints them to out, without
+// modifying the files. Like when loading the config, later files override
+// earlier ones, and keybinding conflicts are checked for the combined config.
+// Returns whether the config is free of errors; warnings don't count.
+func CheckConfig(configFiles []*config.ConfigFile, out io.Writer) bool {
+	appConfig := config.NewDummyAppConfig()
+	userConfig := appConfig.GetUserConfig()
+	valid := true
+
+	for _, configFile := range configFiles {
+		path := configFile.Path
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && configFile.Policy != config.ConfigFilePolicyErrorIfMissing {
+				continue
+			}
+			fmt.Fprintf(out, "%s: %v\n", path, err)
+			valid = false
+			continue
+		}
+
+		content, changes, err := config.ComputeConfigMigrations(path, content)
+		if err != nil {
+			fmt.Fprintf(out, "%s: %v\n", path, err)
+			valid = false
+			continue
+		}
+		for _, change := range changes {
+			fmt.Fprintf(out, "%s: warning: outdated config that lazygit will migrate on startup: %s\n", path, change)
+		}
+
+		validationErrors, err := jsonschema.ValidateUserConfig(content)
+		if err != nil {
+			fmt.Fprintf(out, "%s: %v\n", path, err)
+			valid = false
+			continue
+		}
+		for _, validationError := range validationErrors {
+			fmt.Fprintf(out, "%s:%d: %v\n", path, validationError.Line, validationError)
+		}
+		if len(validationErrors) > 0 {
+			// the remaining checks would only report the same problems again,
+			// less precisely
+			valid = false
+			continue
+		}
+
+		if err := config.MergeUserConfig(userConfig, path, content); err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			valid = false
+		}
+	}
+
+	if !valid {
+		return false
+	}
+
+	conflicts, err := getKeybindingConflicts(appConfig)
+	if err != nil {
+		fmt.Fprintf(out, "%v\n", err)
+		return false
+	}
+	for _, conflict := range conflicts {
+		location := "global keybindings"
+		if conflict.ViewName != "" {
+			location = fmt.Sprintf("keybindings of the '%s' view", conflict.ViewName)
+		}
+		fmt.Fprintf(out, "conflicting %s: %s and %s\n", location,
+			describeBinding(conflict.Bindings[0]), describeBinding(conflict.Bindings[1]))
+	}
+
+	return len(conflicts) == 0
+}
+
+func getKeybindingConflicts(appConfig *config.AppConfig) ([]gui.KeybindingConflict, error) {
+	common, err := NewCommon(appConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// neither the git version nor the updater are needed for creating the
+	// keybindings
+	g, err := gui.NewGui(common, appConfig, &git_commands.GitVersion{}, nil, false, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.GetKeybindingConflicts()
+}
+
+func describeBinding(binding *types.Binding) string {
+	label := fmt.Sprintf("'%s'", keybindings.LabelFromKey(binding.Key))
+	if binding.Description == "" {
+		return label
+	}
+	return fmt.Sprintf("%s (%s)", label, binding.Description)
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	_ "net/http/pprof"
@@ -19,6 +20,8 @@ import (
 	appTypes "github.com/jesseduffield/lazygit/pkg/app/types"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/env"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/remote"
+	"github.com/jesseduffield/lazygit/pkg/integration/script"
 	integrationTypes "github.com/jesseduffield/lazygit/pkg/integration/types"
 	"github.com/jesseduffield/lazygit/pkg/logs/tail"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -35,12 +38,18 @@ type cliArgs struct {
 	GitDir             string
 	CustomConfigFile   string
 	ScreenMode         string
+	ScriptPath         string
+	RemoteRequest      string
 	PrintVersionInfo   bool
+	PrintStatus        bool
+	JSON               bool
 	Debug              bool
 	TailLogs           bool
 	Profile            bool
 	PrintDefaultConfig bool
 	PrintConfigDir     bool
+	CheckConfig        bool
+	CheckConfigFiles   string
 }
 
 type BuildInfo struct {
@@ -54,6 +63,17 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 	cliArgs := parseCliArgsAndEnvVars()
 	mergeBuildInfo(buildInfo)
 
+	// Load the script before we change directories, since its path may be
+	// relative to where we were started from
+	var userScript *script.Script
+	if cliArgs.ScriptPath != "" {
+		var err error
+		userScript, err = script.Load(cliArgs.ScriptPath)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
 	if cliArgs.RepoPath != "" {
 		if cliArgs.WorkTree != "" || cliArgs.GitDir != "" {
 			log.Fatal("--path option is incompatible with the --work-tree and --git-dir options")
@@ -115,6 +135,25 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 		os.Exit(0)
 	}
 
+	if cliArgs.PrintStatus && !cliArgs.JSON {
+		log.Fatal("--status requires --json, which is the only output format so far")
+	}
+
+	if cliArgs.CheckConfig || cliArgs.CheckConfigFiles != "" {
+		configFiles := config.GlobalConfigFiles()
+		if cliArgs.CheckConfigFiles != "" {
+			configFiles = lo.Map(strings.Split(cliArgs.CheckConfigFiles, ","), func(path string, _ int) *config.ConfigFile {
+				return &config.ConfigFile{Path: path, Policy: config.ConfigFilePolicyErrorIfMissing}
+			})
+		}
+
+		if !CheckConfig(configFiles, os.Stdout) {
+			os.Exit(1)
+		}
+		fmt.Println("No problems found")
+		os.Exit(0)
+	}
+
 	if cliArgs.TailLogs {
 		logPath, err := config.LogPath()
 		if err != nil {
@@ -141,6 +180,15 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 		log.Fatal(err.Error())
 	}
 
+	if cliArgs.RemoteRequest != "" {
+		// we only needed the config to find the socket
+		if !sendRemoteRequests(appConfig, cliArgs.RemoteRequest) {
+			os.RemoveAll(tempDir)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if integrationTest != nil {
 		integrationTest.SetupConfig(appConfig)
 		// Set this to true so that integration tests don't have to explicitly deal with the hunk
@@ -159,6 +207,13 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 		log.Fatal(err)
 	}
 
+	if cliArgs.PrintStatus {
+		if err := PrintStatus(appConfig, common, os.Stdout); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	if daemon.InDaemonMode() {
 		daemon.Handle(common)
 		return
@@ -174,6 +229,12 @@ func Start(buildInfo *BuildInfo, integrationTest integrationTypes.IntegrationTes
 
 	parsedGitArg := parseGitArg(cliArgs.GitArg)
 
+	if userScript != nil {
+		// A script is run the same way as an integration test, except that it
+		// uses the user's config and repo as they are
+		integrationTest = userScript
+	}
+
 	Run(appConfig, common, appTypes.NewStartArgs(cliArgs.FilterPath, parsedGitArg, cliArgs.ScreenMode, integrationTest))
 }
 
@@ -207,6 +268,12 @@ func parseCliArgsAndEnvVars() *cliArgs {
 	printConfigDir := false
 	flaggy.Bool(&printConfigDir, "cd", "print-config-dir", "Print the config directory")
 
+	checkConfig := false
+	flaggy.Bool(&checkConfig, "", "check-config", "Check the config files that lazygit would load for errors and conflicting keybindings, then exit with a non-zero status if there are any")
+
+	checkConfigFiles := ""
+	flaggy.String(&checkConfigFiles, "", "check-config-file", "Like --check-config, but check the given comma separated list of config files instead (e.g. `lazygit --check-config-file path/to/config.yml`)")
+
 	useConfigDir := ""
 	flaggy.String(&useConfigDir, "ucd", "use-config-dir", "override default config directory with provided directory")
 
@@ -222,6 +289,18 @@ func parseCliArgsAndEnvVars() *cliArgs {
 	screenMode := ""
 	flaggy.String(&screenMode, "sm", "screen-mode", "The initial screen-mode, which determines the size of the focused panel. Valid options: 'normal' (default), 'half', 'full'")
 
+	scriptPath := ""
+	flaggy.String(&scriptPath, "", "script", "Run the actions in the given yaml script file against the repo without a terminal, then exit. Exits with a non-zero status if a step fails. See docs/Scripting.md")
+
+	remoteRequest := ""
+	flaggy.String(&remoteRequest, "", "remote", "Send a JSON command (e.g. '{\"command\": \"refresh\"}') to the running lazygit and print its response, or '-' to send one command per line from stdin. Requires remoteControl.enabled in the config. See docs/Remote_Control.md")
+
+	printStatus := false
+	flaggy.Bool(&printStatus, "", "status", "Print the status of the repo (branch, upstream, working tree state, file counts, stash count, rebase and bisect progress) and exit, without starting the gui. Requires --json. See docs/Status_Output.md")
+
+	outputJSON := false
+	flaggy.Bool(&outputJSON, "", "json", "Print the output of --status as JSON")
+
 	flaggy.Parse()
 
 	if os.Getenv("DEBUG") == "TRUE" {
@@ -233,16 +312,22 @@ func parseCliArgsAndEnvVars() *cliArgs {
 		FilterPath:         filterPath,
 		GitArg:             gitArg,
 		PrintVersionInfo:   printVersionInfo,
+		PrintStatus:        printStatus,
+		JSON:               outputJSON,
 		Debug:              debug,
 		TailLogs:           tailLogs,
 		Profile:            profile,
 		PrintDefaultConfig: printDefaultConfig,
 		PrintConfigDir:     printConfigDir,
+		CheckConfig:        checkConfig,
+		CheckConfigFiles:   checkConfigFiles,
 		UseConfigDir:       useConfigDir,
 		WorkTree:           workTree,
 		GitDir:             gitDir,
 		CustomConfigFile:   customConfigFile,
 		ScreenMode:         screenMode,
+		ScriptPath:         scriptPath,
+		RemoteRequest:      remoteRequest,
 	}
 }
 
@@ -332,3 +417,24 @@ func getTempDirBase() string {
 
 	return tmpDirBase
 }
+
+// Sends the request to the running lazygit, or the requests on stdin if the
+// request is "-", and prints the responses. Returns false if a request failed.
+func sendRemoteRequests(appConfig *config.AppConfig, request string) bool {
+	socketPath, err := remote.SocketPath(appConfig.GetUserConfig().RemoteControl)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var requests io.Reader = strings.NewReader(request)
+	if request == "-" {
+		requests = os.Stdin
+	}
+
+	succeeded, err := remote.Send(socketPath, requests, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	return succeeded
+}
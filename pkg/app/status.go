@@ -0,0 +1,179 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/git_config"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/samber/lo"
+)
+
+// The state of the repo as printed by `lazygit --status --json`, for shell
+// prompts and other tools. See docs/Status_Output.md
+type RepoStatus struct {
+	Branch       string          `json:"branch"`
+	DetachedHead bool            `json:"detachedHead"`
+	Upstream     *UpstreamStatus `json:"upstream"`
+	// Several of these can be true at once, e.g. when cherry-picking during
+	// an interactive rebase
+	WorkingTreeState WorkingTreeStatus `json:"workingTreeState"`
+	Files            FileCounts        `json:"files"`
+	StashCount       int               `json:"stashCount"`
+	Rebase           *RebaseStatus     `json:"rebase"`
+	Bisect           *BisectStatus     `json:"bisect"`
+}
+
+type UpstreamStatus struct {
+	// e.g. "origin/master"
+	Name string `json:"name"`
+	// nil if the upstream branch hasn't been fetched yet
+	Ahead  *int `json:"ahead"`
+	Behind *int `json:"behind"`
+	// whether the upstream branch has been deleted on the remote
+	Gone bool `json:"gone"`
+}
+
+type WorkingTreeStatus struct {
+	Rebasing      bool `json:"rebasing"`
+	Merging       bool `json:"merging"`
+	CherryPicking bool `json:"cherryPicking"`
+	Reverting     bool `json:"reverting"`
+}
+
+// A file can count as both staged and unstaged
+type FileCounts struct {
+	Total      int `json:"total"`
+	Staged     int `json:"staged"`
+	Unstaged   int `json:"unstaged"`
+	Untracked  int `json:"untracked"`
+	Conflicted int `json:"conflicted"`
+}
+
+type RebaseStatus struct {
+	// e.g. "refs/heads/feature"; empty when rebasing a detached head
+	Branch string `json:"branch"`
+	// the step that the rebase is at, out of Total. Both are 0 if git hasn't
+	// started picking commits yet
+	Step  int `json:"step"`
+	Total int `json:"total"`
+}
+
+type BisectStatus struct {
+	// whether both a new and an old commit have been marked
+	Bisecting bool   `json:"bisecting"`
+	Current   string `json:"current"`
+	NewTerm   string `json:"newTerm"`
+	OldTerm   string `json:"oldTerm"`
+}
+
+// Loads the status of the repo in the current directory the same way the gui
+// does, and prints it to out as JSON
+func PrintStatus(appConfig config.AppConfigurer, cmn *common.Common, out io.Writer) error {
+	app := &App{Common: cmn, Config: appConfig}
+	app.OSCommand = oscommands.NewOSCommand(cmn, appConfig, oscommands.GetPlatform(), oscommands.NewNullGuiIO(cmn.Log))
+
+	gitVersion, err := app.validateGitVersion()
+	if err != nil {
+		return err
+	}
+
+	gitCommand, err := commands.NewGitCommand(
+		cmn,
+		gitVersion,
+		app.OSCommand,
+		git_config.NewStdCachedGitConfig(cmn.Log),
+		config.NewPagerConfig(func() *config.UserConfig { return cmn.UserConfig() }),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	status, err := loadRepoStatus(gitCommand)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(status)
+}
+
+func loadRepoStatus(git *commands.GitCommand) (*RepoStatus, error) {
+	// we don't need recency or divergence from the base branch, so we can skip
+	// loading the reflog and main branches
+	branches, err := git.Loaders.BranchLoader.Load(nil, nil, nil, false, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	// the loader puts the checked out branch first
+	head := branches[0]
+
+	status := &RepoStatus{
+		Branch:       head.Name,
+		DetachedHead: head.DetachedHead,
+		StashCount:   len(git.Loaders.StashLoader.GetStashEntries("")),
+	}
+
+	if head.IsTrackingRemote() {
+		status.Upstream = &UpstreamStatus{
+			Name:   head.ShortUpstreamRefName(),
+			Ahead:  parseCount(head.AheadForPull),
+			Behind: parseCount(head.BehindForPull),
+			Gone:   head.UpstreamGone,
+		}
+	}
+
+	workingTreeState := git.Status.WorkingTreeState()
+	status.WorkingTreeState = WorkingTreeStatus{
+		Rebasing:      workingTreeState.Rebasing,
+		Merging:       workingTreeState.Merging,
+		CherryPicking: workingTreeState.CherryPicking,
+		Reverting:     workingTreeState.Reverting,
+	}
+
+	files := git.Loaders.FileLoader.GetStatusFiles(git_commands.GetStatusFileOptions{})
+	status.Files = FileCounts{
+		Total:      len(files),
+		Staged:     lo.CountBy(files, func(file *models.File) bool { return file.HasStagedChanges }),
+		Unstaged:   lo.CountBy(files, func(file *models.File) bool { return file.HasUnstagedChanges }),
+		Untracked:  lo.CountBy(files, func(file *models.File) bool { return !file.Tracked }),
+		Conflicted: lo.CountBy(files, func(file *models.File) bool { return file.HasMergeConflicts }),
+	}
+
+	if workingTreeState.Rebasing {
+		step, total, _ := git.Status.RebaseProgress()
+		status.Rebase = &RebaseStatus{
+			Branch: git.Status.BranchBeingRebased(),
+			Step:   step,
+			Total:  total,
+		}
+	}
+
+	if bisectInfo := git.Bisect.GetInfo(); bisectInfo.Started() {
+		status.Bisect = &BisectStatus{
+			Bisecting: bisectInfo.Bisecting(),
+			Current:   bisectInfo.GetCurrentHash(),
+			NewTerm:   bisectInfo.NewTerm(),
+			OldTerm:   bisectInfo.OldTerm(),
+		}
+	}
+
+	return status, nil
+}
+
+// Ahead/behind counts are "?" if the upstream branch isn't stored locally
+func parseCount(count string) *int {
+	value, err := strconv.Atoi(count)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
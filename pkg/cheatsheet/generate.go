@@ -123,6 +123,11 @@ func localisedTitle(tr *i18n.TranslationSet, str string) string {
 		"mergeConflicts":    tr.MergingTitle,
 		"staging":           tr.StagingTitle,
 		"menu":              tr.MenuTitle,
+		"filePicker":        tr.FilePickerTitle,
+		"repoDashboard":     tr.RepoDashboardTitle,
+		"commandHistory":    tr.CommandHistoryTitle,
+		"hooks":             tr.HooksTitle,
+		"hookOutput":        tr.HookOutputTitle,
 		"search":            tr.SearchTitle,
 		"secondary":         tr.SecondaryTitle,
 		"stash":             tr.StashTitle,
@@ -26,9 +26,11 @@ type GitCommand struct {
 	Diff        *git_commands.DiffCommands
 	File        *git_commands.FileCommands
 	Flow        *git_commands.FlowCommands
+	Hook        *git_commands.HookCommands
 	Patch       *git_commands.PatchCommands
 	Rebase      *git_commands.RebaseCommands
 	Remote      *git_commands.RemoteCommands
+	Snapshot    *git_commands.SnapshotCommands
 	Stash       *git_commands.StashCommands
 	Status      *git_commands.StatusCommands
 	Submodule   *git_commands.SubmoduleCommands
@@ -50,6 +52,7 @@ type Loaders struct {
 	FileLoader         *git_commands.FileLoader
 	ReflogCommitLoader *git_commands.ReflogCommitLoader
 	RemoteLoader       *git_commands.RemoteLoader
+	RepoSummaryLoader  *git_commands.RepoSummaryLoader
 	StashLoader        *git_commands.StashLoader
 	TagLoader          *git_commands.TagLoader
 	Worktrees          *git_commands.WorktreeLoader
@@ -61,6 +64,7 @@ func NewGitCommand(
 	osCommand *oscommands.OSCommand,
 	gitConfig git_config.IGitConfig,
 	pagerConfig *config.PagerConfig,
+	getDiffContextSize func() *uint64,
 ) (*GitCommand, error) {
 	repoPaths, err := git_commands.GetRepoPaths(osCommand.Cmd, version)
 	if err != nil {
@@ -91,6 +95,7 @@ func NewGitCommand(
 		repoPaths,
 		repository,
 		pagerConfig,
+		getDiffContextSize,
 	), nil
 }
 
@@ -102,6 +107,7 @@ func NewGitCommandAux(
 	repoPaths *git_commands.RepoPaths,
 	repo *gogit.Repository,
 	pagerConfig *config.PagerConfig,
+	getDiffContextSize func() *uint64,
 ) *GitCommand {
 	cmd := NewGitCmdObjBuilder(cmn.Log, osCommand.Cmd)
 
@@ -112,11 +118,12 @@ func NewGitCommandAux(
 	// common ones are: cmn, osCommand, dotGitDir, configCommands
 	configCommands := git_commands.NewConfigCommands(cmn, gitConfig, repo)
 
-	gitCommon := git_commands.NewGitCommon(cmn, version, cmd, osCommand, repoPaths, repo, configCommands, pagerConfig)
+	gitCommon := git_commands.NewGitCommon(cmn, version, cmd, osCommand, repoPaths, repo, configCommands, pagerConfig, getDiffContextSize)
 
 	fileLoader := git_commands.NewFileLoader(gitCommon, cmd, configCommands)
 	statusCommands := git_commands.NewStatusCommands(gitCommon)
 	flowCommands := git_commands.NewFlowCommands(gitCommon)
+	hookCommands := git_commands.NewHookCommands(gitCommon)
 	remoteCommands := git_commands.NewRemoteCommands(gitCommon)
 	branchCommands := git_commands.NewBranchCommands(gitCommon)
 	syncCommands := git_commands.NewSyncCommands(gitCommon)
@@ -126,7 +133,8 @@ func NewGitCommandAux(
 	diffCommands := git_commands.NewDiffCommands(gitCommon)
 	fileCommands := git_commands.NewFileCommands(gitCommon)
 	submoduleCommands := git_commands.NewSubmoduleCommands(gitCommon)
-	workingTreeCommands := git_commands.NewWorkingTreeCommands(gitCommon, submoduleCommands, fileLoader)
+	snapshotCommands := git_commands.NewSnapshotCommands(gitCommon)
+	workingTreeCommands := git_commands.NewWorkingTreeCommands(gitCommon, submoduleCommands, fileLoader, snapshotCommands)
 	rebaseCommands := git_commands.NewRebaseCommands(gitCommon, commitCommands, workingTreeCommands)
 	stashCommands := git_commands.NewStashCommands(gitCommon, fileLoader, workingTreeCommands)
 	patchBuilder := patch.NewPatchBuilder(cmn.Log,
@@ -145,6 +153,7 @@ func NewGitCommandAux(
 	remoteLoader := git_commands.NewRemoteLoader(cmn, cmd, repo.Remotes)
 	worktreeLoader := git_commands.NewWorktreeLoader(gitCommon)
 	stashLoader := git_commands.NewStashLoader(cmn, cmd)
+	repoSummaryLoader := git_commands.NewRepoSummaryLoader(cmn, cmd)
 	tagLoader := git_commands.NewTagLoader(cmn, cmd)
 
 	return &GitCommand{
@@ -156,9 +165,11 @@ func NewGitCommandAux(
 		Diff:        diffCommands,
 		File:        fileCommands,
 		Flow:        flowCommands,
+		Hook:        hookCommands,
 		Patch:       patchCommands,
 		Rebase:      rebaseCommands,
 		Remote:      remoteCommands,
+		Snapshot:    snapshotCommands,
 		Stash:       stashCommands,
 		Status:      statusCommands,
 		Submodule:   submoduleCommands,
@@ -175,6 +186,7 @@ func NewGitCommandAux(
 			FileLoader:         fileLoader,
 			ReflogCommitLoader: reflogCommitLoader,
 			RemoteLoader:       remoteLoader,
+			RepoSummaryLoader:  repoSummaryLoader,
 			Worktrees:          worktreeLoader,
 			StashLoader:        stashLoader,
 			TagLoader:          tagLoader,
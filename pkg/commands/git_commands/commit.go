@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 var ErrInvalidCommitIndex = errors.New("invalid commit index")
@@ -226,6 +227,22 @@ func (self *CommitCommands) GetCommitsOneline(hashes []string) (string, error) {
 	return self.cmd.New(cmdArgs).DontLog().RunWithOutput()
 }
 
+// Returns the subjects of the commits that are reachable from `to` but not from
+// `from`, oldest first
+func (self *CommitCommands) GetCommitSubjectsInRange(from string, to string) ([]string, error) {
+	cmdArgs := NewGitCmd("log").
+		Arg("--format=%s", "--reverse", from+".."+to).
+		Config("log.showsignature=false").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.SplitLines(output), nil
+}
+
 // AmendHead amends HEAD with whatever is staged in your working tree
 func (self *CommitCommands) AmendHead() error {
 	return self.AmendHeadCmdObj().Run()
@@ -240,7 +257,7 @@ func (self *CommitCommands) AmendHeadCmdObj() *oscommands.CmdObj {
 }
 
 func (self *CommitCommands) ShowCmdObj(hash string, filterPaths []string) *oscommands.CmdObj {
-	contextSize := self.UserConfig().Git.DiffContextSize
+	contextSize := self.DiffContextSize()
 
 	extDiffCmd := self.pagerConfig.GetExternalDiffCommand()
 	useExtDiffGitConfig := self.pagerConfig.GetUseExternalDiffGitConfig()
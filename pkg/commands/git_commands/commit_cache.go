@@ -0,0 +1,212 @@
+package git_commands
+
+import (
+	"hash/fnv"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/sasha-s/go-deadlock"
+)
+
+// The number of commits we load when GetCommitsOptions.Limit is set
+const commitsLimit = 300
+
+// The commits of the last log that was loaded with GetCommitsOptions.UseCache.
+// On big repos, running `git log` for the whole history takes long, so when
+// refreshing we only load the commits that were added on top of the previous
+// head, and take the rest from here. When the log is expanded after it was
+// loaded with a limit, we only load the commits below the cached ones.
+type commitCache struct {
+	mutex deadlock.Mutex
+
+	key commitCacheKey
+	// A hash of the refs that can show up in the decorations of the commits,
+	// except the checked-out branch. If any of them moved, we need to reload
+	// everything.
+	refsFingerprint uint64
+	// Only the commits from the log, not the ones of a rebase in progress
+	commits []*models.Commit
+	// Whether commits is the whole log, rather than the first commitsLimit
+	// commits of it
+	complete bool
+}
+
+// The options that the output of the log depends on
+type commitCacheKey struct {
+	order       string
+	excludedRef string
+}
+
+// Returns the commits to continue from, or nil if the previous log can't be
+// used for the given options
+func (self *commitCache) get(key commitCacheKey, refsFingerprint uint64) ([]*models.Commit, bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.key != key || self.refsFingerprint != refsFingerprint {
+		return nil, false
+	}
+	return self.commits, self.complete
+}
+
+func (self *commitCache) set(key commitCacheKey, refsFingerprint uint64, commits []*models.Commit, complete bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.key = key
+	self.refsFingerprint = refsFingerprint
+	self.commits = commits
+	self.complete = complete
+}
+
+func (self *CommitLoader) commitCacheKey(opts GetCommitsOptions) (commitCacheKey, bool) {
+	// The cache only works for the log of HEAD: we rely on the head of the log
+	// being loaded afresh, so that its decorations are up to date. Also, with
+	// --all, or when filtering, commits can appear anywhere in the log.
+	if !opts.UseCache || opts.RefName != "HEAD" || opts.All || opts.FilterPath != "" || opts.FilterAuthor != "" || opts.RefToShowDivergenceFrom != "" {
+		return commitCacheKey{}, false
+	}
+
+	key := commitCacheKey{
+		order: self.UserConfig().Git.Log.Order,
+	}
+	if opts.RefForPushedStatus != nil {
+		key.excludedRef = opts.RefForPushedStatus.FullRefName()
+	}
+	return key, true
+}
+
+func (self *CommitLoader) refsFingerprint(excludedRef string) (uint64, error) {
+	// These are the refs that `git log` decorates commits with. The %(HEAD)
+	// marker tells which branch is checked out, which shows up in the
+	// decorations too; where HEAD itself points is taken care of by loading
+	// the head of the log afresh.
+	cmdArgs := NewGitCmd("for-each-ref").
+		Arg("--format=%(objectname) %(HEAD) %(refname)").
+		Arg("refs/heads", "refs/remotes", "refs/tags").
+		ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return 0, err
+	}
+
+	hash := fnv.New64a()
+	for _, line := range utils.SplitLines(output) {
+		// the checked-out branch moves when committing, but that only changes
+		// the decorations of the head of the log; we only care whether it's
+		// still checked out
+		if len(line) > 43 && line[43:] == excludedRef {
+			line = line[40:43]
+		}
+		hash.Write([]byte(line))
+		hash.Write([]byte{'\n'})
+	}
+	return hash.Sum64(), nil
+}
+
+// Loads the commits of the log, reusing the cached ones where possible
+func (self *CommitLoader) loadLogCommits(opts GetCommitsOptions) ([]*models.Commit, error) {
+	key, useCache := self.commitCacheKey(opts)
+	var refsFingerprint uint64
+	var cachedCommits []*models.Commit
+	cacheComplete := false
+	if useCache {
+		var err error
+		refsFingerprint, err = self.refsFingerprint(key.excludedRef)
+		if err != nil {
+			self.Log.Error(err)
+			useCache = false
+		} else {
+			cachedCommits, cacheComplete = self.cache.get(key, refsFingerprint)
+		}
+	}
+
+	// We can only continue with the cached commits if the new ones are a
+	// linear series on top of the previous head: in that case, once git log
+	// has walked down to the previous head, it outputs the same commits as last
+	// time. If there were merges, commits of the merged branches would appear
+	// further down.
+	foundCachedHead := false
+	linear := true
+	commits, err := loadCommits(self.getLogCmd(opts), opts.FilterPath, func(line string) (*models.Commit, bool) {
+		if foundCachedHead {
+			return nil, true
+		}
+
+		commit := self.extractCommitFromLine(opts.HashPool, line, opts.RefToShowDivergenceFrom != "")
+		if commit != nil && len(cachedCommits) > 0 && linear {
+			if commit.Hash() == cachedCommits[0].Hash() {
+				// We keep the freshly loaded head, because its decorations
+				// change when the branch moves away from it
+				foundCachedHead = true
+			} else if len(commit.ParentPtrs()) != 1 {
+				linear = false
+			}
+		}
+		return commit, false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	complete := !opts.Limit || len(commits) < commitsLimit
+	if foundCachedHead {
+		// copy the cached commits, because their statuses are about to be
+		// updated, and the old ones may still be rendered
+		commits = append(commits, copyCommits(cachedCommits[1:])...)
+		complete = cacheComplete
+
+		if !opts.Limit && !complete {
+			below, err := self.loadCommitsBelow(opts, commits)
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, below...)
+			complete = true
+		}
+	}
+
+	if useCache {
+		self.cache.set(key, refsFingerprint, commits, complete)
+	}
+
+	return commits, nil
+}
+
+// Loads the rest of the log, given the commits at the top of it. These are
+// the ancestors of the parents that aren't among the given commits yet.
+func (self *CommitLoader) loadCommitsBelow(opts GetCommitsOptions, commits []*models.Commit) ([]*models.Commit, error) {
+	loaded := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		loaded[commit.Hash()] = true
+	}
+	parents := lo.Uniq(lo.Reject(lo.FlatMap(commits, func(commit *models.Commit, _ int) []string {
+		return commit.Parents()
+	}), func(parent string, _ int) bool { return loaded[parent] }))
+	if len(parents) == 0 {
+		return nil, nil
+	}
+
+	belowOpts := opts
+	belowOpts.Limit = false
+	return loadCommits(self.getLogCmdForRevisions(belowOpts, parents), "", func(line string) (*models.Commit, bool) {
+		commit := self.extractCommitFromLine(opts.HashPool, line, false)
+		// with clock skew, git log's default order can put a commit below one
+		// of its ancestors
+		if commit != nil && loaded[commit.Hash()] {
+			return nil, false
+		}
+		return commit, false
+	})
+}
+
+func copyCommits(commits []*models.Commit) []*models.Commit {
+	copies := make([]models.Commit, len(commits))
+	result := make([]*models.Commit, len(commits))
+	for i, commit := range commits {
+		copies[i] = *commit
+		result[i] = &copies[i]
+	}
+	return result
+}
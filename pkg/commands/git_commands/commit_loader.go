@@ -35,6 +35,7 @@ type CommitLoader struct {
 	readFile            func(filename string) ([]byte, error)
 	walkFiles           func(root string, fn filepath.WalkFunc) error
 	dotGitDir           string
+	cache               commitCache
 	*GitCommon
 }
 
@@ -68,6 +69,9 @@ type GetCommitsOptions struct {
 	RefToShowDivergenceFrom string
 	MainBranches            *MainBranches
 	HashPool                *utils.StringPool
+	// Only load the commits that were added since the last call with this
+	// option, if possible. For refreshing the same log repeatedly.
+	UseCache bool
 }
 
 // GetCommits obtains the commits of the current branch
@@ -91,9 +95,7 @@ func (self *CommitLoader) GetCommits(opts GetCommitsOptions) ([]*models.Commit,
 		defer wg.Done()
 
 		var realCommits []*models.Commit
-		realCommits, logErr = loadCommits(self.getLogCmd(opts), opts.FilterPath, func(line string) (*models.Commit, bool) {
-			return self.extractCommitFromLine(opts.HashPool, line, opts.RefToShowDivergenceFrom != ""), false
-		})
+		realCommits, logErr = self.loadLogCommits(opts)
 		if logErr == nil {
 			commits = append(commits, realCommits...)
 		}
@@ -579,22 +581,26 @@ func (self *CommitLoader) getReachableHashes(refName string, notRefNames []strin
 
 // getLogCmd gets the git log.
 func (self *CommitLoader) getLogCmd(opts GetCommitsOptions) *oscommands.CmdObj {
-	gitLogOrder := self.UserConfig().Git.Log.Order
-
 	refSpec := opts.RefName
 	if opts.RefToShowDivergenceFrom != "" {
 		refSpec += "..." + opts.RefToShowDivergenceFrom
 	}
 
+	return self.getLogCmdForRevisions(opts, []string{refSpec})
+}
+
+func (self *CommitLoader) getLogCmdForRevisions(opts GetCommitsOptions, revisions []string) *oscommands.CmdObj {
+	gitLogOrder := self.UserConfig().Git.Log.Order
+
 	cmdArgs := NewGitCmd("log").
-		Arg(refSpec).
+		Arg(revisions...).
 		ArgIf(gitLogOrder != "default", "--"+gitLogOrder).
 		ArgIf(opts.All, "--all").
 		Arg("--oneline").
 		Arg(prettyFormat).
 		Arg("--abbrev=40").
 		ArgIf(opts.FilterAuthor != "", "--author="+opts.FilterAuthor).
-		ArgIf(opts.Limit, "-300").
+		ArgIf(opts.Limit, fmt.Sprintf("-%d", commitsLimit)).
 		ArgIf(opts.FilterPath != "", "--follow", "--name-status").
 		Arg("--no-show-signature").
 		ArgIf(opts.RefToShowDivergenceFrom != "", "--left-right").
@@ -1,6 +1,7 @@
 package git_commands
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -794,3 +795,219 @@ func TestCommitLoader_extractCommitFromLine(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommitsWithCache(t *testing.T) {
+	logLine := func(hash string, parents string, refs string) string {
+		return strings.Join([]string{"+" + hash, "1640826609", "Jesse Duffield", "jessedduffield@gmail.com", parents, ">", refs, "msg " + hash}, "\x00")
+	}
+	logOutput := func(lines ...string) string {
+		return strings.Join(lines, "\n")
+	}
+	logArgs := []string{"log", "HEAD", "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "-300", "--no-show-signature", "--"}
+	revListArgs := []string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}
+	forEachRefArgs := []string{"for-each-ref", "--format=%(objectname) %(HEAD) %(refname)", "refs/heads", "refs/remotes", "refs/tags"}
+	refs := "c000000000000000000000000000000000000000   refs/tags/v1\n"
+
+	firstLog := logOutput(
+		logLine("a", "b", "HEAD -> mybranch"),
+		logLine("b", "c", ""),
+		logLine("c", "", "tag: v1"),
+	)
+
+	scenarios := []struct {
+		testName   string
+		secondRefs string
+		// the commits below the previous head have different decorations
+		// than in the first log, to tell whether they were loaded again
+		secondLog      string
+		expectedHashes []string
+		expectedRefs   []string
+	}{
+		{
+			testName:   "new commits on top of the previous head",
+			secondRefs: refs,
+			secondLog: logOutput(
+				logLine("e", "d", "HEAD -> mybranch"),
+				logLine("d", "a", ""),
+				logLine("a", "b", ""),
+				logLine("b", "c", "reloaded"),
+				logLine("c", "", "reloaded"),
+			),
+			expectedHashes: []string{"e", "d", "a", "b", "c"},
+			expectedRefs:   []string{"(HEAD -> mybranch)", "", "", "", "(tag: v1)"},
+		},
+		{
+			testName:   "nothing changed",
+			secondRefs: refs,
+			secondLog: logOutput(
+				logLine("a", "b", "HEAD -> mybranch"),
+				logLine("b", "c", "reloaded"),
+			),
+			expectedHashes: []string{"a", "b", "c"},
+			expectedRefs:   []string{"(HEAD -> mybranch)", "", "(tag: v1)"},
+		},
+		{
+			testName:   "a ref other than the checked out branch changed",
+			secondRefs: refs + "b000000000000000000000000000000000000000   refs/heads/other\n",
+			secondLog: logOutput(
+				logLine("d", "a", "HEAD -> mybranch"),
+				logLine("a", "b", ""),
+				logLine("b", "c", "other"),
+				logLine("c", "", "tag: v1"),
+			),
+			expectedHashes: []string{"d", "a", "b", "c"},
+			expectedRefs:   []string{"(HEAD -> mybranch)", "", "(other)", "(tag: v1)"},
+		},
+		{
+			testName:   "merge on top of the previous head",
+			secondRefs: refs,
+			secondLog: logOutput(
+				logLine("e", "a f", "HEAD -> mybranch"),
+				logLine("f", "c", ""),
+				logLine("a", "b", ""),
+				logLine("b", "c", ""),
+				logLine("c", "", "reloaded"),
+			),
+			expectedHashes: []string{"e", "f", "a", "b", "c"},
+			expectedRefs:   []string{"(HEAD -> mybranch)", "", "", "", "(reloaded)"},
+		},
+		{
+			testName:   "previous head was amended",
+			secondRefs: refs,
+			secondLog: logOutput(
+				logLine("a2", "b", "HEAD -> mybranch"),
+				logLine("b", "c", ""),
+				logLine("c", "", "reloaded"),
+			),
+			expectedHashes: []string{"a2", "b", "c"},
+			expectedRefs:   []string{"(HEAD -> mybranch)", "", "(reloaded)"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.testName, func(t *testing.T) {
+			runner := oscommands.NewFakeRunner(t).
+				ExpectGitArgs(revListArgs, "", nil).
+				ExpectGitArgs(revListArgs, "", nil).
+				ExpectGitArgs(forEachRefArgs, refs+"a000000000000000000000000000000000000000 * refs/heads/mybranch\n", nil).
+				ExpectGitArgs(logArgs, firstLog, nil).
+				ExpectGitArgs(forEachRefArgs, scenario.secondRefs+"d000000000000000000000000000000000000000 * refs/heads/mybranch\n", nil).
+				ExpectGitArgs(logArgs, scenario.secondLog, nil)
+
+			common := common.NewDummyCommon()
+			common.UserConfig().Git.Log.Order = "default"
+			common.UserConfig().Git.MainBranches = nil
+			cmd := oscommands.NewDummyCmdObjBuilder(runner)
+			loader := &CommitLoader{
+				Common:              common,
+				cmd:                 cmd,
+				getWorkingTreeState: func() models.WorkingTreeState { return models.WorkingTreeState{} },
+				dotGitDir:           ".git",
+				readFile: func(filename string) ([]byte, error) {
+					return []byte(""), nil
+				},
+				walkFiles: func(root string, fn filepath.WalkFunc) error {
+					return nil
+				},
+			}
+
+			opts := GetCommitsOptions{
+				Limit:              true,
+				RefName:            "HEAD",
+				RefForPushedStatus: &models.Branch{Name: "mybranch"},
+				MainBranches:       NewMainBranches(common, cmd),
+				HashPool:           &utils.StringPool{},
+				UseCache:           true,
+			}
+			firstCommits, err := loader.GetCommits(opts)
+			assert.NoError(t, err)
+
+			commits, err := loader.GetCommits(opts)
+			assert.NoError(t, err)
+			assert.Equal(t, scenario.expectedHashes, lo.Map(commits, func(commit *models.Commit, _ int) string { return commit.Hash() }))
+			assert.Equal(t, scenario.expectedRefs, lo.Map(commits, func(commit *models.Commit, _ int) string { return commit.ExtraInfo }))
+			// the commits of the first load may still be in use, so they
+			// must not be shared
+			for _, commit := range commits {
+				assert.False(t, lo.Contains(firstCommits, commit))
+			}
+
+			runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestGetCommitsWithCacheExpandingTheLog(t *testing.T) {
+	logLine := func(hash string, parents string) string {
+		return strings.Join([]string{"+" + hash, "1640826609", "Jesse Duffield", "jessedduffield@gmail.com", parents, ">", "", "msg " + hash}, "\x00")
+	}
+	logArgs := func(revisions ...string) []string {
+		return append(append([]string{"log"}, revisions...), "--oneline", "--pretty=format:+%H%x00%at%x00%aN%x00%ae%x00%P%x00%m%x00%D%x00%s", "--abbrev=40", "--no-show-signature", "--")
+	}
+	revListArgs := []string{"rev-list", "refs/heads/mybranch", "^mybranch@{u}"}
+	forEachRefArgs := []string{"for-each-ref", "--format=%(objectname) %(HEAD) %(refname)", "refs/heads", "refs/remotes", "refs/tags"}
+	refs := "c000000000000000000000000000000000000000 * refs/heads/mybranch\n"
+
+	// The first 300 commits are c0 to c299, where c0 merges m, which branched
+	// off from c5
+	limitedLog := []string{logLine("c0", "c1 m")}
+	for i := 1; i < 300; i++ {
+		limitedLog = append(limitedLog, logLine(fmt.Sprintf("c%d", i), fmt.Sprintf("c%d", i+1)))
+	}
+	expectedHashes := append(lo.Times(300, func(i int) string { return fmt.Sprintf("c%d", i) }), "m", "c300")
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs(revListArgs, "", nil).
+		ExpectGitArgs(forEachRefArgs, refs, nil).
+		ExpectGitArgs(append(logArgs("HEAD")[:5], "-300", "--no-show-signature", "--"), strings.Join(limitedLog, "\n"), nil).
+		ExpectGitArgs(revListArgs, "", nil).
+		ExpectGitArgs(forEachRefArgs, refs, nil).
+		ExpectGitArgs(logArgs("HEAD"), logLine("c0", "c1 m"), nil).
+		// only the commits below the ones we have are loaded; the ones we
+		// have already are skipped
+		ExpectGitArgs(logArgs("m", "c300"), strings.Join([]string{logLine("m", "c5"), logLine("c5", "c6"), logLine("c300", "")}, "\n"), nil).
+		ExpectGitArgs(revListArgs, "", nil).
+		ExpectGitArgs(forEachRefArgs, refs, nil).
+		ExpectGitArgs(logArgs("HEAD"), logLine("c0", "c1 m"), nil)
+
+	common := common.NewDummyCommon()
+	common.UserConfig().Git.Log.Order = "default"
+	common.UserConfig().Git.MainBranches = nil
+	cmd := oscommands.NewDummyCmdObjBuilder(runner)
+	loader := &CommitLoader{
+		Common:              common,
+		cmd:                 cmd,
+		getWorkingTreeState: func() models.WorkingTreeState { return models.WorkingTreeState{} },
+		dotGitDir:           ".git",
+		readFile: func(filename string) ([]byte, error) {
+			return []byte(""), nil
+		},
+		walkFiles: func(root string, fn filepath.WalkFunc) error {
+			return nil
+		},
+	}
+
+	opts := GetCommitsOptions{
+		Limit:              true,
+		RefName:            "HEAD",
+		RefForPushedStatus: &models.Branch{Name: "mybranch"},
+		MainBranches:       NewMainBranches(common, cmd),
+		HashPool:           &utils.StringPool{},
+		UseCache:           true,
+	}
+	commits, err := loader.GetCommits(opts)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 300)
+
+	opts.Limit = false
+	commits, err = loader.GetCommits(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHashes, lo.Map(commits, func(commit *models.Commit, _ int) string { return commit.Hash() }))
+
+	// now that we have the whole log, there's nothing left to load below it
+	commits, err = loader.GetCommits(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHashes, lo.Map(commits, func(commit *models.Commit, _ int) string { return commit.Hash() }))
+
+	runner.CheckForMissingCalls()
+}
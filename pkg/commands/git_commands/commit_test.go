@@ -390,6 +390,38 @@ func TestGetCommitMsg(t *testing.T) {
 	}
 }
 
+func TestGetCommitSubjectsInRange(t *testing.T) {
+	scenarios := []struct {
+		testName       string
+		output         string
+		expectedResult []string
+	}{
+		{
+			testName:       "no commits",
+			output:         "",
+			expectedResult: []string{},
+		},
+		{
+			testName:       "several commits",
+			output:         "first commit\nsecond commit\n",
+			expectedResult: []string{"first commit", "second commit"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			instance := buildCommitCommands(commonDeps{
+				runner: oscommands.NewFakeRunner(t).ExpectGitArgs([]string{"-c", "log.showsignature=false", "log", "--format=%s", "--reverse", "main..feature"}, s.output, nil),
+			})
+
+			result, err := instance.GetCommitSubjectsInRange("main", "feature")
+
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedResult, result)
+		})
+	}
+}
+
 func TestGetCommitMessageFromHistory(t *testing.T) {
 	type scenario struct {
 		testName string
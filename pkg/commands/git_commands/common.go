@@ -16,6 +16,9 @@ type GitCommon struct {
 	repo        *gogit.Repository
 	config      *ConfigCommands
 	pagerConfig *config.PagerConfig
+	// Returns the diff context size that was chosen in the gui for the current
+	// repo, or nil if the configured one should be used
+	getDiffContextSize func() *uint64
 }
 
 func NewGitCommon(
@@ -27,6 +30,7 @@ func NewGitCommon(
 	repo *gogit.Repository,
 	config *ConfigCommands,
 	pagerConfig *config.PagerConfig,
+	getDiffContextSize func() *uint64,
 ) *GitCommon {
 	return &GitCommon{
 		Common:      cmn,
@@ -37,5 +41,17 @@ func NewGitCommon(
 		repo:        repo,
 		config:      config,
 		pagerConfig: pagerConfig,
+
+		getDiffContextSize: getDiffContextSize,
+	}
+}
+
+// The number of context lines to show around changes in diffs
+func (self *GitCommon) DiffContextSize() uint64 {
+	if self.getDiffContextSize != nil {
+		if size := self.getDiffContextSize(); size != nil {
+			return *size
+		}
 	}
+	return self.UserConfig().Git.DiffContextSize
 }
@@ -125,7 +125,21 @@ func buildWorkingTreeCommands(deps commonDeps) *WorkingTreeCommands {
 	submoduleCommands := buildSubmoduleCommands(deps)
 	fileLoader := buildFileLoader(gitCommon)
 
-	return NewWorkingTreeCommands(gitCommon, submoduleCommands, fileLoader)
+	snapshotCommands := NewSnapshotCommands(gitCommon)
+
+	return NewWorkingTreeCommands(gitCommon, submoduleCommands, fileLoader, snapshotCommands)
+}
+
+func buildSnapshotCommands(deps commonDeps) *SnapshotCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewSnapshotCommands(gitCommon)
+}
+
+func buildHookCommands(deps commonDeps) *HookCommands {
+	gitCommon := buildGitCommon(deps)
+
+	return NewHookCommands(gitCommon)
 }
 
 func buildStashCommands(deps commonDeps) *StashCommands {
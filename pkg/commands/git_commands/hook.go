@@ -0,0 +1,212 @@
+package git_commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+)
+
+// The hooks that git knows about (see `git help hooks`), in the order in which
+// they are documented. Any other files in the hooks directory are ignored,
+// including the *.sample files that `git init` creates.
+var KnownHookNames = []string{
+	"applypatch-msg",
+	"pre-applypatch",
+	"post-applypatch",
+	"pre-commit",
+	"pre-merge-commit",
+	"prepare-commit-msg",
+	"commit-msg",
+	"post-commit",
+	"pre-rebase",
+	"post-checkout",
+	"post-merge",
+	"pre-push",
+	"pre-receive",
+	"update",
+	"proc-receive",
+	"post-receive",
+	"post-update",
+	"reference-transaction",
+	"push-to-checkout",
+	"pre-auto-gc",
+	"post-rewrite",
+	"sendemail-validate",
+	"fsmonitor-watchman",
+	"p4-changelist",
+	"p4-prepare-changelist",
+	"p4-post-changelist",
+	"p4-pre-submit",
+	"post-index-change",
+}
+
+type HookCommands struct {
+	*GitCommon
+}
+
+func NewHookCommands(gitCommon *GitCommon) *HookCommands {
+	return &HookCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+// The absolute path of the directory that git runs hooks from. This respects
+// core.hooksPath.
+func (self *HookCommands) HooksPath() (string, error) {
+	cmdArgs := NewGitCmd("rev-parse").
+		Arg("--path-format=absolute", "--git-path", "hooks").
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// Returns the hooks that exist in the hooks directory, in the order of
+// KnownHookNames
+func (self *HookCommands) List() ([]*models.Hook, error) {
+	hooksPath, err := self.HooksPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return loadHooks(hooksPath)
+}
+
+func loadHooks(hooksPath string) ([]*models.Hook, error) {
+	entries, err := os.ReadDir(hooksPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	hooks := []*models.Hook{}
+	for _, name := range KnownHookNames {
+		index := slices.IndexFunc(entries, func(entry os.DirEntry) bool { return entry.Name() == name })
+		if index == -1 || entries[index].IsDir() {
+			continue
+		}
+
+		info, err := entries[index].Info()
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, &models.Hook{
+			Name:    name,
+			Path:    filepath.Join(hooksPath, name),
+			Enabled: info.Mode()&0o111 != 0,
+		})
+	}
+
+	return hooks, nil
+}
+
+// Enables or disables a hook by setting or clearing its executable bits, which
+// is what git checks to decide whether to run it
+func (self *HookCommands) SetEnabled(hook *models.Hook, enabled bool) error {
+	info, err := os.Stat(hook.Path)
+	if err != nil {
+		return err
+	}
+
+	mode := info.Mode().Perm()
+	if enabled {
+		// make it executable for everyone who can read it
+		mode |= (mode & 0o444) >> 2
+	} else {
+		mode &^= 0o111
+	}
+
+	return os.Chmod(hook.Path, mode)
+}
+
+// Runs the hook directly from the root of the worktree, like git does. The
+// output is the combined output of the hook.
+func (self *HookCommands) RunCmdObj(hook *models.Hook, args []string) *oscommands.CmdObj {
+	return self.cmd.New(append([]string{hook.Path}, args...)).
+		SetWd(self.repoPaths.WorktreePath())
+}
+
+// Makes git write a trace of the command to a file, from which the returned
+// function tells which hook made the command fail once it has finished: it
+// returns the name of the first hook that git ran and that exited with a
+// non-zero code, or "" if there was none. Hooks that aren't executable aren't
+// run, so they never count.
+func (self *HookCommands) TraceFailures(cmdObj *oscommands.CmdObj) func() string {
+	path := filepath.Join(self.repoPaths.WorktreeGitDirPath(), "lazygit-hook-trace")
+	// git appends to the trace file, so we start with an empty one
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		self.Log.Error(err)
+		return func() string { return "" }
+	}
+
+	cmdObj.AddEnvVars("GIT_TRACE2_EVENT=" + path)
+
+	return func() string {
+		defer os.Remove(path)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			self.Log.Error(err)
+			return ""
+		}
+
+		return failedHookFromTrace(content)
+	}
+}
+
+// Parses the events of a trace2 event trace. The git processes that our
+// command spawns (including those run by hooks) write to the same trace, so we
+// only look at the events of the process that wrote the first one.
+func failedHookFromTrace(trace []byte) string {
+	type traceEvent struct {
+		Event      string `json:"event"`
+		Sid        string `json:"sid"`
+		ChildID    int    `json:"child_id"`
+		ChildClass string `json:"child_class"`
+		HookName   string `json:"hook_name"`
+		Code       int    `json:"code"`
+	}
+
+	sid := ""
+	hookNamesByChildID := map[int]string{}
+	for _, line := range bytes.Split(trace, []byte("\n")) {
+		var event traceEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		if sid == "" {
+			sid = event.Sid
+		}
+		if event.Sid != sid {
+			continue
+		}
+
+		switch event.Event {
+		case "child_start":
+			if event.ChildClass == "hook" {
+				hookNamesByChildID[event.ChildID] = event.HookName
+			}
+		case "child_exit":
+			if hookName, ok := hookNamesByChildID[event.ChildID]; ok && event.Code != 0 {
+				return hookName
+			}
+		}
+	}
+
+	return ""
+}
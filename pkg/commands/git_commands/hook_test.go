@@ -0,0 +1,113 @@
+package git_commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+
+	hooks, err := loadHooks(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	for name, mode := range map[string]os.FileMode{
+		"pre-push":          0o755,
+		"pre-commit":        0o644,
+		"pre-commit.sample": 0o755,
+		"not-a-hook":        0o755,
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode))
+	}
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "commit-msg"), 0o755))
+
+	hooks, err = loadHooks(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Hook{
+		{Name: "pre-commit", Path: filepath.Join(dir, "pre-commit"), Enabled: false},
+		{Name: "pre-push", Path: filepath.Join(dir, "pre-push"), Enabled: true},
+	}, hooks)
+}
+
+func TestHookSetEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pre-commit")
+	assert.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o640))
+	hook := &models.Hook{Name: "pre-commit", Path: path}
+
+	instance := buildHookCommands(commonDeps{})
+
+	assert.NoError(t, instance.SetEnabled(hook, true))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o750), info.Mode().Perm())
+
+	assert.NoError(t, instance.SetEnabled(hook, false))
+	info, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm())
+}
+
+func TestFailedHookFromTrace(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		trace    string
+		expected string
+	}{
+		{
+			name:     "empty trace",
+			trace:    "",
+			expected: "",
+		},
+		{
+			name: "hook succeeded, other child failed",
+			trace: `{"event":"version","sid":"top"}
+{"event":"child_start","sid":"top","child_id":0,"child_class":"hook","hook_name":"pre-commit"}
+{"event":"child_exit","sid":"top","child_id":0,"code":0}
+{"event":"child_start","sid":"top","child_id":1,"child_class":"?"}
+{"event":"child_exit","sid":"top","child_id":1,"code":1}
+`,
+			expected: "",
+		},
+		{
+			name: "hook failed",
+			trace: `{"event":"version","sid":"top"}
+{"event":"child_start","sid":"top","child_id":0,"child_class":"hook","hook_name":"pre-commit"}
+{"event":"child_exit","sid":"top","child_id":0,"code":0}
+{"event":"child_start","sid":"top","child_id":1,"child_class":"hook","hook_name":"commit-msg"}
+{"event":"child_exit","sid":"top","child_id":1,"code":1}
+`,
+			expected: "commit-msg",
+		},
+		{
+			name: "hook of a nested git process failed",
+			trace: `{"event":"version","sid":"top"}
+{"event":"child_start","sid":"top","child_id":0,"child_class":"hook","hook_name":"pre-commit"}
+{"event":"version","sid":"top/nested"}
+{"event":"child_start","sid":"top/nested","child_id":0,"child_class":"hook","hook_name":"post-checkout"}
+{"event":"child_exit","sid":"top/nested","child_id":0,"code":1}
+{"event":"child_exit","sid":"top","child_id":0,"code":0}
+`,
+			expected: "",
+		},
+		{
+			name: "unparsable lines are skipped",
+			trace: `{"event":"version","sid":"top"}
+{"event":"child_start","sid":"top","child_id":0,"child_class":"hook","hook_name":"pre-push"}
+{"event":"child_ex
+{"event":"child_exit","sid":"top","child_id":0,"code":1}
+`,
+			expected: "pre-push",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, failedHookFromTrace([]byte(s.trace)))
+		})
+	}
+}
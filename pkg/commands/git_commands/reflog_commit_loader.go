@@ -62,6 +62,20 @@ func (self *ReflogCommitLoader) GetReflogCommits(hashPool *utils.StringPool, las
 	return commits, onlyObtainedNewReflogCommits, nil
 }
 
+// Returns the number of entries in the reflog of HEAD, which is the number of
+// commits that GetReflogCommits returns when not filtering. Unlike the loaded
+// reflog commits, this is never out of date.
+func (self *ReflogCommitLoader) GetReflogCount() (int, error) {
+	cmdArgs := NewGitCmd("rev-list").Arg("--walk-reflogs", "--count", "HEAD").ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(output))
+}
+
 func (self *ReflogCommitLoader) sameReflogCommit(a *models.Commit, b *models.Commit) bool {
 	return a.Hash() == b.Hash() && a.UnixTimestamp == b.UnixTimestamp && a.Name == b.Name
 }
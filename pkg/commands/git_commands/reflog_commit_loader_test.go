@@ -201,3 +201,17 @@ func TestGetReflogCommits(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReflogCount(t *testing.T) {
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-list", "--walk-reflogs", "--count", "HEAD"}, "12\n", nil)
+	builder := &ReflogCommitLoader{
+		Common: common.NewDummyCommon(),
+		cmd:    oscommands.NewDummyCmdObjBuilder(runner),
+	}
+
+	count, err := builder.GetReflogCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, count)
+	runner.CheckForMissingCalls()
+}
@@ -0,0 +1,96 @@
+package git_commands
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Loads summaries of arbitrary repos, not just the current one, for the repo
+// dashboard
+type RepoSummaryLoader struct {
+	*common.Common
+	cmd oscommands.ICmdObjBuilder
+}
+
+func NewRepoSummaryLoader(
+	common *common.Common,
+	cmd oscommands.ICmdObjBuilder,
+) *RepoSummaryLoader {
+	return &RepoSummaryLoader{
+		Common: common,
+		cmd:    cmd,
+	}
+}
+
+func (self *RepoSummaryLoader) Load(path string) *models.RepoSummary {
+	summary := &models.RepoSummary{Path: path, Loaded: true}
+
+	cmdArgs := NewGitCmd("status").
+		Arg("--porcelain=v2", "--branch", "-z").
+		Dir(path).
+		ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		summary.Err = err
+		return summary
+	}
+	parseStatusV2(summary, output)
+
+	cmdArgs = NewGitCmd("stash").Arg("list", "--format=%H").Dir(path).ToArgv()
+	if output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput(); err == nil {
+		summary.StashCount = len(utils.SplitLines(output))
+	}
+
+	if summary.Head != "" {
+		cmdArgs = NewGitCmd("log").Arg("-1", "--format=%ct").Dir(path).ToArgv()
+		if output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput(); err == nil {
+			summary.LastCommitUnixTimestamp, _ = strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+		}
+	}
+
+	return summary
+}
+
+// Parses the output of `git status --porcelain=v2 --branch -z`
+func parseStatusV2(summary *models.RepoSummary, output string) {
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if entry == "" {
+			continue
+		}
+
+		header, isHeader := strings.CutPrefix(entry, "# ")
+		if !isHeader {
+			summary.ChangedFiles++
+			// renames and copies are followed by an entry with the original path
+			if strings.HasPrefix(entry, "2 ") {
+				i++
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(header, " ")
+		switch key {
+		case "branch.oid":
+			if value != "(initial)" {
+				summary.Head = utils.ShortHash(value)
+			}
+		case "branch.head":
+			if value != "(detached)" {
+				summary.Branch = value
+			}
+		case "branch.upstream":
+			summary.HasUpstream = true
+		case "branch.ab":
+			ahead, behind, _ := strings.Cut(value, " ")
+			summary.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+			summary.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package git_commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoSummaryLoader(t *testing.T) {
+	statusArgs := []string{"-C", "/repo", "status", "--porcelain=v2", "--branch", "-z"}
+	stashArgs := []string{"-C", "/repo", "stash", "list", "--format=%H"}
+	logArgs := []string{"-C", "/repo", "log", "-1", "--format=%ct"}
+
+	scenarios := []struct {
+		testName        string
+		runner          *oscommands.FakeCmdObjRunner
+		expectedSummary *models.RepoSummary
+	}{
+		{
+			testName: "clean repo without upstream",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs(statusArgs, "# branch.oid 1234567890abcdef\x00# branch.head main\x00", nil).
+				ExpectGitArgs(stashArgs, "", nil).
+				ExpectGitArgs(logArgs, "1700000000\n", nil),
+			expectedSummary: &models.RepoSummary{
+				Path:                    "/repo",
+				Loaded:                  true,
+				Branch:                  "main",
+				Head:                    "12345678",
+				LastCommitUnixTimestamp: 1700000000,
+			},
+		},
+		{
+			testName: "dirty repo that is ahead and behind, with stashes",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs(statusArgs,
+					"# branch.oid 1234567890abcdef\x00# branch.head feature\x00# branch.upstream origin/feature\x00# branch.ab +2 -3\x00"+
+						"1 .M N... 100644 100644 100644 abc abc file1\x00"+
+						"2 R. N... 100644 100644 100644 abc abc R100 new\x00old\x00"+
+						"? untracked\x00",
+					nil).
+				ExpectGitArgs(stashArgs, "abc\ndef\n", nil).
+				ExpectGitArgs(logArgs, "1700000000\n", nil),
+			expectedSummary: &models.RepoSummary{
+				Path:                    "/repo",
+				Loaded:                  true,
+				Branch:                  "feature",
+				Head:                    "12345678",
+				ChangedFiles:            3,
+				HasUpstream:             true,
+				Ahead:                   2,
+				Behind:                  3,
+				StashCount:              2,
+				LastCommitUnixTimestamp: 1700000000,
+			},
+		},
+		{
+			testName: "detached head",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs(statusArgs, "# branch.oid 1234567890abcdef\x00# branch.head (detached)\x00", nil).
+				ExpectGitArgs(stashArgs, "", nil).
+				ExpectGitArgs(logArgs, "1700000000\n", nil),
+			expectedSummary: &models.RepoSummary{
+				Path:                    "/repo",
+				Loaded:                  true,
+				Head:                    "12345678",
+				LastCommitUnixTimestamp: 1700000000,
+			},
+		},
+		{
+			testName: "repo without commits",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs(statusArgs, "# branch.oid (initial)\x00# branch.head main\x00", nil).
+				ExpectGitArgs(stashArgs, "", nil),
+			expectedSummary: &models.RepoSummary{
+				Path:   "/repo",
+				Loaded: true,
+				Branch: "main",
+			},
+		},
+		{
+			testName: "not a repo",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs(statusArgs, "", errors.New("fatal: not a git repository")),
+			expectedSummary: &models.RepoSummary{
+				Path:   "/repo",
+				Loaded: true,
+				Err:    errors.New("fatal: not a git repository"),
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			loader := NewRepoSummaryLoader(common.NewDummyCommon(), oscommands.NewDummyCmdObjBuilder(s.runner))
+
+			assert.Equal(t, s.expectedSummary, loader.Load("/repo"))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package git_commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/samber/lo"
+)
+
+// The refs that keep the snapshots alive. They are outside of refs/heads and
+// refs/tags so that they don't show up anywhere, but they protect the snapshots
+// from garbage collection.
+const snapshotRefPrefix = "refs/lazygit/snapshots/"
+
+// Snapshots are private objects, so we don't want to require (or use) the
+// user's identity for them
+var snapshotIdentityEnvVars = []string{
+	"GIT_AUTHOR_NAME=lazygit",
+	"GIT_AUTHOR_EMAIL=lazygit@localhost",
+	"GIT_COMMITTER_NAME=lazygit",
+	"GIT_COMMITTER_EMAIL=lazygit@localhost",
+}
+
+// SnapshotCommands save the working tree and index before changes are
+// discarded, so that the user can get them back. A snapshot has the same shape
+// as a stash entry made with `git stash create`, except that its tree also
+// contains the untracked files.
+type SnapshotCommands struct {
+	*GitCommon
+}
+
+func NewSnapshotCommands(gitCommon *GitCommon) *SnapshotCommands {
+	return &SnapshotCommands{
+		GitCommon: gitCommon,
+	}
+}
+
+// Takes a snapshot of the working tree and index unless snapshots are disabled
+// or there are no changes, and then deletes the snapshots that are no longer
+// needed.
+// Errors are only logged, because failing to take a snapshot shouldn't stop the
+// user from discarding their changes.
+func (self *SnapshotCommands) TakeBeforeDiscarding(description string) {
+	self.takeBeforeDiscarding(description, nil)
+}
+
+// Like TakeBeforeDiscarding, but only the working tree changes of the given
+// paths (files or directories) are saved, so that discarding a few files in a
+// big repo doesn't have to hash the whole working tree. The index is still
+// saved as a whole.
+func (self *SnapshotCommands) TakeBeforeDiscardingPaths(description string, paths []string) {
+	self.takeBeforeDiscarding(description, paths)
+}
+
+func (self *SnapshotCommands) takeBeforeDiscarding(description string, paths []string) {
+	if !self.UserConfig().Git.DiscardSnapshots.Enabled {
+		return
+	}
+
+	taken, err := self.take(description, paths)
+	if err != nil {
+		self.Log.Errorf("Failed to take a snapshot before discarding changes: %v", err)
+		return
+	}
+	if !taken {
+		return
+	}
+
+	if err := self.prune(time.Now()); err != nil {
+		self.Log.Error(err)
+	}
+}
+
+// Takes a snapshot of the given paths, or of the whole working tree if paths is
+// nil
+func (self *SnapshotCommands) take(description string, paths []string) (bool, error) {
+	output, err := self.cmd.New(
+		NewGitCmd("rev-parse").Arg("HEAD", "HEAD^{tree}").ToArgv(),
+	).DontLog().RunWithOutput()
+	if err != nil {
+		// there are no commits yet, so there's no commit to base the snapshot on
+		return false, nil
+	}
+	lines := strings.Fields(output)
+	if len(lines) != 2 {
+		return false, fmt.Errorf("unexpected output of rev-parse: %s", output)
+	}
+	head, headTree := lines[0], lines[1]
+
+	indexTree, err := self.writeTree(nil)
+	if err != nil {
+		// the index has conflicts, which can't be written as a tree
+		indexTree = headTree
+	}
+
+	// we add the working tree to a separate index so that the real one stays
+	// untouched
+	tempIndexPath := filepath.Join(self.repoPaths.WorktreeGitDirPath(), "lazygit-snapshot-index")
+	defer os.Remove(tempIndexPath)
+	indexEnvVar := "GIT_INDEX_FILE=" + tempIndexPath
+	if paths == nil {
+		// we start from a copy of the real index to make use of its stat
+		// information
+		if err := oscommands.CopyFile(filepath.Join(self.repoPaths.WorktreeGitDirPath(), "index"), tempIndexPath); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+		if err := self.cmd.New(
+			NewGitCmd("add").Arg("-A").ToArgv(),
+		).AddEnvVars(indexEnvVar).DontLog().Run(); err != nil {
+			return false, err
+		}
+	} else {
+		// we start from HEAD, so that only the given paths differ from it
+		if err := self.cmd.New(
+			NewGitCmd("read-tree").Arg("HEAD").ToArgv(),
+		).AddEnvVars(indexEnvVar).DontLog().Run(); err != nil {
+			return false, err
+		}
+		paths, err = self.pathsToAdd(paths, indexEnvVar)
+		if err != nil {
+			return false, err
+		}
+		if len(paths) > 0 {
+			if err := self.cmd.New(
+				NewGitCmd("add").Arg("-A", "--").Arg(paths...).ToArgv(),
+			).AddEnvVars(indexEnvVar).DontLog().Run(); err != nil {
+				return false, err
+			}
+		}
+	}
+	worktreeTree, err := self.writeTree([]string{indexEnvVar})
+	if err != nil {
+		return false, err
+	}
+
+	if worktreeTree == headTree && indexTree == headTree {
+		return false, nil
+	}
+
+	indexCommit, err := self.commitTree(indexTree, []string{head}, "index on "+description)
+	if err != nil {
+		return false, err
+	}
+	snapshot, err := self.commitTree(worktreeTree, []string{head, indexCommit}, description)
+	if err != nil {
+		return false, err
+	}
+
+	ref := snapshotRefPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+	if err := self.cmd.New(
+		NewGitCmd("update-ref").Arg(ref, snapshot).ToArgv(),
+	).DontLog().Run(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// git add fails for paths that match neither a file in the working tree nor
+// one in the index, which starts out as HEAD. Such paths have nothing in the
+// working tree to save, e.g. a file that was added to the index and then
+// deleted, so we leave them out.
+func (self *SnapshotCommands) pathsToAdd(paths []string, indexEnvVar string) ([]string, error) {
+	existing := []string{}
+	missing := []string{}
+	for _, path := range paths {
+		if _, err := os.Lstat(filepath.Join(self.repoPaths.WorktreePath(), path)); err == nil {
+			existing = append(existing, path)
+		} else {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return paths, nil
+	}
+
+	output, err := self.cmd.New(
+		NewGitCmd("ls-files").Arg("-z", "--").Arg(missing...).ToArgv(),
+	).AddEnvVars(indexEnvVar).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	headPaths := lo.Compact(strings.Split(output, "\x00"))
+
+	inHead := lo.Filter(missing, func(path string, _ int) bool {
+		return lo.ContainsBy(headPaths, func(headPath string) bool {
+			return headPath == path || strings.HasPrefix(headPath, path+"/")
+		})
+	})
+	return append(existing, inHead...), nil
+}
+
+func (self *SnapshotCommands) writeTree(envVars []string) (string, error) {
+	output, err := self.cmd.New(
+		NewGitCmd("write-tree").ToArgv(),
+	).AddEnvVars(envVars...).DontLog().RunWithOutput()
+	return strings.TrimSpace(output), err
+}
+
+func (self *SnapshotCommands) commitTree(tree string, parents []string, message string) (string, error) {
+	cmdArgs := NewGitCmd("commit-tree").Arg(tree)
+	for _, parent := range parents {
+		cmdArgs = cmdArgs.Arg("-p", parent)
+	}
+	cmdArgs = cmdArgs.Arg("-m", message)
+
+	output, err := self.cmd.New(cmdArgs.ToArgv()).
+		AddEnvVars(snapshotIdentityEnvVars...).DontLog().RunWithOutput()
+	return strings.TrimSpace(output), err
+}
+
+// Deletes the snapshots that exceed the configured count or age
+func (self *SnapshotCommands) prune(now time.Time) error {
+	snapshots, err := self.List()
+	if err != nil {
+		return err
+	}
+
+	snapshotsConfig := self.UserConfig().Git.DiscardSnapshots
+	for i, snapshot := range snapshots {
+		tooMany := snapshotsConfig.MaxCount > 0 && i >= snapshotsConfig.MaxCount
+		tooOld := snapshotsConfig.MaxAgeDays > 0 &&
+			now.Sub(time.Unix(snapshot.UnixTimestamp, 0)) > time.Duration(snapshotsConfig.MaxAgeDays)*24*time.Hour
+		if !tooMany && !tooOld {
+			continue
+		}
+
+		if err := self.Delete(snapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the snapshots of the repo, most recent first
+func (self *SnapshotCommands) List() ([]*models.Snapshot, error) {
+	cmdArgs := NewGitCmd("for-each-ref").
+		Arg("--sort=-refname").
+		Arg("--format=%(refname)%00%(objectname)%00%(committerdate:unix)%00%(contents:subject)").
+		Arg(snapshotRefPrefix).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSnapshots(output), nil
+}
+
+func parseSnapshots(output string) []*models.Snapshot {
+	return lo.FilterMap(strings.Split(output, "\n"), func(line string, _ int) (*models.Snapshot, bool) {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) != 4 {
+			return nil, false
+		}
+
+		timestamp, _ := strconv.ParseInt(fields[2], 10, 64)
+		return &models.Snapshot{
+			Ref:           fields[0],
+			Hash:          fields[1],
+			UnixTimestamp: timestamp,
+			Description:   fields[3],
+		}, true
+	})
+}
+
+// Returns the paths of the files that had changes (other than deletions) when
+// the snapshot was taken
+func (self *SnapshotCommands) ChangedFiles(snapshot *models.Snapshot) ([]string, error) {
+	cmdArgs := NewGitCmd("diff-tree").
+		Arg("-r", "--name-only", "--no-renames", "--diff-filter=d", "-z").
+		Arg(snapshot.Hash+"^1", snapshot.Hash).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Compact(strings.Split(output, "\x00")), nil
+}
+
+// Writes the content that the given files had in the snapshot to the working
+// tree, leaving the index alone. Since this overwrites the current content of
+// the files, it takes a snapshot first.
+func (self *SnapshotCommands) Restore(snapshot *models.Snapshot, paths []string) error {
+	// git restore refuses to run without paths, and there is nothing to do anyway
+	if len(paths) == 0 {
+		return nil
+	}
+
+	self.TakeBeforeDiscardingPaths("restore files from snapshot "+snapshot.ShortHash(), paths)
+
+	cmdArgs := NewGitCmd("restore").
+		Arg("--source="+snapshot.Hash, "--worktree", "--").
+		Arg(paths...).
+		ToArgv()
+
+	return self.cmd.New(cmdArgs).Run()
+}
+
+func (self *SnapshotCommands) Delete(snapshot *models.Snapshot) error {
+	cmdArgs := NewGitCmd("update-ref").Arg("-d", snapshot.Ref).ToArgv()
+
+	return self.cmd.New(cmdArgs).DontLog().Run()
+}
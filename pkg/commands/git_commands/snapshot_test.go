@@ -0,0 +1,223 @@
+package git_commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotTakeBeforeDiscarding(t *testing.T) {
+	hasTempIndex := func(cmdObj *oscommands.CmdObj) bool {
+		return slices.ContainsFunc(cmdObj.GetEnvVars(), func(envVar string) bool {
+			return strings.HasPrefix(envVar, "GIT_INDEX_FILE=") && strings.HasSuffix(envVar, "lazygit-snapshot-index")
+		})
+	}
+	expectWorktreeTree := func(runner *oscommands.FakeCmdObjRunner, tree string) *oscommands.FakeCmdObjRunner {
+		return runner.
+			ExpectFunc("git add -A to the temp index", func(cmdObj *oscommands.CmdObj) bool {
+				return slices.Equal(cmdObj.Args()[1:], []string{"add", "-A"}) && hasTempIndex(cmdObj)
+			}, "", nil).
+			ExpectFunc("git write-tree of the temp index", func(cmdObj *oscommands.CmdObj) bool {
+				return slices.Equal(cmdObj.Args()[1:], []string{"write-tree"}) && hasTempIndex(cmdObj)
+			}, tree+"\n", nil)
+	}
+
+	type scenario struct {
+		testName string
+		enabled  bool
+		runner   *oscommands.FakeCmdObjRunner
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "disabled",
+			enabled:  false,
+			runner:   oscommands.NewFakeRunner(t),
+		},
+		{
+			testName: "no commits yet",
+			enabled:  true,
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"rev-parse", "HEAD", "HEAD^{tree}"}, "", errors.New("unknown revision")),
+		},
+		{
+			testName: "no changes",
+			enabled:  true,
+			runner: expectWorktreeTree(
+				oscommands.NewFakeRunner(t).
+					ExpectGitArgs([]string{"rev-parse", "HEAD", "HEAD^{tree}"}, "head\nheadtree\n", nil).
+					ExpectGitArgs([]string{"write-tree"}, "headtree\n", nil),
+				"headtree",
+			),
+		},
+		{
+			testName: "changes and a conflicted index, pruning the oldest snapshot",
+			enabled:  true,
+			runner: expectWorktreeTree(
+				oscommands.NewFakeRunner(t).
+					ExpectGitArgs([]string{"rev-parse", "HEAD", "HEAD^{tree}"}, "head\nheadtree\n", nil).
+					ExpectGitArgs([]string{"write-tree"}, "", errors.New("unmerged entries")),
+				"worktreetree",
+			).
+				ExpectGitArgs([]string{"commit-tree", "headtree", "-p", "head", "-m", "index on discard all changes to file"}, "indexcommit\n", nil).
+				ExpectGitArgs([]string{"commit-tree", "worktreetree", "-p", "head", "-p", "indexcommit", "-m", "discard all changes to file"}, "snapshot\n", nil).
+				ExpectFunc("git update-ref of a new snapshot ref", func(cmdObj *oscommands.CmdObj) bool {
+					args := cmdObj.Args()[1:]
+					return len(args) == 3 && args[0] == "update-ref" &&
+						strings.HasPrefix(args[1], "refs/lazygit/snapshots/") && args[2] == "snapshot"
+				}, "", nil).
+				ExpectGitArgs([]string{"for-each-ref", "--sort=-refname", "--format=%(refname)%00%(objectname)%00%(committerdate:unix)%00%(contents:subject)", "refs/lazygit/snapshots/"},
+					"refs/lazygit/snapshots/3\x00snapshot\x00"+unixNow()+"\x00discard all changes to file\n"+
+						"refs/lazygit/snapshots/2\x00older\x00"+unixNow()+"\x00remove untracked files\n"+
+						"refs/lazygit/snapshots/1\x00oldest\x00"+unixNow()+"\x00nuke working tree\n",
+					nil).
+				ExpectGitArgs([]string{"update-ref", "-d", "refs/lazygit/snapshots/1"}, "", nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitDir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(gitDir, "index"), []byte("index"), 0o644))
+
+			userConfig := config.GetDefaultConfig()
+			userConfig.Git.DiscardSnapshots.Enabled = s.enabled
+			userConfig.Git.DiscardSnapshots.MaxCount = 2
+			repoPaths := RepoPaths{worktreeGitDirPath: gitDir}
+			instance := buildSnapshotCommands(commonDeps{runner: s.runner, userConfig: userConfig, appState: &config.AppState{}, repoPaths: &repoPaths})
+
+			instance.TakeBeforeDiscarding("discard all changes to file")
+			s.runner.CheckForMissingCalls()
+
+			_, err := os.Stat(filepath.Join(gitDir, "lazygit-snapshot-index"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestSnapshotTakeBeforeDiscardingPaths(t *testing.T) {
+	hasTempIndex := func(cmdObj *oscommands.CmdObj) bool {
+		return slices.ContainsFunc(cmdObj.GetEnvVars(), func(envVar string) bool {
+			return strings.HasPrefix(envVar, "GIT_INDEX_FILE=") && strings.HasSuffix(envVar, "lazygit-snapshot-index")
+		})
+	}
+	expectOnTempIndex := func(runner *oscommands.FakeCmdObjRunner, args []string, output string) *oscommands.FakeCmdObjRunner {
+		return runner.ExpectFunc("git "+strings.Join(args, " ")+" on the temp index", func(cmdObj *oscommands.CmdObj) bool {
+			return slices.Equal(cmdObj.Args()[1:], args) && hasTempIndex(cmdObj)
+		}, output, nil)
+	}
+
+	worktreeDir := t.TempDir()
+	gitDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(worktreeDir, "modified"), []byte("content"), 0o644))
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"rev-parse", "HEAD", "HEAD^{tree}"}, "head\nheadtree\n", nil).
+		ExpectGitArgs([]string{"write-tree"}, "indextree\n", nil)
+	runner = expectOnTempIndex(runner, []string{"read-tree", "HEAD"}, "")
+	// "deleted" is in HEAD, "added-then-deleted" only in the real index
+	runner = expectOnTempIndex(runner, []string{"ls-files", "-z", "--", "deleted", "added-then-deleted"}, "deleted\x00")
+	runner = expectOnTempIndex(runner, []string{"add", "-A", "--", "modified", "deleted"}, "")
+	runner = expectOnTempIndex(runner, []string{"write-tree"}, "worktreetree\n").
+		ExpectGitArgs([]string{"commit-tree", "indextree", "-p", "head", "-m", "index on discard changes"}, "indexcommit\n", nil).
+		ExpectGitArgs([]string{"commit-tree", "worktreetree", "-p", "head", "-p", "indexcommit", "-m", "discard changes"}, "snapshot\n", nil).
+		ExpectFunc("git update-ref of a new snapshot ref", func(cmdObj *oscommands.CmdObj) bool {
+			args := cmdObj.Args()[1:]
+			return len(args) == 3 && args[0] == "update-ref" && args[2] == "snapshot"
+		}, "", nil).
+		ExpectGitArgs([]string{"for-each-ref", "--sort=-refname", "--format=%(refname)%00%(objectname)%00%(committerdate:unix)%00%(contents:subject)", "refs/lazygit/snapshots/"}, "", nil)
+
+	userConfig := config.GetDefaultConfig()
+	userConfig.Git.DiscardSnapshots.Enabled = true
+	repoPaths := RepoPaths{worktreePath: worktreeDir, worktreeGitDirPath: gitDir}
+	instance := buildSnapshotCommands(commonDeps{runner: runner, userConfig: userConfig, appState: &config.AppState{}, repoPaths: &repoPaths})
+
+	instance.TakeBeforeDiscardingPaths("discard changes", []string{"modified", "deleted", "added-then-deleted"})
+	runner.CheckForMissingCalls()
+}
+
+func TestSnapshotPruneByAge(t *testing.T) {
+	now := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) string {
+		return unixString(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+
+	runner := oscommands.NewFakeRunner(t).
+		ExpectGitArgs([]string{"for-each-ref", "--sort=-refname", "--format=%(refname)%00%(objectname)%00%(committerdate:unix)%00%(contents:subject)", "refs/lazygit/snapshots/"},
+			"refs/lazygit/snapshots/2\x00recent\x00"+daysAgo(1)+"\x00discard unstaged changes to a\n"+
+				"refs/lazygit/snapshots/1\x00old\x00"+daysAgo(15)+"\x00discard unstaged changes to b\n",
+			nil).
+		ExpectGitArgs([]string{"update-ref", "-d", "refs/lazygit/snapshots/1"}, "", nil)
+
+	userConfig := config.GetDefaultConfig()
+	userConfig.Git.DiscardSnapshots.MaxCount = 0
+	userConfig.Git.DiscardSnapshots.MaxAgeDays = 14
+	instance := buildSnapshotCommands(commonDeps{runner: runner, userConfig: userConfig, appState: &config.AppState{}})
+
+	assert.NoError(t, instance.prune(now))
+	runner.CheckForMissingCalls()
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	snapshot := &models.Snapshot{Ref: "refs/lazygit/snapshots/1", Hash: "snapshot"}
+
+	scenarios := []struct {
+		testName string
+		paths    []string
+		runner   *oscommands.FakeCmdObjRunner
+	}{
+		{
+			testName: "no paths",
+			paths:    []string{},
+			runner:   oscommands.NewFakeRunner(t),
+		},
+		{
+			testName: "some paths",
+			paths:    []string{"a", "dir/b"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"restore", "--source=snapshot", "--worktree", "--", "a", "dir/b"}, "", nil),
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig := config.GetDefaultConfig()
+			userConfig.Git.DiscardSnapshots.Enabled = false
+			instance := buildSnapshotCommands(commonDeps{runner: s.runner, userConfig: userConfig, appState: &config.AppState{}})
+
+			assert.NoError(t, instance.Restore(snapshot, s.paths))
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
+
+func TestParseSnapshots(t *testing.T) {
+	output := "refs/lazygit/snapshots/1700000000000000000\x00abc123\x001700000000\x00hard reset to HEAD\n"
+
+	assert.Equal(t, []*models.Snapshot{
+		{
+			Ref:           "refs/lazygit/snapshots/1700000000000000000",
+			Hash:          "abc123",
+			Description:   "hard reset to HEAD",
+			UnixTimestamp: 1700000000,
+		},
+	}, parseSnapshots(output))
+}
+
+func unixNow() string {
+	return unixString(time.Now())
+}
+
+func unixString(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
@@ -3,6 +3,7 @@ package git_commands
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -92,3 +93,31 @@ func (self *StatusCommands) BranchBeingRebased() string {
 	}
 	return ""
 }
+
+// The number of the rebase step that git is at, and the total number of steps,
+// as shown by `git status`. Returns false if we're not in a rebase.
+func (self *StatusCommands) RebaseProgress() (int, int, bool) {
+	// interactive rebases and merge-based rebases use rebase-merge; the
+	// apply backend (and `git am`) uses rebase-apply
+	for _, files := range [][3]string{{"rebase-merge", "msgnum", "end"}, {"rebase-apply", "next", "last"}} {
+		dir := filepath.Join(self.repoPaths.WorktreeGitDirPath(), files[0])
+		step, err := readIntFile(filepath.Join(dir, files[1]))
+		if err != nil {
+			continue
+		}
+		total, err := readIntFile(filepath.Join(dir, files[2]))
+		if err != nil {
+			continue
+		}
+		return step, total, true
+	}
+	return 0, 0, false
+}
+
+func readIntFile(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
@@ -0,0 +1,71 @@
+package git_commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCommandsRebaseProgress(t *testing.T) {
+	type scenario struct {
+		testName      string
+		files         map[string]string
+		expectedStep  int
+		expectedTotal int
+		expectedOk    bool
+	}
+
+	scenarios := []scenario{
+		{
+			testName:   "not rebasing",
+			files:      map[string]string{},
+			expectedOk: false,
+		},
+		{
+			testName: "interactive rebase",
+			files: map[string]string{
+				"rebase-merge/msgnum": "2\n",
+				"rebase-merge/end":    "5\n",
+			},
+			expectedStep:  2,
+			expectedTotal: 5,
+			expectedOk:    true,
+		},
+		{
+			testName: "apply backend",
+			files: map[string]string{
+				"rebase-apply/next": "1\n",
+				"rebase-apply/last": "3\n",
+			},
+			expectedStep:  1,
+			expectedTotal: 3,
+			expectedOk:    true,
+		},
+		{
+			testName: "interactive rebase that hasn't picked anything yet",
+			files: map[string]string{
+				"rebase-merge/git-rebase-todo": "pick 1234 commit\n",
+			},
+			expectedOk: false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			repoPath := t.TempDir()
+			for path, content := range s.files {
+				fullPath := filepath.Join(repoPath, ".git", path)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+				assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+			}
+
+			instance := NewStatusCommands(buildGitCommon(commonDeps{repoPaths: MockRepoPaths(repoPath)}))
+			step, total, ok := instance.RebaseProgress()
+			assert.Equal(t, s.expectedStep, step)
+			assert.Equal(t, s.expectedTotal, total)
+			assert.Equal(t, s.expectedOk, ok)
+		})
+	}
+}
@@ -26,6 +26,7 @@ type PushOpts struct {
 	UpstreamRemote string
 	UpstreamBranch string
 	SetUpstream    bool
+	NoVerify       bool
 }
 
 func (self *SyncCommands) PushCmdObj(task gocui.Task, opts PushOpts) (*oscommands.CmdObj, error) {
@@ -37,6 +38,7 @@ func (self *SyncCommands) PushCmdObj(task gocui.Task, opts PushOpts) (*oscommand
 		ArgIf(opts.Force, "--force").
 		ArgIf(opts.ForceWithLease, "--force-with-lease").
 		ArgIf(opts.SetUpstream, "--set-upstream").
+		ArgIf(opts.NoVerify, "--no-verify").
 		ArgIf(opts.UpstreamRemote != "", opts.UpstreamRemote).
 		ArgIf(opts.UpstreamBranch != "", fmt.Sprintf("refs/heads/%s:%s", opts.CurrentBranch, opts.UpstreamBranch)).
 		ToArgv()
@@ -87,6 +89,37 @@ func (self *SyncCommands) FetchBackground() error {
 	return self.FetchBackgroundCmdObj().Run()
 }
 
+// Fetches in the repo at the given path, which doesn't need to be the current
+// repo. Since this is used for fetching many repos at once, we can't prompt for
+// credentials.
+func (self *SyncCommands) FetchInRepoCmdObj(path string) *oscommands.CmdObj {
+	cmdArgs := self.fetchCommandBuilder(self.UserConfig().Git.FetchAll).Dir(path).ToArgv()
+
+	return self.inRepoCmdObj(cmdArgs, path).FailOnCredentialRequest()
+}
+
+func (self *SyncCommands) FetchInRepo(path string) error {
+	return self.FetchInRepoCmdObj(path).Run()
+}
+
+// Fast-forwards the checked-out branch of the repo at the given path to its
+// upstream. Like FetchInRepo, this doesn't prompt for credentials.
+func (self *SyncCommands) FastForwardInRepo(path string) error {
+	cmdArgs := NewGitCmd("pull").
+		Arg("--ff-only").
+		Dir(path).
+		ToArgv()
+
+	return self.inRepoCmdObj(cmdArgs, path).FailOnCredentialRequest().Run()
+}
+
+// Runs the command in the repo at the given path, so that it ends up in that
+// repo's command history. The args still pass -C, so that the command log shows
+// which repo it ran in.
+func (self *SyncCommands) inRepoCmdObj(cmdArgs []string, path string) *oscommands.CmdObj {
+	return self.cmd.New(cmdArgs).SetWd(path)
+}
+
 type PullOptions struct {
 	RemoteName      string
 	BranchName      string
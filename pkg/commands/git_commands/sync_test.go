@@ -40,6 +40,14 @@ func TestSyncPush(t *testing.T) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			testName: "Push without running the pre-push hook",
+			opts:     PushOpts{NoVerify: true},
+			test: func(cmdObj *oscommands.CmdObj, err error) {
+				assert.Equal(t, cmdObj.Args(), []string{"git", "push", "--no-verify"})
+				assert.NoError(t, err)
+			},
+		},
 		{
 			testName: "Push with force disabled, upstream supplied",
 			opts: PushOpts{
@@ -151,6 +159,16 @@ func TestSyncFetch(t *testing.T) {
 	}
 }
 
+func TestSyncFetchInRepo(t *testing.T) {
+	instance := buildSyncCommands(commonDeps{})
+	instance.UserConfig().Git.FetchAll = true
+	cmdObj := instance.FetchInRepoCmdObj("/path/to/repo")
+	assert.True(t, cmdObj.ShouldLog())
+	assert.Equal(t, cmdObj.GetCredentialStrategy(), oscommands.FAIL)
+	assert.Equal(t, cmdObj.Args(), []string{"git", "-C", "/path/to/repo", "fetch", "--all", "--no-write-fetch-head"})
+	assert.Equal(t, "/path/to/repo", cmdObj.GetCmd().Dir)
+}
+
 func TestSyncFetchBackground(t *testing.T) {
 	type scenario struct {
 		testName       string
@@ -53,6 +53,17 @@ func (self *TagCommands) LocalDelete(tagName string) error {
 	return self.cmd.New(cmdArgs).Run()
 }
 
+// Returns the hash that the tag's ref points to, which for an annotated tag is
+// the hash of the tag object rather than the commit
+func (self *TagCommands) Hash(tagName string) (string, error) {
+	cmdArgs := NewGitCmd("rev-parse").
+		Arg("refs/tags/" + tagName).
+		ToArgv()
+
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	return strings.TrimSpace(output), err
+}
+
 func (self *TagCommands) Push(task gocui.Task, remoteName string, tagName string) error {
 	cmdArgs := NewGitCmd("push").Arg(remoteName, "tag", tagName).
 		ToArgv()
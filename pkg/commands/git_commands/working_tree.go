@@ -16,17 +16,20 @@ type WorkingTreeCommands struct {
 	*GitCommon
 	submodule  *SubmoduleCommands
 	fileLoader *FileLoader
+	snapshot   *SnapshotCommands
 }
 
 func NewWorkingTreeCommands(
 	gitCommon *GitCommon,
 	submodule *SubmoduleCommands,
 	fileLoader *FileLoader,
+	snapshot *SnapshotCommands,
 ) *WorkingTreeCommands {
 	return &WorkingTreeCommands{
 		GitCommon:  gitCommon,
 		submodule:  submodule,
 		fileLoader: fileLoader,
+		snapshot:   snapshot,
 	}
 }
 
@@ -118,17 +121,23 @@ func (self *WorkingTreeCommands) BeforeAndAfterFileForRename(file *models.File)
 
 // DiscardAllFileChanges directly
 func (self *WorkingTreeCommands) DiscardAllFileChanges(file *models.File) error {
+	self.snapshot.TakeBeforeDiscardingPaths("discard all changes to "+file.Path, file.Names())
+
+	return self.discardAllFileChanges(file)
+}
+
+func (self *WorkingTreeCommands) discardAllFileChanges(file *models.File) error {
 	if file.IsRename() {
 		beforeFile, afterFile, err := self.BeforeAndAfterFileForRename(file)
 		if err != nil {
 			return err
 		}
 
-		if err := self.DiscardAllFileChanges(beforeFile); err != nil {
+		if err := self.discardAllFileChanges(beforeFile); err != nil {
 			return err
 		}
 
-		if err := self.DiscardAllFileChanges(afterFile); err != nil {
+		if err := self.discardAllFileChanges(afterFile); err != nil {
 			return err
 		}
 
@@ -173,7 +182,7 @@ func (self *WorkingTreeCommands) DiscardAllFileChanges(file *models.File) error
 		return self.os.RemoveFile(file.Path)
 	}
 
-	return self.DiscardUnstagedFileChanges(file)
+	return self.discardUnstagedFileChanges(file)
 }
 
 type IFileNode interface {
@@ -185,14 +194,31 @@ type IFileNode interface {
 }
 
 func (self *WorkingTreeCommands) DiscardAllDirChanges(node IFileNode) error {
+	self.snapshot.TakeBeforeDiscardingPaths("discard all changes in "+node.GetPath(), dirSnapshotPaths(node))
+
 	// this could be more efficient but we would need to handle all the edge cases
-	return node.ForEachFile(self.DiscardAllFileChanges)
+	return node.ForEachFile(self.discardAllFileChanges)
+}
+
+// The paths to take a snapshot of before discarding the changes in a directory:
+// the directory itself, plus the old paths of files that were renamed into it
+func dirSnapshotPaths(node IFileNode) []string {
+	paths := []string{node.GetPath()}
+	_ = node.ForEachFile(func(file *models.File) error {
+		if file.IsRename() {
+			paths = append(paths, file.PreviousPath)
+		}
+		return nil
+	})
+	return paths
 }
 
 func (self *WorkingTreeCommands) DiscardUnstagedDirChanges(node IFileNode) error {
+	self.snapshot.TakeBeforeDiscardingPaths("discard unstaged changes in "+node.GetPath(), dirSnapshotPaths(node))
+
 	file := node.GetFile()
 	if file == nil {
-		if err := self.RemoveUntrackedDirFiles(node); err != nil {
+		if err := self.removeUntrackedDirFiles(node); err != nil {
 			return err
 		}
 
@@ -205,7 +231,7 @@ func (self *WorkingTreeCommands) DiscardUnstagedDirChanges(node IFileNode) error
 			return self.os.RemoveFile(file.Path)
 		}
 
-		if err := self.DiscardUnstagedFileChanges(file); err != nil {
+		if err := self.discardUnstagedFileChanges(file); err != nil {
 			return err
 		}
 	}
@@ -213,7 +239,7 @@ func (self *WorkingTreeCommands) DiscardUnstagedDirChanges(node IFileNode) error
 	return nil
 }
 
-func (self *WorkingTreeCommands) RemoveUntrackedDirFiles(node IFileNode) error {
+func (self *WorkingTreeCommands) removeUntrackedDirFiles(node IFileNode) error {
 	untrackedFilePaths := node.GetFilePathsMatching(
 		func(file *models.File) bool { return !file.GetIsTracked() },
 	)
@@ -229,6 +255,12 @@ func (self *WorkingTreeCommands) RemoveUntrackedDirFiles(node IFileNode) error {
 }
 
 func (self *WorkingTreeCommands) DiscardUnstagedFileChanges(file *models.File) error {
+	self.snapshot.TakeBeforeDiscardingPaths("discard unstaged changes to "+file.Path, file.Names())
+
+	return self.discardUnstagedFileChanges(file)
+}
+
+func (self *WorkingTreeCommands) discardUnstagedFileChanges(file *models.File) error {
 	cmdArgs := NewGitCmd("checkout").Arg("--", file.Path).ToArgv()
 	return self.cmd.New(cmdArgs).Run()
 }
@@ -270,7 +302,7 @@ func (self *WorkingTreeCommands) WorktreeFileDiffCmdObj(node models.IFile, plain
 		colorArg = "never"
 	}
 
-	contextSize := self.UserConfig().Git.DiffContextSize
+	contextSize := self.DiffContextSize()
 	prevPath := node.GetPreviousPath()
 	noIndex := !node.GetIsTracked() && !node.GetHasStagedChanges() && !cached && node.GetIsFile()
 	extDiffCmd := self.pagerConfig.GetExternalDiffCommand()
@@ -309,7 +341,7 @@ func (self *WorkingTreeCommands) ShowFileDiff(from string, to string, reverse bo
 }
 
 func (self *WorkingTreeCommands) ShowFileDiffCmdObj(from string, to string, reverse bool, fileNames []string, plain bool) *oscommands.CmdObj {
-	contextSize := self.UserConfig().Git.DiffContextSize
+	contextSize := self.DiffContextSize()
 
 	colorArg := self.pagerConfig.GetColorArg()
 	if plain {
@@ -350,6 +382,8 @@ func (self *WorkingTreeCommands) CheckoutFile(commitHash, fileName string) error
 
 // DiscardAnyUnstagedFileChanges discards any unstaged file changes via `git checkout -- .`
 func (self *WorkingTreeCommands) DiscardAnyUnstagedFileChanges() error {
+	self.snapshot.TakeBeforeDiscarding("discard all unstaged changes")
+
 	cmdArgs := NewGitCmd("checkout").Arg("--", ".").
 		ToArgv()
 
@@ -365,6 +399,8 @@ func (self *WorkingTreeCommands) RemoveTrackedFiles(name string) error {
 }
 
 func (self *WorkingTreeCommands) RemoveConflictedFile(name string) error {
+	self.snapshot.TakeBeforeDiscardingPaths("remove conflicted file "+name, []string{name})
+
 	cmdArgs := NewGitCmd("rm").Arg("--", name).
 		ToArgv()
 
@@ -373,6 +409,12 @@ func (self *WorkingTreeCommands) RemoveConflictedFile(name string) error {
 
 // RemoveUntrackedFiles runs `git clean -fd`
 func (self *WorkingTreeCommands) RemoveUntrackedFiles() error {
+	self.snapshot.TakeBeforeDiscarding("remove untracked files")
+
+	return self.removeUntrackedFiles()
+}
+
+func (self *WorkingTreeCommands) removeUntrackedFiles() error {
 	cmdArgs := NewGitCmd("clean").Arg("-fd").ToArgv()
 
 	return self.cmd.New(cmdArgs).Run()
@@ -380,6 +422,8 @@ func (self *WorkingTreeCommands) RemoveUntrackedFiles() error {
 
 // ResetAndClean removes all unstaged changes and removes all untracked files
 func (self *WorkingTreeCommands) ResetAndClean() error {
+	self.snapshot.TakeBeforeDiscarding("nuke working tree")
+
 	submoduleConfigs, err := self.submodule.GetConfigs(nil)
 	if err != nil {
 		return err
@@ -391,15 +435,21 @@ func (self *WorkingTreeCommands) ResetAndClean() error {
 		}
 	}
 
-	if err := self.ResetHard("HEAD"); err != nil {
+	if err := self.resetHard("HEAD"); err != nil {
 		return err
 	}
 
-	return self.RemoveUntrackedFiles()
+	return self.removeUntrackedFiles()
 }
 
 // ResetHard runs `git reset --hard`
 func (self *WorkingTreeCommands) ResetHard(ref string) error {
+	self.snapshot.TakeBeforeDiscarding("hard reset to " + ref)
+
+	return self.resetHard(ref)
+}
+
+func (self *WorkingTreeCommands) resetHard(ref string) error {
 	cmdArgs := NewGitCmd("reset").Arg("--hard", ref).
 		ToArgv()
 
@@ -479,3 +529,70 @@ func (self *WorkingTreeCommands) AllRepoFiles() ([]string, error) {
 	}
 	return strings.Split(strings.TrimRight(output, "\x00"), "\x00"), nil
 }
+
+// Like AllRepoFiles, but returns the files in the tree of the given ref
+func (self *WorkingTreeCommands) AllFilesAtRef(ref string) ([]string, error) {
+	cmdArgs := NewGitCmd("ls-tree").Arg("-r", "--name-only", "-z", ref).ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return []string{}, nil
+	}
+	return strings.Split(strings.TrimRight(output, "\x00"), "\x00"), nil
+}
+
+// Returns the untracked directories that are ignored as a whole, relative to
+// the repo root and without a trailing slash. Ignored files in directories
+// that aren't ignored are not returned.
+func (self *WorkingTreeCommands) IgnoredDirectories() ([]string, error) {
+	cmdArgs := NewGitCmd("ls-files").
+		Arg("-z", "--others", "--ignored", "--exclude-standard", "--directory").
+		ToArgv()
+	output, err := self.cmd.New(cmdArgs).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, path := range strings.Split(output, "\x00") {
+		if dir, ok := strings.CutSuffix(path, "/"); ok {
+			result = append(result, dir)
+		}
+	}
+	return result, nil
+}
+
+// Returns those of the given paths (relative to the repo root) that are
+// ignored
+func (self *WorkingTreeCommands) IgnoredPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return []string{}, nil
+	}
+
+	// With --non-matching, check-ignore outputs a record for every path, and
+	// doesn't fail if none of them are ignored
+	cmdArgs := NewGitCmd("check-ignore").
+		Arg("-z", "--stdin", "--verbose", "--non-matching").
+		ToArgv()
+	output, err := self.cmd.New(cmdArgs).
+		SetStdin(strings.Join(paths, "\x00") + "\x00").
+		DontLog().
+		RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	// each record is made up of the source, line number, pattern and path
+	result := []string{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+3 < len(fields); i += 4 {
+		pattern, path := fields[i+2], fields[i+3]
+		// a negated pattern means that the path is explicitly not ignored
+		if pattern != "" && !strings.HasPrefix(pattern, "!") {
+			result = append(result, path)
+		}
+	}
+	return result, nil
+}
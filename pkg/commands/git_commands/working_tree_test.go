@@ -189,7 +189,7 @@ func TestWorkingTreeDiscardAllFileChanges(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, removeFile: s.removeFile})
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, userConfig: userConfigWithoutSnapshots(), appState: &config.AppState{}, removeFile: s.removeFile})
 			err := instance.DiscardAllFileChanges(s.file)
 
 			if s.expectedError == "" {
@@ -475,7 +475,7 @@ func TestWorkingTreeDiscardUnstagedFileChanges(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, userConfig: userConfigWithoutSnapshots(), appState: &config.AppState{}})
 			s.test(instance.DiscardUnstagedFileChanges(s.file))
 			s.runner.CheckForMissingCalls()
 		})
@@ -502,7 +502,7 @@ func TestWorkingTreeDiscardAnyUnstagedFileChanges(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, userConfig: userConfigWithoutSnapshots(), appState: &config.AppState{}})
 			s.test(instance.DiscardAnyUnstagedFileChanges())
 			s.runner.CheckForMissingCalls()
 		})
@@ -529,7 +529,7 @@ func TestWorkingTreeRemoveUntrackedFiles(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, userConfig: userConfigWithoutSnapshots(), appState: &config.AppState{}})
 			s.test(instance.RemoveUntrackedFiles())
 			s.runner.CheckForMissingCalls()
 		})
@@ -558,7 +558,7 @@ func TestWorkingTreeResetHard(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner, userConfig: userConfigWithoutSnapshots(), appState: &config.AppState{}})
 			s.test(instance.ResetHard(s.ref))
 		})
 	}
@@ -592,3 +592,105 @@ func TestWorkingTreeCommands_AllRepoFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkingTreeCommands_AllFilesAtRef(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		runner   *oscommands.FakeCmdObjRunner
+		expected []string
+	}{
+		{
+			name: "no files",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"ls-tree", "-r", "--name-only", "-z", "v1.0"}, "", nil),
+			expected: []string{},
+		},
+		{
+			name: "two files",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"ls-tree", "-r", "--name-only", "-z", "v1.0"}, "dir/file1.txt\x00dir2/file2.go\x00", nil),
+			expected: []string{"dir/file1.txt", "dir2/file2.go"},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			result, err := instance.AllFilesAtRef("v1.0")
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, result)
+		})
+	}
+}
+
+// The snapshots that are taken before discarding changes are covered by
+// snapshot_test.go
+func userConfigWithoutSnapshots() *config.UserConfig {
+	userConfig := config.GetDefaultConfig()
+	userConfig.Git.DiscardSnapshots.Enabled = false
+	return userConfig
+}
+
+func TestWorkingTreeCommands_IgnoredDirectories(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		runner   *oscommands.FakeCmdObjRunner
+		expected []string
+	}{
+		{
+			name: "nothing ignored",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory"}, "", nil),
+			expected: []string{},
+		},
+		{
+			name: "ignored files and directories",
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory"}, "build/\x00debug.log\x00src/node_modules/\x00", nil),
+			expected: []string{"build", "src/node_modules"},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			result, err := instance.IgnoredDirectories()
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, result)
+		})
+	}
+}
+
+func TestWorkingTreeCommands_IgnoredPaths(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		paths    []string
+		runner   *oscommands.FakeCmdObjRunner
+		expected []string
+	}{
+		{
+			name:     "no paths",
+			paths:    []string{},
+			runner:   oscommands.NewFakeRunner(t),
+			expected: []string{},
+		},
+		{
+			name:  "some paths ignored",
+			paths: []string{"main.go", "debug.log", "keep.log"},
+			runner: oscommands.NewFakeRunner(t).
+				ExpectGitArgs([]string{"check-ignore", "-z", "--stdin", "--verbose", "--non-matching"},
+					"\x00\x00\x00main.go\x00"+
+						".gitignore\x001\x00*.log\x00debug.log\x00"+
+						".gitignore\x002\x00!keep.log\x00keep.log\x00",
+					nil),
+			expected: []string{"debug.log"},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			instance := buildWorkingTreeCommands(commonDeps{runner: s.runner})
+			result, err := instance.IgnoredPaths(s.paths)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, result)
+			s.runner.CheckForMissingCalls()
+		})
+	}
+}
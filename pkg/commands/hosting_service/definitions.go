@@ -17,6 +17,9 @@ var githubServiceDef = ServiceDefinition{
 	commitURL:                       "/commit/{{.CommitHash}}",
 	regexStrings:                    defaultUrlRegexStrings,
 	repoURLTemplate:                 defaultRepoURLTemplate,
+	createPullRequest:               createGithubPullRequest,
+	authHeaders:                     githubAuthHeaders,
+	defaultTokenEnvVar:              "GITHUB_TOKEN",
 }
 
 var bitbucketServiceDef = ServiceDefinition{
@@ -38,6 +41,9 @@ var gitLabServiceDef = ServiceDefinition{
 	commitURL:                       "/-/commit/{{.CommitHash}}",
 	regexStrings:                    defaultUrlRegexStrings,
 	repoURLTemplate:                 defaultRepoURLTemplate,
+	createPullRequest:               createGitlabMergeRequest,
+	authHeaders:                     gitlabAuthHeaders,
+	defaultTokenEnvVar:              "GITLAB_TOKEN",
 }
 
 var azdoServiceDef = ServiceDefinition{
@@ -73,6 +79,9 @@ var giteaServiceDef = ServiceDefinition{
 	commitURL:                       "/commit/{{.CommitHash}}",
 	regexStrings:                    defaultUrlRegexStrings,
 	repoURLTemplate:                 defaultRepoURLTemplate,
+	createPullRequest:               createGiteaPullRequest,
+	authHeaders:                     giteaAuthHeaders,
+	defaultTokenEnvVar:              "GITEA_TOKEN",
 }
 
 var codebergServiceDef = ServiceDefinition{
@@ -82,6 +91,9 @@ var codebergServiceDef = ServiceDefinition{
 	commitURL:                       "/commit/{{.CommitHash}}",
 	regexStrings:                    defaultUrlRegexStrings,
 	repoURLTemplate:                 defaultRepoURLTemplate,
+	createPullRequest:               createGiteaPullRequest,
+	authHeaders:                     giteaAuthHeaders,
+	defaultTokenEnvVar:              "CODEBERG_TOKEN",
 }
 
 var serviceDefinitions = []ServiceDefinition{
@@ -1,9 +1,11 @@
 package hosting_service
 
 import (
+	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
@@ -26,6 +28,8 @@ type HostingServiceMgr struct {
 
 	// see https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#custom-pull-request-urls
 	configServiceDomains map[string]string
+
+	httpClient *http.Client
 }
 
 // NewHostingServiceMgr creates new instance of PullRequest
@@ -35,6 +39,7 @@ func NewHostingServiceMgr(log logrus.FieldLogger, tr *i18n.TranslationSet, remot
 		tr:                   tr,
 		remoteURL:            remoteURL,
 		configServiceDomains: configServiceDomains,
+		httpClient:           &http.Client{Timeout: 30 * time.Second},
 	}
 }
 
@@ -61,6 +66,53 @@ func (self *HostingServiceMgr) GetCommitURL(commitHash string) (string, error) {
 	return pullRequestURL, nil
 }
 
+// Returns the name of the environment variable that we look at for an API token
+// if the user hasn't configured one, or an empty string if the hosting service
+// doesn't support creating pull requests through its API.
+func (self *HostingServiceMgr) GetDefaultTokenEnvVar() (string, error) {
+	serviceDomain, err := self.getServiceDomain(self.remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	if serviceDomain.serviceDefinition.createPullRequest == nil {
+		return "", nil
+	}
+
+	return serviceDomain.serviceDefinition.defaultTokenEnvVar, nil
+}
+
+// Creates a pull request through the hosting service's API and returns the URL
+// of the new pull request.
+func (self *HostingServiceMgr) CreatePullRequest(pr PullRequest, token string) (string, error) {
+	serviceDomain, err := self.getServiceDomain(self.remoteURL)
+	if err != nil {
+		return "", err
+	}
+
+	definition := serviceDomain.serviceDefinition
+	if definition.createPullRequest == nil {
+		return "", errors.New(self.tr.UnsupportedGitService)
+	}
+
+	matches, err := definition.parseRemoteURL(self.remoteURL, serviceDomain.webDomain)
+	if err != nil {
+		return "", err
+	}
+
+	client := &apiClient{
+		httpClient: self.httpClient,
+		headers:    definition.authHeaders(token),
+	}
+	repo := &repoInfo{
+		webDomain: serviceDomain.webDomain,
+		owner:     matches["owner"],
+		repo:      matches["repo"],
+	}
+
+	return definition.createPullRequest(client, repo, pr)
+}
+
 func (self *HostingServiceMgr) getService() (*Service, error) {
 	serviceDomain, err := self.getServiceDomain(self.remoteURL)
 	if err != nil {
@@ -145,19 +197,33 @@ type ServiceDefinition struct {
 
 	// can expect 'webdomain' to be passed in. Otherwise, you get to pick what we match in the regex
 	repoURLTemplate string
+
+	// only set for services that support creating pull requests through their API
+	createPullRequest  pullRequestCreator
+	authHeaders        func(token string) map[string]string
+	defaultTokenEnvVar string
 }
 
 func (self ServiceDefinition) getRepoURLFromRemoteURL(url string, webDomain string) (string, error) {
+	input, err := self.parseRemoteURL(url, webDomain)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ResolvePlaceholderString(self.repoURLTemplate, input), nil
+}
+
+func (self ServiceDefinition) parseRemoteURL(url string, webDomain string) (map[string]string, error) {
 	for _, regexStr := range self.regexStrings {
 		re := regexp.MustCompile(regexStr)
 		input := utils.FindNamedMatches(re, url)
 		if input != nil {
 			input["webDomain"] = webDomain
-			return utils.ResolvePlaceholderString(self.repoURLTemplate, input), nil
+			return input, nil
 		}
 	}
 
-	return "", errors.New("Failed to parse repo information from url")
+	return nil, errors.New("Failed to parse repo information from url")
 }
 
 type Service struct {
@@ -1,6 +1,10 @@
 package hosting_service
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/jesseduffield/lazygit/pkg/fakes"
@@ -577,3 +581,164 @@ func TestGetPullRequestURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatePullRequest(t *testing.T) {
+	type request struct {
+		method  string
+		path    string
+		headers http.Header
+		body    map[string]any
+	}
+
+	type scenario struct {
+		testName         string
+		provider         string
+		pullRequest      PullRequest
+		responses        map[string]string
+		expectedURL      string
+		expectedRequests func(t *testing.T, requests []request)
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "Creates a draft pull request on github and requests reviewers",
+			provider: "github",
+			pullRequest: PullRequest{
+				From:      "feature/sum-operation",
+				To:        "main",
+				Title:     "Add sum operation",
+				Body:      "Adds a sum operation",
+				Draft:     true,
+				Reviewers: []string{"jane"},
+			},
+			responses: map[string]string{
+				"/api/v3/repos/peter/calculator/pulls":                        `{"number": 12, "html_url": "https://github.com/peter/calculator/pull/12"}`,
+				"/api/v3/repos/peter/calculator/pulls/12/requested_reviewers": `{}`,
+			},
+			expectedURL: "https://github.com/peter/calculator/pull/12",
+			expectedRequests: func(t *testing.T, requests []request) {
+				assert.Len(t, requests, 2)
+				assert.Equal(t, "POST", requests[0].method)
+				assert.Equal(t, "Bearer secret", requests[0].headers.Get("Authorization"))
+				assert.Equal(t, map[string]any{
+					"title": "Add sum operation",
+					"body":  "Adds a sum operation",
+					"head":  "feature/sum-operation",
+					"base":  "main",
+					"draft": true,
+				}, requests[0].body)
+				assert.Equal(t, map[string]any{"reviewers": []any{"jane"}}, requests[1].body)
+			},
+		},
+		{
+			testName: "Creates a merge request on gitlab",
+			provider: "gitlab",
+			pullRequest: PullRequest{
+				From:  "feature/sum-operation",
+				To:    "main",
+				Title: "Add sum operation",
+				Draft: true,
+			},
+			responses: map[string]string{
+				"/api/v4/projects/peter/calculator/merge_requests": `{"web_url": "https://gitlab.com/peter/calculator/-/merge_requests/3"}`,
+			},
+			expectedURL: "https://gitlab.com/peter/calculator/-/merge_requests/3",
+			expectedRequests: func(t *testing.T, requests []request) {
+				assert.Len(t, requests, 1)
+				assert.Equal(t, "secret", requests[0].headers.Get("PRIVATE-TOKEN"))
+				assert.Equal(t, "Draft: Add sum operation", requests[0].body["title"])
+				assert.Equal(t, "feature/sum-operation", requests[0].body["source_branch"])
+				assert.Equal(t, "main", requests[0].body["target_branch"])
+			},
+		},
+		{
+			testName: "Creates a pull request on gitea",
+			provider: "gitea",
+			pullRequest: PullRequest{
+				From:  "feature/sum-operation",
+				To:    "main",
+				Title: "Add sum operation",
+			},
+			responses: map[string]string{
+				"/api/v1/repos/peter/calculator/pulls": `{"number": 5, "html_url": "https://gitea.example.com/peter/calculator/pulls/5"}`,
+			},
+			expectedURL: "https://gitea.example.com/peter/calculator/pulls/5",
+			expectedRequests: func(t *testing.T, requests []request) {
+				assert.Len(t, requests, 1)
+				assert.Equal(t, "token secret", requests[0].headers.Get("Authorization"))
+				assert.Equal(t, "Add sum operation", requests[0].body["title"])
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			requests := []request{}
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body := map[string]any{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requests = append(requests, request{method: r.Method, path: r.URL.Path, headers: r.Header, body: body})
+
+				response, ok := s.responses[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(response))
+			}))
+			defer server.Close()
+
+			webDomain := strings.TrimPrefix(server.URL, "https://")
+			tr := i18n.EnglishTranslationSet()
+			log := &fakes.FakeFieldLogger{}
+			hostingServiceMgr := NewHostingServiceMgr(log, tr, "git@git.example.com:peter/calculator.git",
+				map[string]string{"git.example.com": s.provider + ":" + webDomain})
+			hostingServiceMgr.httpClient = server.Client()
+
+			url, err := hostingServiceMgr.CreatePullRequest(s.pullRequest, "secret")
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedURL, url)
+			s.expectedRequests(t, requests)
+			log.AssertErrors(t, nil)
+		})
+	}
+}
+
+func TestCreatePullRequestReportsApiErrors(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message": "Validation Failed", "errors": [{"message": "A pull request already exists for peter:feature."}]}`))
+	}))
+	defer server.Close()
+
+	webDomain := strings.TrimPrefix(server.URL, "https://")
+	hostingServiceMgr := NewHostingServiceMgr(&fakes.FakeFieldLogger{}, i18n.EnglishTranslationSet(), "git@git.example.com:peter/calculator.git",
+		map[string]string{"git.example.com": "github:" + webDomain})
+	hostingServiceMgr.httpClient = server.Client()
+
+	_, err := hostingServiceMgr.CreatePullRequest(PullRequest{From: "feature", To: "main", Title: "Feature"}, "secret")
+	assert.EqualError(t, err, "422 Unprocessable Entity: Validation Failed; A pull request already exists for peter:feature.")
+}
+
+func TestGetDefaultTokenEnvVar(t *testing.T) {
+	scenarios := []struct {
+		remoteUrl string
+		expected  string
+	}{
+		{remoteUrl: "git@github.com:peter/calculator.git", expected: "GITHUB_TOKEN"},
+		{remoteUrl: "git@gitlab.com:peter/calculator.git", expected: "GITLAB_TOKEN"},
+		{remoteUrl: "git@codeberg.org:peter/calculator.git", expected: "CODEBERG_TOKEN"},
+		{remoteUrl: "git@bitbucket.org:peter/calculator.git", expected: ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.remoteUrl, func(t *testing.T) {
+			hostingServiceMgr := NewHostingServiceMgr(&fakes.FakeFieldLogger{}, i18n.EnglishTranslationSet(), s.remoteUrl, nil)
+			envVar, err := hostingServiceMgr.GetDefaultTokenEnvVar()
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, envVar)
+		})
+	}
+}
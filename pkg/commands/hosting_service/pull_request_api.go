@@ -0,0 +1,237 @@
+package hosting_service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// This file contains the logic for creating pull requests through a hosting
+// service's REST API, as opposed to opening the 'compare' page in the browser.
+// Only some services support this; for the others (and when no API token is
+// available) we fall back to the browser URL.
+
+type PullRequest struct {
+	From      string
+	To        string
+	Title     string
+	Body      string
+	Draft     bool
+	Reviewers []string
+}
+
+type pullRequestCreator func(client *apiClient, repo *repoInfo, pr PullRequest) (string, error)
+
+// the parts of the remote URL that we need for talking to the API
+type repoInfo struct {
+	webDomain string
+	owner     string
+	repo      string
+}
+
+type apiClient struct {
+	httpClient *http.Client
+	// headers that are sent with every request, used for authentication
+	headers map[string]string
+}
+
+func (self *apiClient) do(method string, url string, payload any, response any) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range self.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := self.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New(apiErrorMessage(resp.Status, respBody))
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, response)
+}
+
+// Services report errors in slightly different shapes; we try to pick out the
+// human-readable part and fall back to the raw body otherwise.
+func apiErrorMessage(status string, body []byte) string {
+	var parsed struct {
+		Message any `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		details := []string{}
+		if parsed.Message != nil {
+			details = append(details, fmt.Sprint(parsed.Message))
+		}
+		for _, e := range parsed.Errors {
+			if e.Message != "" {
+				details = append(details, e.Message)
+			}
+		}
+		if len(details) > 0 {
+			return status + ": " + strings.Join(details, "; ")
+		}
+	}
+
+	return status + ": " + strings.TrimSpace(string(body))
+}
+
+func githubApiURL(webDomain string) string {
+	if webDomain == "github.com" {
+		return "https://api.github.com"
+	}
+	// GitHub Enterprise
+	return "https://" + webDomain + "/api/v3"
+}
+
+func createGithubPullRequest(client *apiClient, repo *repoInfo, pr PullRequest) (string, error) {
+	repoApiURL := fmt.Sprintf("%s/repos/%s/%s", githubApiURL(repo.webDomain), repo.owner, repo.repo)
+
+	var created struct {
+		Number  int    `json:"number"`
+		HtmlURL string `json:"html_url"`
+	}
+	err := client.do("POST", repoApiURL+"/pulls", map[string]any{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"head":  pr.From,
+		"base":  pr.To,
+		"draft": pr.Draft,
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	if len(pr.Reviewers) > 0 {
+		err := client.do("POST", fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoApiURL, created.Number), map[string]any{
+			"reviewers": pr.Reviewers,
+		}, nil)
+		if err != nil {
+			return created.HtmlURL, err
+		}
+	}
+
+	return created.HtmlURL, nil
+}
+
+func createGitlabMergeRequest(client *apiClient, repo *repoInfo, pr PullRequest) (string, error) {
+	apiURL := "https://" + repo.webDomain + "/api/v4"
+
+	// GitLab wants user IDs rather than usernames for reviewers
+	reviewerIds := make([]int, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		var users []struct {
+			Id int `json:"id"`
+		}
+		if err := client.do("GET", apiURL+"/users?username="+url.QueryEscape(reviewer), nil, &users); err != nil {
+			return "", err
+		}
+		if len(users) == 0 {
+			return "", fmt.Errorf("Unknown GitLab user '%s'", reviewer)
+		}
+		reviewerIds = append(reviewerIds, users[0].Id)
+	}
+
+	title := pr.Title
+	if pr.Draft {
+		title = "Draft: " + title
+	}
+
+	var created struct {
+		WebURL string `json:"web_url"`
+	}
+	projectPath := url.PathEscape(repo.owner + "/" + repo.repo)
+	err := client.do("POST", apiURL+"/projects/"+projectPath+"/merge_requests", map[string]any{
+		"source_branch": pr.From,
+		"target_branch": pr.To,
+		"title":         title,
+		"description":   pr.Body,
+		"reviewer_ids":  reviewerIds,
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	return created.WebURL, nil
+}
+
+func createGiteaPullRequest(client *apiClient, repo *repoInfo, pr PullRequest) (string, error) {
+	repoApiURL := fmt.Sprintf("https://%s/api/v1/repos/%s/%s", repo.webDomain, repo.owner, repo.repo)
+
+	title := pr.Title
+	if pr.Draft {
+		title = "WIP: " + title
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HtmlURL string `json:"html_url"`
+	}
+	err := client.do("POST", repoApiURL+"/pulls", map[string]any{
+		"title": title,
+		"body":  pr.Body,
+		"head":  pr.From,
+		"base":  pr.To,
+	}, &created)
+	if err != nil {
+		return "", err
+	}
+
+	if len(pr.Reviewers) > 0 {
+		err := client.do("POST", fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoApiURL, created.Number), map[string]any{
+			"reviewers": pr.Reviewers,
+		}, nil)
+		if err != nil {
+			return created.HtmlURL, err
+		}
+	}
+
+	return created.HtmlURL, nil
+}
+
+func githubAuthHeaders(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func gitlabAuthHeaders(token string) map[string]string {
+	return map[string]string{"PRIVATE-TOKEN": token}
+}
+
+func giteaAuthHeaders(token string) map[string]string {
+	return map[string]string{"Authorization": "token " + token}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// CommandHistoryEntry : a command that was shown in the command log, as saved
+// in the command history of a repo
+type CommandHistoryEntry struct {
+	// The worktree path of the repo that was open when the command was run
+	Repo string `json:"repo"`
+	// The command as shown in the command log; only meant for display
+	Command string `json:"command"`
+	// The args the command was run with, including the executable. Empty for
+	// entries that were saved by older versions, which can't be re-run.
+	Args []string `json:"args,omitempty"`
+	// The directory the command was run in
+	Dir string `json:"dir,omitempty"`
+	// Whether the args run a command string in a shell
+	Shell      bool      `json:"shell,omitempty"`
+	Time       time.Time `json:"time"`
+	DurationMs int64     `json:"durationMs"`
+	// -1 if the command could not be started
+	ExitCode int `json:"exitCode"`
+	// Only saved if enabled in the config
+	Output string `json:"output,omitempty"`
+}
+
+func (e *CommandHistoryEntry) ID() string {
+	return strconv.FormatInt(e.Time.UnixNano(), 10)
+}
+
+func (e *CommandHistoryEntry) URN() string {
+	return "command-" + e.ID()
+}
+
+func (e *CommandHistoryEntry) Description() string {
+	return e.Command
+}
+
+func (e *CommandHistoryEntry) Succeeded() bool {
+	return e.ExitCode == 0
+}
+
+func (e *CommandHistoryEntry) Duration() time.Duration {
+	return time.Duration(e.DurationMs) * time.Millisecond
+}
@@ -0,0 +1,18 @@
+package models
+
+// CustomPanelItem : An item of a user-defined custom panel, produced by the
+// panel's command
+type CustomPanelItem struct {
+	Label string
+	Value string
+	// The named groups of the panel's filter, or the fields of the JSON object
+	Fields map[string]string
+}
+
+func (i *CustomPanelItem) ID() string {
+	return i.Value
+}
+
+func (i *CustomPanelItem) Description() string {
+	return i.Label
+}
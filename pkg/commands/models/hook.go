@@ -0,0 +1,21 @@
+package models
+
+// Hook : a git hook in the effective hooks directory of the repo
+type Hook struct {
+	Name string
+	Path string
+	// Git only runs hooks that are executable
+	Enabled bool
+}
+
+func (h *Hook) ID() string {
+	return h.Name
+}
+
+func (h *Hook) URN() string {
+	return "hook-" + h.ID()
+}
+
+func (h *Hook) Description() string {
+	return h.Name
+}
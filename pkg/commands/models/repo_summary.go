@@ -0,0 +1,55 @@
+package models
+
+import "path/filepath"
+
+// RepoSummary : the state of a repo as shown in the repo dashboard
+type RepoSummary struct {
+	Path string
+	// False until the summary has been loaded
+	Loaded bool
+	// Set if the summary couldn't be loaded, e.g. because the repo was deleted
+	Err error
+
+	// Empty on a detached head
+	Branch string
+	// The short hash of HEAD; empty if there are no commits yet
+	Head string
+	// The number of changed (including untracked) files
+	ChangedFiles int
+	HasUpstream  bool
+	Ahead        int
+	Behind       int
+	StashCount   int
+	// Zero if there are no commits yet
+	LastCommitUnixTimestamp int64
+}
+
+func (r *RepoSummary) Name() string {
+	return filepath.Base(r.Path)
+}
+
+func (r *RepoSummary) ID() string {
+	return r.Path
+}
+
+func (r *RepoSummary) URN() string {
+	return "repo-" + r.ID()
+}
+
+func (r *RepoSummary) Description() string {
+	return r.Path
+}
+
+func (r *RepoSummary) IsDirty() bool {
+	return r.ChangedFiles > 0
+}
+
+// Whether pulling would fast-forward the checked-out branch to its upstream
+func (r *RepoSummary) IsFastForwardable() bool {
+	return r.Loaded && r.Err == nil && r.Branch != "" && r.HasUpstream && r.Behind > 0 && r.Ahead == 0
+}
+
+// Whether the repo has anything that may need the user's attention
+func (r *RepoSummary) NeedsAttention() bool {
+	return r.Err != nil || r.IsDirty() || r.Ahead > 0 || r.Behind > 0 || r.StashCount > 0
+}
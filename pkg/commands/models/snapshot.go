@@ -0,0 +1,20 @@
+package models
+
+import "github.com/jesseduffield/lazygit/pkg/utils"
+
+// Snapshot : a copy of the working tree and index that was taken before
+// discarding changes, so that they can be recovered
+type Snapshot struct {
+	// The private ref that keeps the snapshot alive
+	Ref string
+	// The hash of a stash-like commit whose tree is the working tree (including
+	// untracked files) and whose second parent holds the index
+	Hash string
+	// What was about to be discarded when the snapshot was taken
+	Description   string
+	UnixTimestamp int64
+}
+
+func (s *Snapshot) ShortHash() string {
+	return utils.ShortHash(s.Hash)
+}
@@ -0,0 +1,42 @@
+package models
+
+type UndoJournalEntryKind string
+
+const (
+	UndoJournalDeleteBranches UndoJournalEntryKind = "deleteBranches"
+	UndoJournalDeleteTag      UndoJournalEntryKind = "deleteTag"
+	UndoJournalDropStashes    UndoJournalEntryKind = "dropStashes"
+	// Marks that the most recent action that hadn't been undone yet was undone
+	UndoJournalUndo UndoJournalEntryKind = "undo"
+	// Marks that the most recently undone action was redone
+	UndoJournalRedo UndoJournalEntryKind = "redo"
+)
+
+// An action that isn't recorded in the reflog, so that lazygit has to keep
+// track of it itself in order to be able to undo it
+type UndoJournalEntry struct {
+	Kind UndoJournalEntryKind `json:"kind"`
+	// The number of entries that the HEAD reflog had when the action was done.
+	// This tells us where the action goes in relation to the reflog entries.
+	ReflogCount int `json:"reflogCount"`
+	// The refs that were deleted
+	Refs []*UndoJournalRef `json:"refs,omitempty"`
+}
+
+type UndoJournalRef struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+	// only for branches
+	UpstreamRemote string `json:"upstreamRemote,omitempty"`
+	UpstreamBranch string `json:"upstreamBranch,omitempty"`
+	// only for stash entries
+	Message string `json:"message,omitempty"`
+}
+
+func (self *UndoJournalEntry) RefNames() []string {
+	names := make([]string, 0, len(self.Refs))
+	for _, ref := range self.Refs {
+		names = append(names, ref.Name)
+	}
+	return names
+}
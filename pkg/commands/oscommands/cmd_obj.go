@@ -31,6 +31,9 @@ type CmdObj struct {
 	// see IgnoreEmptyError()
 	ignoreEmptyError bool
 
+	// true if the command was created with NewShell
+	shell bool
+
 	// if set to true, it means we might be asked to enter a username/password by this command.
 	credentialStrategy CredentialStrategy
 	task               gocui.Task
@@ -95,6 +98,12 @@ func (self *CmdObj) GetEnvVars() []string {
 	return self.cmd.Env
 }
 
+// This returns true if the command was created with NewShell, i.e. its args
+// run a command string in the platform's shell
+func (self *CmdObj) IsShell() bool {
+	return self.shell
+}
+
 // sets the working directory
 func (self *CmdObj) SetWd(wd string) *CmdObj {
 	self.cmd.Dir = wd
@@ -51,7 +51,9 @@ func (self *CmdObjBuilder) NewShell(commandStr string, shellFunctionsFile string
 	quotedCommand := self.quotedCommandString(commandStr)
 	cmdArgs := str.ToArgv(fmt.Sprintf("%s %s %s", self.platform.Shell, self.platform.ShellArg, quotedCommand))
 
-	return self.New(cmdArgs)
+	cmdObj := self.New(cmdArgs)
+	cmdObj.shell = true
+	return cmdObj
 }
 
 func (self *CmdObjBuilder) quotedCommandString(commandStr string) string {
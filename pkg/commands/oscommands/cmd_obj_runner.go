@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -111,6 +112,7 @@ func (self *cmdObjRunner) RunWithOutputAux(cmdObj *CmdObj) (string, error) {
 	}
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	self.reportFinishedCmd(cmdObj, t, output)
 
 	return output, err
 }
@@ -136,6 +138,7 @@ func (self *cmdObjRunner) RunWithOutputsAux(cmdObj *CmdObj) (string, string, err
 	if err != nil {
 		self.log.WithField("command", cmdObj.ToString()).Error(stderr)
 	}
+	self.reportFinishedCmd(cmdObj, t, stdout+stderr)
 
 	return stdout, stderr, err
 }
@@ -188,6 +191,8 @@ func (self *cmdObjRunner) RunAndProcessLines(cmdObj *CmdObj, onLine func(line st
 	_ = cmd.Wait()
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	// the output has been consumed line by line, so we don't report it
+	self.reportFinishedCmd(cmdObj, t, "")
 
 	return nil
 }
@@ -196,6 +201,30 @@ func (self *cmdObjRunner) logCmdObj(cmdObj *CmdObj) {
 	self.guiIO.logCommandFn(cmdObj.ToString(), true)
 }
 
+// Tells the gui that a command which was shown in the command log has finished,
+// so that it can be added to the command history
+func (self *cmdObjRunner) reportFinishedCmd(cmdObj *CmdObj, start time.Time, output string) {
+	if !cmdObj.ShouldLog() {
+		return
+	}
+
+	exitCode := -1
+	if state := cmdObj.GetCmd().ProcessState; state != nil {
+		exitCode = state.ExitCode()
+	}
+
+	self.guiIO.cmdFinishedFn(CmdResult{
+		Command:  cmdObj.ToString(),
+		Args:     cmdObj.Args(),
+		Dir:      cmdObj.GetCmd().Dir,
+		Shell:    cmdObj.IsShell(),
+		Start:    start,
+		Duration: time.Since(start),
+		ExitCode: exitCode,
+		Output:   output,
+	})
+}
+
 func sanitisedCommandOutput(output []byte, err error) (string, error) {
 	outputString := string(output)
 	if err != nil {
@@ -209,6 +238,18 @@ func sanitisedCommandOutput(output []byte, err error) (string, error) {
 	return outputString, nil
 }
 
+// The error of a streamed command that failed. Its message is the command's
+// stderr, but some commands print relevant details to stdout too (e.g. git
+// passes the stdout of some hooks through), so we keep the whole output.
+type StreamedCmdError struct {
+	message string
+	Output  string
+}
+
+func (self *StreamedCmdError) Error() string {
+	return self.message
+}
+
 type cmdHandler struct {
 	stdoutPipe io.Reader
 	stdinPipe  io.Writer
@@ -271,6 +312,7 @@ func (self *cmdObjRunner) runAndStreamAux(
 	err = cmd.Wait()
 
 	self.log.Infof("%s (%s)", cmdObj.ToString(), time.Since(t))
+	self.reportFinishedCmd(cmdObj, t, stdout.String()+stderr.String())
 
 	if err != nil {
 		if cmdObj.suppressOutputUnlessError {
@@ -279,7 +321,7 @@ func (self *cmdObjRunner) runAndStreamAux(
 
 		errStr := stderr.String()
 		if errStr != "" {
-			return errors.New(errStr)
+			return &StreamedCmdError{message: errStr, Output: stdout.String() + errStr}
 		}
 
 		if cmdObj.ShouldIgnoreEmptyError() {
@@ -342,13 +384,37 @@ func (self *cmdObjRunner) runAndDetectCredentialRequest(
 	// setting the output to english so we can parse it for a username/password request
 	cmdObj.AddEnvVars("LANG=C", "LC_ALL=C", "LC_MESSAGES=C")
 
-	return self.runAndStreamAux(cmdObj, func(handler *cmdHandler, cmdWriter io.Writer) {
+	var credentialsRequested atomic.Bool
+	promptFn := func(askFor CredentialType) <-chan string {
+		credentialsRequested.Store(true)
+		return promptUserForCredential(askFor)
+	}
+
+	err := self.runAndStreamAux(cmdObj, func(handler *cmdHandler, cmdWriter io.Writer) {
 		tr := io.TeeReader(handler.stdoutPipe, cmdWriter)
 
 		go utils.Safe(func() {
-			self.processOutput(tr, handler.stdinPipe, promptUserForCredential, handler.close, cmdObj)
+			self.processOutput(tr, handler.stdinPipe, promptFn, handler.close, cmdObj)
 		})
 	})
+	if err != nil && credentialsRequested.Load() {
+		return &CredentialsRequestedError{err: err}
+	}
+	return err
+}
+
+// The error of a command that failed after asking for credentials, which
+// usually means that the credentials were wrong
+type CredentialsRequestedError struct {
+	err error
+}
+
+func (self *CredentialsRequestedError) Error() string {
+	return self.err.Error()
+}
+
+func (self *CredentialsRequestedError) Unwrap() error {
+	return self.err
 }
 
 func (self *cmdObjRunner) processOutput(
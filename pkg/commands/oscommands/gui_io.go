@@ -2,6 +2,7 @@ package oscommands
 
 import (
 	"io"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -27,6 +28,29 @@ type guiIO struct {
 	// that a command requests it.
 	// the 'credential' arg is something like 'username' or 'password'
 	promptForCredentialFn func(credential CredentialType) <-chan string
+	// this is called whenever a command that was passed to logCommandFn has
+	// finished, so that the GUI can keep a history of the commands it ran.
+	cmdFinishedFn func(result CmdResult)
+}
+
+// The outcome of a command that was shown in the command log
+type CmdResult struct {
+	// the command as shown in the command log
+	Command string
+	// the args the command was run with, including the executable
+	Args []string
+	// the directory the command was run in. Empty if it was run in the current
+	// directory.
+	Dir string
+	// true if the command was run in a shell (see CmdObj.IsShell)
+	Shell    bool
+	Start    time.Time
+	Duration time.Duration
+	// -1 if the command could not be started
+	ExitCode int
+	// the combined stdout and stderr of the command. Empty for commands whose
+	// output is processed line by line.
+	Output string
 }
 
 func NewGuiIO(
@@ -34,12 +58,14 @@ func NewGuiIO(
 	logCommandFn func(string, bool),
 	newCmdWriterFn func() io.Writer,
 	promptForCredentialFn func(CredentialType) <-chan string,
+	cmdFinishedFn func(CmdResult),
 ) *guiIO {
 	return &guiIO{
 		log:                   log,
 		logCommandFn:          logCommandFn,
 		newCmdWriterFn:        newCmdWriterFn,
 		promptForCredentialFn: promptForCredentialFn,
+		cmdFinishedFn:         cmdFinishedFn,
 	}
 }
 
@@ -51,5 +77,6 @@ func NewNullGuiIO(log *logrus.Entry) *guiIO {
 		logCommandFn:          func(string, bool) {},
 		newCmdWriterFn:        func() io.Writer { return io.Discard },
 		promptForCredentialFn: failPromptFn,
+		cmdFinishedFn:         func(CmdResult) {},
 	}
 }
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -82,20 +84,7 @@ func NewAppConfig(
 		return nil, err
 	}
 
-	var configFiles []*ConfigFile
-	customConfigFiles := os.Getenv("LG_CONFIG_FILE")
-	if customConfigFiles != "" {
-		// Load user defined config files
-		userConfigPaths := strings.Split(customConfigFiles, ",")
-		configFiles = lo.Map(userConfigPaths, func(path string, _ int) *ConfigFile {
-			return &ConfigFile{Path: path, Policy: ConfigFilePolicyErrorIfMissing}
-		})
-	} else {
-		// Load default config files
-		path := filepath.Join(configDir, ConfigFilename)
-		configFile := &ConfigFile{Path: path, Policy: ConfigFilePolicyCreateIfMissing}
-		configFiles = []*ConfigFile{configFile}
-	}
+	configFiles := globalConfigFiles(configDir)
 
 	userConfig, err := loadUserConfigWithDefaults(configFiles, false)
 	if err != nil {
@@ -124,6 +113,26 @@ func NewAppConfig(
 	return appConfig, nil
 }
 
+func globalConfigFiles(configDir string) []*ConfigFile {
+	customConfigFiles := os.Getenv("LG_CONFIG_FILE")
+	if customConfigFiles != "" {
+		// Load user defined config files
+		userConfigPaths := strings.Split(customConfigFiles, ",")
+		return lo.Map(userConfigPaths, func(path string, _ int) *ConfigFile {
+			return &ConfigFile{Path: path, Policy: ConfigFilePolicyErrorIfMissing}
+		})
+	}
+
+	// Load default config files
+	path := filepath.Join(configDir, ConfigFilename)
+	return []*ConfigFile{{Path: path, Policy: ConfigFilePolicyCreateIfMissing}}
+}
+
+// Returns the global config files that lazygit loads on startup
+func GlobalConfigFiles() []*ConfigFile {
+	return globalConfigFiles(ConfigDir())
+}
+
 func ConfigDir() string {
 	_, filePath := findConfigFile(ConfigFilename)
 
@@ -189,20 +198,30 @@ func loadUserConfig(configFiles []*ConfigFile, base *UserConfig, isGuiInitialize
 			return nil, err
 		}
 
-		existingCustomCommands := base.CustomCommands
-
-		if err := yaml.Unmarshal(content, base); err != nil {
-			return nil, fmt.Errorf("The config at `%s` couldn't be parsed, please inspect it before opening up an issue.\n%w", path, err)
+		if err := MergeUserConfig(base, path, content); err != nil {
+			return nil, err
 		}
+	}
+
+	return base, nil
+}
 
-		base.CustomCommands = append(base.CustomCommands, existingCustomCommands...)
+// Applies the content of a config file on top of the given config, and
+// validates the result
+func MergeUserConfig(base *UserConfig, path string, content []byte) error {
+	existingCustomCommands := base.CustomCommands
 
-		if err := base.Validate(); err != nil {
-			return nil, fmt.Errorf("The config at `%s` has a validation error.\n%w", path, err)
-		}
+	if err := yaml.Unmarshal(content, base); err != nil {
+		return fmt.Errorf("The config at `%s` couldn't be parsed, please inspect it before opening up an issue.\n%w", path, err)
 	}
 
-	return base, nil
+	base.CustomCommands = append(base.CustomCommands, existingCustomCommands...)
+
+	if err := base.Validate(); err != nil {
+		return fmt.Errorf("The config at `%s` has a validation error.\n%w", path, err)
+	}
+
+	return nil
 }
 
 type ChangesSet = orderedset.OrderedSet[string]
@@ -251,6 +270,24 @@ func migrateUserConfig(path string, content []byte, isGuiInitialized bool) ([]by
 	return changedContent, nil
 }
 
+// Returns the content of a config file with the backward-compatibility
+// migrations applied, along with a description of each change. Unlike
+// migrateUserConfig, this doesn't write the migrated content back to the file.
+func ComputeConfigMigrations(path string, content []byte) ([]byte, []string, error) {
+	changes := NewChangesSet()
+
+	changedContent, didChange, err := computeMigratedConfig(path, content, changes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !didChange {
+		return content, nil, nil
+	}
+
+	return changedContent, changes.ToSliceFromOldest(), nil
+}
+
 // A pure function helper for testing purposes
 func computeMigratedConfig(path string, content []byte, changes *ChangesSet) ([]byte, bool, error) {
 	var err error
@@ -704,6 +741,29 @@ type AppState struct {
 	ShellCommandsHistory []string `yaml:"customcommandshistory"`
 
 	HideCommandLog bool
+
+	// The name of the theme chosen in the theme menu; empty means gui.theme
+	Theme string
+
+	// The state of the gui when a repo was last left, keyed by the path of its
+	// worktree, so that it can be restored when the repo is opened again
+	RepoSessions map[string]*RepoSession `yaml:"reposessions,omitempty"`
+}
+
+// RepoSession is the part of the gui state of a repo that is remembered between
+// runs of the app
+type RepoSession struct {
+	// The key of the focused side context, e.g. "localBranches"
+	Context            string   `yaml:"context,omitempty"`
+	SelectedBranch     string   `yaml:"selectedbranch,omitempty"`
+	SelectedCommitHash string   `yaml:"selectedcommithash,omitempty"`
+	CollapsedPaths     []string `yaml:"collapsedpaths,omitempty"`
+	FilterPath         string   `yaml:"filterpath,omitempty"`
+	FilterAuthor       string   `yaml:"filterauthor,omitempty"`
+	// One of "normal", "half" or "full"
+	ScreenMode string `yaml:"screenmode,omitempty"`
+	// Only set if the diff context size was changed in the gui
+	DiffContextSize *uint64 `yaml:"diffcontextsize,omitempty"`
 }
 
 func getDefaultAppState() *AppState {
@@ -717,3 +777,10 @@ func LogPath() (string, error) {
 
 	return stateFilePath("development.log")
 }
+
+// CommandHistoryPath returns the path of the file that the command history of
+// the repo at the given path is saved to
+func CommandHistoryPath(repoPath string) (string, error) {
+	hash := sha256.Sum256([]byte(repoPath))
+	return stateFilePath(filepath.Join("command_history", hex.EncodeToString(hash[:8])+".jsonl"))
+}
@@ -78,7 +78,32 @@ var LabelByKey = map[gocui.Key]string{
 
 var KeyByLabel = lo.Invert(LabelByKey)
 
+// Splits a key sequence like "g b" or "<space> p r" into its keys. A key
+// that isn't a sequence is returned as is.
+func SplitKeySequence(key string) []string {
+	if _, ok := KeyByLabel[strings.ToLower(key)]; ok {
+		// some labels contain spaces, e.g. "mouse wheel up"
+		return []string{key}
+	}
+
+	keys := strings.Fields(key)
+	if len(keys) < 2 {
+		return []string{key}
+	}
+	return keys
+}
+
 func isValidKeybindingKey(key string) bool {
+	if keys := SplitKeySequence(key); len(keys) > 1 {
+		return lo.EveryBy(keys, func(key string) bool {
+			return key != "<disabled>" && isValidSingleKey(key)
+		})
+	}
+
+	return isValidSingleKey(key)
+}
+
+func isValidSingleKey(key string) bool {
 	runeCount := utf8.RuneCountInString(key)
 	if key == "<disabled>" {
 		return true
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The themes that are available without defining them
+func builtInThemes() map[string]ThemeConfig {
+	return map[string]ThemeConfig{
+		"dark": {
+			ActiveBorderColor:               []string{"green", "bold"},
+			InactiveBorderColor:             []string{"white"},
+			SearchingActiveBorderColor:      []string{"cyan", "bold"},
+			OptionsTextColor:                []string{"blue"},
+			SelectedLineBgColor:             []string{"blue"},
+			InactiveViewSelectedLineBgColor: []string{"bold"},
+			CherryPickedCommitBgColor:       []string{"cyan"},
+			CherryPickedCommitFgColor:       []string{"blue"},
+			MarkedBaseCommitBgColor:         []string{"yellow"},
+			MarkedBaseCommitFgColor:         []string{"blue"},
+			UnstagedChangesColor:            []string{"red"},
+			DefaultFgColor:                  []string{"white"},
+		},
+		"light": {
+			ActiveBorderColor:               []string{"blue", "bold"},
+			InactiveBorderColor:             []string{"black"},
+			SearchingActiveBorderColor:      []string{"magenta", "bold"},
+			OptionsTextColor:                []string{"blue"},
+			SelectedLineBgColor:             []string{"cyan"},
+			InactiveViewSelectedLineBgColor: []string{"bold"},
+			CherryPickedCommitBgColor:       []string{"yellow"},
+			CherryPickedCommitFgColor:       []string{"black"},
+			MarkedBaseCommitBgColor:         []string{"green"},
+			MarkedBaseCommitFgColor:         []string{"black"},
+			UnstagedChangesColor:            []string{"red"},
+			DefaultFgColor:                  []string{"black"},
+		},
+		"high-contrast": {
+			ActiveBorderColor:               []string{"yellow", "bold"},
+			InactiveBorderColor:             []string{"white"},
+			SearchingActiveBorderColor:      []string{"cyan", "bold"},
+			OptionsTextColor:                []string{"white", "bold"},
+			SelectedLineBgColor:             []string{"reverse"},
+			InactiveViewSelectedLineBgColor: []string{"underline"},
+			CherryPickedCommitBgColor:       []string{"magenta"},
+			CherryPickedCommitFgColor:       []string{"white"},
+			MarkedBaseCommitBgColor:         []string{"yellow"},
+			MarkedBaseCommitFgColor:         []string{"black"},
+			UnstagedChangesColor:            []string{"red", "bold"},
+			DefaultFgColor:                  []string{"white"},
+		},
+	}
+}
+
+// Returns all named themes: the built-in ones, the ones in the 'themes'
+// directory of the config dir (one per yaml file, named after the file), and
+// the ones in the user config, in increasing order of precedence when names
+// clash. Colors that a named theme doesn't set are taken from gui.theme.
+func LoadThemes(userConfig *UserConfig, configDir string) (map[string]ThemeConfig, error) {
+	themes := builtInThemes()
+
+	themesDir := filepath.Join(configDir, "themes")
+	entries, err := os.ReadDir(themesDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(themesDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var theme ThemeConfig
+		if err := yaml.Unmarshal(content, &theme); err != nil {
+			return nil, fmt.Errorf("Error in theme file %s: %w", entry.Name(), err)
+		}
+		themes[strings.TrimSuffix(entry.Name(), ext)] = theme
+	}
+
+	for name, theme := range userConfig.Gui.Themes {
+		themes[name] = theme
+	}
+
+	for name, theme := range themes {
+		themes[name] = mergeThemes(userConfig.Gui.Theme, theme)
+	}
+
+	return themes, nil
+}
+
+// Returns the theme with the given name, or gui.theme if the name is empty or
+// there's no such theme (e.g. because its file has been deleted)
+func GetTheme(userConfig *UserConfig, configDir string, name string) (ThemeConfig, error) {
+	if name == "" {
+		return userConfig.Gui.Theme, nil
+	}
+
+	themes, err := LoadThemes(userConfig, configDir)
+	if err != nil {
+		return userConfig.Gui.Theme, err
+	}
+
+	theme, ok := themes[name]
+	if !ok {
+		return userConfig.Gui.Theme, nil
+	}
+	return theme, nil
+}
+
+// Returns overrides, with the colors that it doesn't set taken from base
+func mergeThemes(base ThemeConfig, overrides ThemeConfig) ThemeConfig {
+	result := overrides
+	resultValue := reflect.ValueOf(&result).Elem()
+	baseValue := reflect.ValueOf(base)
+	for i := range resultValue.NumField() {
+		if resultValue.Field(i).Len() == 0 {
+			resultValue.Field(i).Set(baseValue.Field(i))
+		}
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadThemes(t *testing.T) {
+	configDir := t.TempDir()
+	themesDir := filepath.Join(configDir, "themes")
+	assert.NoError(t, os.Mkdir(themesDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(themesDir, "solarized.yml"), []byte("activeBorderColor: [yellow]\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(themesDir, "light.yaml"), []byte("defaultFgColor: ['#333333']\n"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(themesDir, "notes.txt"), []byte("not a theme"), 0o644))
+
+	userConfig := GetDefaultConfig()
+	userConfig.Gui.Themes = map[string]ThemeConfig{
+		"solarized": {ActiveBorderColor: []string{"cyan"}},
+		"mine":      {UnstagedChangesColor: []string{"magenta"}},
+	}
+
+	themes, err := LoadThemes(userConfig, configDir)
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"dark", "light", "high-contrast", "solarized", "mine"}, lo.Keys(themes))
+
+	// the config takes precedence over the themes dir
+	assert.Equal(t, []string{"cyan"}, themes["solarized"].ActiveBorderColor)
+	// the themes dir takes precedence over the built-in themes, and unset
+	// colors are taken from gui.theme
+	assert.Equal(t, []string{"#333333"}, themes["light"].DefaultFgColor)
+	assert.Equal(t, userConfig.Gui.Theme.ActiveBorderColor, themes["light"].ActiveBorderColor)
+	assert.Equal(t, []string{"magenta"}, themes["mine"].UnstagedChangesColor)
+	assert.Equal(t, userConfig.Gui.Theme.SelectedLineBgColor, themes["mine"].SelectedLineBgColor)
+}
+
+func TestLoadThemesInvalidFile(t *testing.T) {
+	configDir := t.TempDir()
+	themesDir := filepath.Join(configDir, "themes")
+	assert.NoError(t, os.Mkdir(themesDir, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(themesDir, "broken.yml"), []byte("activeBorderColor: [yellow\n"), 0o644))
+
+	_, err := LoadThemes(GetDefaultConfig(), configDir)
+	assert.ErrorContains(t, err, "Error in theme file broken.yml")
+}
+
+func TestGetTheme(t *testing.T) {
+	userConfig := GetDefaultConfig()
+	configDir := t.TempDir()
+
+	theme, err := GetTheme(userConfig, configDir, "")
+	assert.NoError(t, err)
+	assert.Equal(t, userConfig.Gui.Theme, theme)
+
+	theme, err = GetTheme(userConfig, configDir, "no-such-theme")
+	assert.NoError(t, err)
+	assert.Equal(t, userConfig.Gui.Theme, theme)
+
+	theme, err = GetTheme(userConfig, configDir, "high-contrast")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"reverse"}, theme.SelectedLineBgColor)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/karimkhaleel/jsonschema"
@@ -15,6 +17,10 @@ type UserConfig struct {
 	Update UpdateConfig `yaml:"update"`
 	// Background refreshes
 	Refresher RefresherConfig `yaml:"refresher"`
+	// The overview of several repos that is opened from the status panel
+	RepoDashboard RepoDashboardConfig `yaml:"repoDashboard"`
+	// The history of the commands shown in the command log, which is saved per repo
+	CommandHistory CommandHistoryConfig `yaml:"commandHistory"`
 	// If true, show a confirmation popup before quitting Lazygit
 	ConfirmOnQuit bool `yaml:"confirmOnQuit"`
 	// If true, exit Lazygit when the user presses escape in a context where there is nothing to cancel/close
@@ -26,6 +32,15 @@ type UserConfig struct {
 	// User-configured commands that can be invoked from within Lazygit
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Custom_Command_Keybindings.md
 	CustomCommands []CustomCommand `yaml:"customCommands" jsonschema:"uniqueItems=true"`
+	// User-defined panels that show the output of a command as a list
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Custom_Panels.md
+	CustomPanels []CustomPanel `yaml:"customPanels"`
+	// Executables that extend Lazygit. They are started when opening a repo and talk to Lazygit via JSON-RPC on stdin/stdout.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Plugins.md
+	Plugins []PluginConfig `yaml:"plugins"`
+	// Lets editors and scripts control a running Lazygit through a Unix socket.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Remote_Control.md
+	RemoteControl RemoteControlConfig `yaml:"remoteControl"`
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#custom-pull-request-urls
 	Services map[string]string `yaml:"services"`
 	// What to do when opening Lazygit outside of a git repo.
@@ -41,7 +56,11 @@ type UserConfig struct {
 }
 
 type RefresherConfig struct {
-	// File/submodule refresh interval in seconds.
+	// If true, refresh when files in the work tree or refs in the git dir change, instead of polling every refreshInterval seconds. Ignored files don't cause a refresh.
+	// Lazygit falls back to polling if it can't watch all directories of the repo, e.g. because the OS limit on watches has been reached.
+	// Auto-refresh can be disabled via option 'git.autoRefresh'.
+	WatchFiles bool `yaml:"watchFiles"`
+	// File/submodule refresh interval in seconds, when not watching files.
 	// Auto-refresh can be disabled via option 'git.autoRefresh'.
 	RefreshInterval int `yaml:"refreshInterval" jsonschema:"minimum=0"`
 	// Re-fetch interval in seconds.
@@ -57,6 +76,20 @@ func (c *RefresherConfig) FetchIntervalDuration() time.Duration {
 	return time.Second * time.Duration(c.FetchInterval)
 }
 
+type RepoDashboardConfig struct {
+	// Directories whose immediate subdirectories are shown in the repo dashboard if they are git repos, in addition to the recent repos. A leading '~' stands for the home directory.
+	RootDirectories []string `yaml:"rootDirectories"`
+}
+
+type CommandHistoryConfig struct {
+	// If true, every command shown in the command log is saved to a history file of the repo, together with its time, duration and exit code
+	Enabled bool `yaml:"enabled"`
+	// If true, the output of the commands is saved too. Output longer than 10,000 characters is truncated.
+	SaveOutput bool `yaml:"saveOutput"`
+	// The number of commands after which the history file is rotated. The previous file is kept, so up to twice as many commands are available.
+	MaxEntries int `yaml:"maxEntries" jsonschema:"minimum=1"`
+}
+
 type GuiConfig struct {
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#custom-author-color
 	AuthorColors map[string]string `yaml:"authorColors"`
@@ -112,6 +145,9 @@ type GuiConfig struct {
 	// - 'left': split the window horizontally (side panel on the left, main view on the right)
 	// - 'top': split the window vertically (side panel on top, main view below)
 	EnlargedSideViewLocation string `yaml:"enlargedSideViewLocation"`
+	// Which side windows are shown, in which order, and how they are arranged.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#side-window-layout
+	SideWindowLayout SideWindowLayoutConfig `yaml:"sideWindowLayout"`
 	// If true, wrap lines in the staging view to the width of the view. This makes it much easier to work with diffs that have long lines, e.g. paragraphs of markdown text.
 	WrapLinesInStagingView bool `yaml:"wrapLinesInStagingView"`
 	// If true, hunk selection mode will be enabled by default when entering the staging view.
@@ -127,6 +163,10 @@ type GuiConfig struct {
 	// Config relating to colors and styles.
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#color-attributes
 	Theme ThemeConfig `yaml:"theme"`
+	// Named themes that can be chosen from the theme menu in the status panel, in addition to the built-in 'light', 'dark', and 'high-contrast' themes and the ones in the 'themes' directory of the config dir.
+	// Colors that a named theme doesn't set are taken from 'theme'.
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#themes
+	Themes map[string]ThemeConfig `yaml:"themes"`
 	// Config relating to the commit length indicator
 	CommitLength CommitLengthConfig `yaml:"commitLength"`
 	// If true, show the '5 of 20' footer at the bottom of list views
@@ -235,6 +275,46 @@ type CommitLengthConfig struct {
 	Show bool `yaml:"show"`
 }
 
+type SideWindowLayoutConfig struct {
+	// Where the side windows are shown relative to the main view.
+	// One of 'left' (default) | 'right' | 'top'
+	Position string `yaml:"position" jsonschema:"enum=left,enum=right,enum=top"`
+	// The side windows to show, from top to bottom. Windows that are neither listed here nor in the tabs of a listed window are hidden.
+	Windows []SideWindowConfig `yaml:"windows"`
+}
+
+type SideWindowConfig struct {
+	// The name of the window
+	Window string `yaml:"window" json:"window" jsonschema:"enum=status,enum=files,enum=branches,enum=commits,enum=stash"`
+	// The height of the window relative to the other windows. Defaults to 1.
+	Weight int `yaml:"weight,omitempty" json:"weight,omitempty" jsonschema:"minimum=0"`
+	// The fixed height of the window, including its frame. Takes precedence over weight.
+	// If neither is set, the status and stash windows are 3 lines high (the stash window grows when focused), and the others have a weight of 1.
+	Size int `yaml:"size,omitempty" json:"size,omitempty" jsonschema:"minimum=0"`
+	// Other side windows whose tabs are shown in this window, after its own tabs
+	Tabs []string `yaml:"tabs,omitempty" json:"tabs,omitempty" jsonschema:"uniqueItems=true"`
+}
+
+// Returns the names of the windows that are shown, from top to bottom
+func (c *SideWindowLayoutConfig) WindowNames() []string {
+	names := make([]string, 0, len(c.Windows))
+	for _, window := range c.Windows {
+		names = append(names, window.Window)
+	}
+	return names
+}
+
+// Returns the shown window that the given side window's tabs are part of, or
+// the empty string if the window is hidden
+func (c *SideWindowLayoutConfig) HostWindow(window string) string {
+	for _, sideWindow := range c.Windows {
+		if sideWindow.Window == window || slices.Contains(sideWindow.Tabs, window) {
+			return sideWindow.Window
+		}
+	}
+	return ""
+}
+
 type SpinnerConfig struct {
 	// The frames of the spinner animation.
 	Frames []string `yaml:"frames"`
@@ -271,6 +351,8 @@ type GitConfig struct {
 	Commit CommitConfig `yaml:"commit"`
 	// Config relating to merging
 	Merging MergingConfig `yaml:"merging"`
+	// Config relating to creating pull requests
+	PullRequests PullRequestsConfig `yaml:"pullRequests"`
 	// list of branches that are considered 'main' branches, used when displaying commits
 	MainBranches []string `yaml:"mainBranches" jsonschema:"uniqueItems=true"`
 	// Prefix to use when skipping hooks. E.g. if set to 'WIP', then pre-commit hooks will be skipped when the commit message starts with 'WIP'
@@ -300,6 +382,9 @@ type GitConfig struct {
 	OverrideGpg bool `yaml:"overrideGpg"`
 	// If true, do not allow force pushes
 	DisableForcePushing bool `yaml:"disableForcePushing"`
+	// Branches that some actions are not allowed on, e.g. to prevent force pushing to main
+	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#protected-branches
+	ProtectedBranches []ProtectedBranchConfig `yaml:"protectedBranches"`
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#predefined-commit-message-prefix
 	CommitPrefix []CommitPrefixConfig `yaml:"commitPrefix"`
 	// See https://github.com/jesseduffield/lazygit/blob/master/docs/Config.md#predefined-commit-message-prefix
@@ -321,6 +406,17 @@ type GitConfig struct {
 	RemoteBranchSortOrder string `yaml:"remoteBranchSortOrder" jsonschema:"enum=date,enum=alphabetical"`
 	// When copying commit hashes to the clipboard, truncate them to this length. Set to 40 to disable truncation.
 	TruncateCopiedCommitHashesTo int `yaml:"truncateCopiedCommitHashesTo"`
+	// Snapshots of the working tree that are taken before discarding changes, so that the changes can be recovered from the reset menu
+	DiscardSnapshots DiscardSnapshotsConfig `yaml:"discardSnapshots"`
+}
+
+type DiscardSnapshotsConfig struct {
+	// If true, the working tree and index are saved to a private ref before changes are discarded or the working tree is reset
+	Enabled bool `yaml:"enabled"`
+	// The number of snapshots to keep per repo. Older ones are deleted when a new one is taken. 0 means no limit.
+	MaxCount int `yaml:"maxCount" jsonschema:"minimum=0"`
+	// Snapshots older than this number of days are deleted when a new one is taken. 0 means no limit.
+	MaxAgeDays int `yaml:"maxAgeDays" jsonschema:"minimum=0"`
 }
 
 type PagerType string
@@ -367,6 +463,18 @@ type MergingConfig struct {
 	SquashMergeMessage string `yaml:"squashMergeMessage"`
 }
 
+type PullRequestsConfig struct {
+	// Name of the environment variable containing an API token for the hosting service. If the variable is set, creating a pull request opens a form in Lazygit and submits the pull request through the hosting service's API; otherwise the pull request page is opened in the browser.
+	// If empty, GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or CODEBERG_TOKEN is used depending on the hosting service. Only GitHub, GitLab, Gitea and Codeberg are supported.
+	TokenEnvVar string `yaml:"tokenEnvVar"`
+	// Path of the pull request template, relative to the repo root. If empty, the usual locations (e.g. .github/pull_request_template.md) are searched.
+	TemplatePath string `yaml:"templatePath"`
+	// If true, new pull requests are created as drafts by default
+	Draft bool `yaml:"draft"`
+	// Reviewers to request by default (usernames on the hosting service)
+	Reviewers []string `yaml:"reviewers"`
+}
+
 type LogConfig struct {
 	// One of: 'date-order' | 'author-date-order' | 'topo-order' | 'default'
 	// 'topo-order' makes it easier to read the git log graph, but commits may not appear chronologically. See https://git-scm.com/docs/
@@ -382,6 +490,23 @@ type LogConfig struct {
 	ShowWholeGraph bool `yaml:"showWholeGraph"`
 }
 
+type ProtectedBranchConfig struct {
+	// Glob pattern that the branch name must match, e.g. 'main' or 'release/*'
+	Pattern string `yaml:"pattern" jsonschema:"example=main,example=release/*"`
+	// If true, force pushing the branch is not allowed
+	NoForcePush bool `yaml:"noForcePush"`
+	// If true, committing while the branch is checked out is not allowed
+	NoDirectCommit bool `yaml:"noDirectCommit"`
+	// If true, resetting the branch while it is checked out is not allowed
+	NoReset bool `yaml:"noReset"`
+	// If true, rebasing the branch (including interactive rebases like squashing or dropping commits) is not allowed
+	NoRebase bool `yaml:"noRebase"`
+	// If true, deleting the branch (locally or on a remote) is not allowed
+	NoDelete bool `yaml:"noDelete"`
+	// If true, a restricted action can still be done after typing the name of the branch
+	AllowOverride bool `yaml:"allowOverride"`
+}
+
 type CommitPrefixConfig struct {
 	// pattern to match on. E.g. for 'feature/AB-123' to match on the AB-123 use "^\\w+\\/(\\w+-\\w+).*"
 	Pattern string `yaml:"pattern" jsonschema:"example=^\\w+\\/(\\w+-\\w+).*"`
@@ -447,6 +572,7 @@ type KeybindingUniversalConfig struct {
 	StartSearch                       string   `yaml:"startSearch"`
 	OptionMenu                        string   `yaml:"optionMenu"`
 	OptionMenuAlt1                    string   `yaml:"optionMenu-alt1"`
+	CommandPalette                    string   `yaml:"commandPalette"`
 	Select                            string   `yaml:"select"`
 	GoInto                            string   `yaml:"goInto"`
 	Confirm                           string   `yaml:"confirm"`
@@ -497,6 +623,9 @@ type KeybindingStatusConfig struct {
 	RecentRepos                string `yaml:"recentRepos"`
 	AllBranchesLogGraph        string `yaml:"allBranchesLogGraph"`
 	AllBranchesLogGraphReverse string `yaml:"allBranchesLogGraphReverse"`
+	SelectTheme                string `yaml:"selectTheme"`
+	RepoDashboard              string `yaml:"repoDashboard"`
+	Hooks                      string `yaml:"hooks"`
 }
 
 type KeybindingFilesConfig struct {
@@ -663,6 +792,9 @@ type CustomCommand struct {
 	Command string `yaml:"command" jsonschema:"example=git fetch {{.Form.Remote}} {{.Form.Branch}} && git checkout FETCH_HEAD"`
 	// A list of prompts that will request user input before running the final command
 	Prompts []CustomCommandPrompt `yaml:"prompts"`
+	// A list of steps to run instead of a single command, for workflows with several stages. Each step runs a command, shows a prompt, or refreshes. Later steps can use the output of earlier ones, e.g. `{{.Steps.version.Output}}`.
+	// Can't be used together with command, output, or outputTitle.
+	Steps []CustomCommandStep `yaml:"steps"`
 	// Text to display while waiting for command to finish
 	LoadingText string `yaml:"loadingText" jsonschema:"example=Loading..."`
 	// Label for the custom command when displayed in the keybindings menu
@@ -674,6 +806,10 @@ type CustomCommand struct {
 	// Actions to take after the command has completed
 	// [dev] Pointer so that we can tell whether it appears in the config file
 	After *CustomCommandAfterHook `yaml:"after"`
+	// A template (using the same placeholder values as the command) that determines whether the command is available. The command is disabled when the template renders to an empty string or to 'false'.
+	Condition string `yaml:"condition" jsonschema:"example={{hasPrefix \"release/\" .SelectedLocalBranch.Name}},example={{.WorkingTreeState.Rebasing}}"`
+	// The reason to show when trying to run the command while its condition is not met (using Go template syntax for placeholder values)
+	DisabledReason string `yaml:"disabledReason"`
 }
 
 func (c *CustomCommand) GetDescription() string {
@@ -681,13 +817,85 @@ func (c *CustomCommand) GetDescription() string {
 		return c.Description
 	}
 
+	if len(c.Steps) > 0 {
+		commands := []string{}
+		for _, step := range c.Steps {
+			if step.Command != "" {
+				commands = append(commands, step.Command)
+			}
+		}
+		return strings.Join(commands, " && ")
+	}
+
 	return c.Command
 }
 
+type CustomCommandStep struct {
+	// Used for referring to the result of the step in later steps: `{{.Steps.<key>.Output}}` is the output of a command step (with surrounding whitespace trimmed), or the response of an input or menu prompt.
+	Key string `yaml:"key"`
+	// The command to run (using Go template syntax for placeholder values)
+	Command string `yaml:"command" jsonschema:"example=git tag v{{.Steps.version.Output}}"`
+	// A prompt requesting user input. As with the custom command's prompts, the response is also available as `{{.Form.<key>}}` using the prompt's key.
+	Prompt *CustomCommandPrompt `yaml:"prompt"`
+	// If true, the step refreshes Lazygit's views, e.g. to show the effects of the previous steps while waiting for the next prompt
+	Refresh bool `yaml:"refresh"`
+	// Text to display while the step's command is running (using Go template syntax for placeholder values). Defaults to the custom command's loadingText.
+	LoadingText string `yaml:"loadingText" jsonschema:"example=Pushing..."`
+	// A step to run when the step's command fails, e.g. for cleaning up. The remaining steps are skipped either way.
+	OnError *CustomCommandStep `yaml:"onError"`
+	// The error message to show when the step's command fails (using Go template syntax for placeholder values). Defaults to the command's error output.
+	ErrorMessage string `yaml:"errorMessage"`
+}
+
+type CustomPanel struct {
+	// Identifies the panel. Use it as the context of custom commands that operate on the panel's items.
+	Key string `yaml:"key"`
+	// The title of the panel's tab
+	Title string `yaml:"title"`
+	// The side window in which the panel is shown as an additional tab
+	Window string `yaml:"window" jsonschema:"enum=files,enum=branches,enum=commits,enum=stash"`
+	// The command whose output is shown in the panel
+	Command string `yaml:"command"`
+	// How to parse the output of the command. 'lines' makes one item per line; 'json' expects a JSON array of objects, whose fields can be accessed in valueFormat and labelFormat, e.g. `{{.id}}`
+	Format string `yaml:"format" jsonschema:"enum=lines,enum=json"`
+	// The regexp to run specifying groups which are going to be kept from each line of output, like in menuFromCommand prompts.
+	// Only for the 'lines' format.
+	Filter string `yaml:"filter" jsonschema:"example=^(?P<id>\\d+) (?P<title>.*)$"`
+	// How to format the value of an item, which custom commands can access as `{{.SelectedCustomItem.Value}}`. Defaults to the whole line for the 'lines' format.
+	ValueFormat string `yaml:"valueFormat" jsonschema:"example={{ .id }}"`
+	// How to format the item for display in the panel. Defaults to the value.
+	LabelFormat string `yaml:"labelFormat" jsonschema:"example={{ .title | green }}"`
+	// The command to run for showing the selected item in the main view (using Go template syntax for placeholder values, e.g. `{{.SelectedCustomItem.Value}}`)
+	Preview string `yaml:"preview"`
+}
+
+type PluginConfig struct {
+	// The name of the plugin, used in error messages. Defaults to the command.
+	Name string `yaml:"name"`
+	// The command that starts the plugin. It is run in the repo's directory.
+	Command string `yaml:"command" jsonschema:"example=python3 ~/lazygit-plugins/jira.py"`
+}
+
+func (p *PluginConfig) GetName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+
+	return p.Command
+}
+
+type RemoteControlConfig struct {
+	// If true, Lazygit listens for commands on a Unix socket while it's running
+	Enabled bool `yaml:"enabled"`
+	// The path of the socket. Defaults to lazygit/remote.sock in $XDG_RUNTIME_DIR (or the temp directory if that's not set).
+	SocketPath string `yaml:"socketPath"`
+}
+
 type CustomCommandPrompt struct {
-	// One of: 'input' | 'menu' | 'confirm' | 'menuFromCommand'
+	// One of: 'input' | 'menu' | 'confirm' | 'menuFromCommand' | 'multiSelect' | 'multiSelectFromCommand' | 'filePicker'
 	Type string `yaml:"type"`
-	// Used to reference the entered value from within the custom command. E.g. a prompt with `key: 'Branch'` can be referred to as `{{.Form.Branch}}` in the command
+	// Used to reference the entered value from within the custom command. E.g. a prompt with `key: 'Branch'` can be referred to as `{{.Form.Branch}}` in the command.
+	// For multiSelect and multiSelectFromCommand prompts, and filePicker prompts with allowMultiple, the value is a list, e.g. `{{range .Form.Files}}{{. | quote}} {{end}}` or `{{.Form.Files | join " "}}`
 	Key string `yaml:"key"`
 	// The title to display in the popup panel
 	Title string `yaml:"title"`
@@ -704,21 +912,28 @@ type CustomCommandPrompt struct {
 	Body string `yaml:"body" jsonschema:"example=Are you sure you want to push to the remote?"`
 
 	// Menu options.
-	// Only for menu prompts.
+	// Only for menu and multiSelect prompts.
 	Options []CustomCommandMenuOption `yaml:"options"`
 
 	// The command to run to generate menu options
-	// Only for menuFromCommand prompts.
+	// Only for menuFromCommand and multiSelectFromCommand prompts.
 	Command string `yaml:"command" jsonschema:"example=git fetch {{.Form.Remote}} {{.Form.Branch}} && git checkout FETCH_HEAD"`
 	// The regexp to run specifying groups which are going to be kept from the command's output.
-	// Only for menuFromCommand prompts.
+	// Only for menuFromCommand and multiSelectFromCommand prompts.
 	Filter string `yaml:"filter" jsonschema:"example=.*{{.SelectedRemote.Name }}/(?P<branch>.*)"`
 	// How to format matched groups from the filter to construct a menu item's value.
-	// Only for menuFromCommand prompts.
+	// Only for menuFromCommand and multiSelectFromCommand prompts.
 	ValueFormat string `yaml:"valueFormat" jsonschema:"example={{ .branch }}"`
 	// Like valueFormat but for the labels. If `labelFormat` is not specified, `valueFormat` is shown instead.
-	// Only for menuFromCommand prompts.
+	// Only for menuFromCommand and multiSelectFromCommand prompts.
 	LabelFormat string `yaml:"labelFormat" jsonschema:"example={{ .branch | green }}"`
+
+	// The ref whose files to pick from. If empty, all tracked files of the working tree are shown.
+	// Only for filePicker prompts.
+	Ref string `yaml:"ref" jsonschema:"example={{.SelectedLocalCommit.Hash}}"`
+	// Allows picking several files (or whole directories) by selecting them with space.
+	// Only for filePicker prompts.
+	AllowMultiple bool `yaml:"allowMultiple"`
 }
 
 type CustomCommandSuggestions struct {
@@ -768,6 +983,16 @@ func GetDefaultConfig() *UserConfig {
 			ExpandedSidePanelWeight:  2,
 			MainPanelSplitMode:       "flexible",
 			EnlargedSideViewLocation: "left",
+			SideWindowLayout: SideWindowLayoutConfig{
+				Position: "left",
+				Windows: []SideWindowConfig{
+					{Window: "status"},
+					{Window: "files"},
+					{Window: "branches"},
+					{Window: "commits"},
+					{Window: "stash"},
+				},
+			},
 			WrapLinesInStagingView:   true,
 			UseHunkModeInStagingView: true,
 			Language:                 "auto",
@@ -834,6 +1059,12 @@ func GetDefaultConfig() *UserConfig {
 				Args:               "",
 				SquashMergeMessage: "Squash merge {{selectedRef}} into {{currentBranch}}",
 			},
+			PullRequests: PullRequestsConfig{
+				TokenEnvVar:  "",
+				TemplatePath: "",
+				Draft:        false,
+				Reviewers:    []string{},
+			},
 			Log: LogConfig{
 				Order:          "topo-order",
 				ShowGraph:      "always",
@@ -858,11 +1089,25 @@ func GetDefaultConfig() *UserConfig {
 			BranchPrefix:                 "",
 			ParseEmoji:                   false,
 			TruncateCopiedCommitHashesTo: 12,
+			DiscardSnapshots: DiscardSnapshotsConfig{
+				Enabled:    true,
+				MaxCount:   50,
+				MaxAgeDays: 14,
+			},
 		},
 		Refresher: RefresherConfig{
+			WatchFiles:      true,
 			RefreshInterval: 10,
 			FetchInterval:   60,
 		},
+		RepoDashboard: RepoDashboardConfig{
+			RootDirectories: []string{},
+		},
+		CommandHistory: CommandHistoryConfig{
+			Enabled:    true,
+			SaveOutput: false,
+			MaxEntries: 1000,
+		},
 		Update: UpdateConfig{
 			Method: "prompt",
 			Days:   14,
@@ -872,6 +1117,9 @@ func GetDefaultConfig() *UserConfig {
 		OS:                           OSConfig{},
 		DisableStartupPopups:         false,
 		CustomCommands:               []CustomCommand(nil),
+		CustomPanels:                 []CustomPanel(nil),
+		Plugins:                      []PluginConfig(nil),
+		RemoteControl:                RemoteControlConfig{Enabled: false, SocketPath: ""},
 		Services:                     map[string]string(nil),
 		NotARepository:               "prompt",
 		PromptToReturnFromSubprocess: true,
@@ -911,6 +1159,7 @@ func GetDefaultConfig() *UserConfig {
 				StartSearch:                       "/",
 				OptionMenu:                        "<disabled>",
 				OptionMenuAlt1:                    "?",
+				CommandPalette:                    "<c-x>",
 				Select:                            "<space>",
 				GoInto:                            "<enter>",
 				Confirm:                           "<enter>",
@@ -960,6 +1209,9 @@ func GetDefaultConfig() *UserConfig {
 				RecentRepos:                "<enter>",
 				AllBranchesLogGraph:        "a",
 				AllBranchesLogGraphReverse: "A",
+				SelectTheme:                "t",
+				RepoDashboard:              "D",
+				Hooks:                      "H",
 			},
 			Files: KeybindingFilesConfig{
 				CommitChanges:            "c",
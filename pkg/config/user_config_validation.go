@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"path"
 	"reflect"
 	"slices"
 	"strings"
@@ -39,12 +41,24 @@ func (config *UserConfig) Validate() error {
 		[]string{"always", "never", "when-maximised"}); err != nil {
 		return err
 	}
+	if err := validateProtectedBranches(config.Git.ProtectedBranches); err != nil {
+		return err
+	}
+	if err := validateSideWindowLayout(config.Gui.SideWindowLayout); err != nil {
+		return err
+	}
 	if err := validateKeybindings(config.Keybinding); err != nil {
 		return err
 	}
 	if err := validateCustomCommands(config.CustomCommands); err != nil {
 		return err
 	}
+	if err := validateCustomPanels(config.CustomPanels); err != nil {
+		return err
+	}
+	if err := validatePlugins(config.Plugins); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -56,6 +70,44 @@ func validateEnum(name string, value string, allowedValues []string) error {
 	return fmt.Errorf("Unexpected value '%s' for '%s'. Allowed values: %s", value, name, allowedValuesStr)
 }
 
+func validateSideWindowLayout(layout SideWindowLayoutConfig) error {
+	if err := validateEnum("gui.sideWindowLayout.position", layout.Position,
+		[]string{"left", "right", "top"}); err != nil {
+		return err
+	}
+	if len(layout.Windows) == 0 {
+		return errors.New("gui.sideWindowLayout.windows must contain at least one window")
+	}
+
+	sideWindows := []string{"status", "files", "branches", "commits", "stash"}
+	seen := map[string]bool{}
+	checkWindow := func(window string) error {
+		if seen[window] {
+			return fmt.Errorf("Side window '%s' appears more than once in gui.sideWindowLayout", window)
+		}
+		seen[window] = true
+		return nil
+	}
+	for _, sideWindow := range layout.Windows {
+		if err := validateEnum("gui.sideWindowLayout.windows.window", sideWindow.Window, sideWindows); err != nil {
+			return err
+		}
+		if err := checkWindow(sideWindow.Window); err != nil {
+			return err
+		}
+		for _, tab := range sideWindow.Tabs {
+			// the status window has no tabs and its view can't be shown as one
+			if err := validateEnum("gui.sideWindowLayout.windows.tabs", tab, sideWindows[1:]); err != nil {
+				return err
+			}
+			if err := checkWindow(tab); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func validateKeybindingsRecurse(path string, node any) error {
 	value := reflect.ValueOf(node)
 	if value.Kind() == reflect.Struct {
@@ -95,6 +147,17 @@ func validateKeybindings(keybindingConfig KeybindingConfig) error {
 		return err
 	}
 
+	// these are also passed to gocui for searching, which only knows single keys
+	for name, key := range map[string]string{
+		"return":    keybindingConfig.Universal.Return,
+		"nextMatch": keybindingConfig.Universal.NextMatch,
+		"prevMatch": keybindingConfig.Universal.PrevMatch,
+	} {
+		if len(SplitKeySequence(key)) > 1 {
+			return fmt.Errorf("keybinding.universal.%s can't be a key sequence", name)
+		}
+	}
+
 	if len(keybindingConfig.Universal.JumpToBlock) != 5 {
 		return fmt.Errorf("keybinding.universal.jumpToBlock must have 5 elements; found %d.",
 			len(keybindingConfig.Universal.JumpToBlock))
@@ -121,10 +184,13 @@ func validateCustomCommands(customCommands []CustomCommand) error {
 			if len(customCommand.Context) > 0 ||
 				len(customCommand.Command) > 0 ||
 				len(customCommand.Prompts) > 0 ||
+				len(customCommand.Steps) > 0 ||
 				len(customCommand.LoadingText) > 0 ||
 				len(customCommand.Output) > 0 ||
 				len(customCommand.OutputTitle) > 0 ||
-				customCommand.After != nil {
+				customCommand.After != nil ||
+				len(customCommand.Condition) > 0 ||
+				len(customCommand.DisabledReason) > 0 {
 				commandRef := ""
 				if len(customCommand.Key) > 0 {
 					commandRef = fmt.Sprintf(" with key '%s'", customCommand.Key)
@@ -146,6 +212,119 @@ func validateCustomCommands(customCommands []CustomCommand) error {
 				[]string{"", "none", "terminal", "log", "logWithPty", "popup"}); err != nil {
 				return err
 			}
+
+			if len(customCommand.Steps) > 0 {
+				if len(customCommand.Command) > 0 ||
+					len(customCommand.Output) > 0 ||
+					len(customCommand.OutputTitle) > 0 {
+					return fmt.Errorf("Error with custom command with key '%s': it is not allowed to use both steps and command, output, or outputTitle.", customCommand.Key)
+				}
+
+				for _, step := range customCommand.Steps {
+					if err := validateCustomCommandStep(step); err != nil {
+						return fmt.Errorf("Error with step of custom command with key '%s': %w", customCommand.Key, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateCustomCommandStep(step CustomCommandStep) error {
+	kinds := 0
+	if step.Command != "" {
+		kinds++
+	}
+	if step.Prompt != nil {
+		kinds++
+	}
+	if step.Refresh {
+		kinds++
+	}
+	if kinds != 1 {
+		return errors.New("a step must have exactly one of command, prompt, or refresh")
+	}
+
+	if step.Command == "" && (step.OnError != nil || step.ErrorMessage != "" || step.LoadingText != "") {
+		return errors.New("onError, errorMessage, and loadingText can only be used in command steps")
+	}
+
+	if step.Prompt != nil {
+		if err := validateCustomCommandPrompt(*step.Prompt); err != nil {
+			return err
+		}
+	}
+
+	if step.OnError != nil {
+		return validateCustomCommandStep(*step.OnError)
+	}
+
+	return nil
+}
+
+func validateProtectedBranches(protectedBranches []ProtectedBranchConfig) error {
+	for _, protectedBranch := range protectedBranches {
+		if protectedBranch.Pattern == "" {
+			return errors.New("Error with protected branch: pattern is required")
+		}
+		if _, err := path.Match(protectedBranch.Pattern, ""); err != nil {
+			return fmt.Errorf("Error with protected branch '%s': invalid pattern", protectedBranch.Pattern)
+		}
+	}
+	return nil
+}
+
+// The keys of the built-in contexts. A custom panel's key is used as the key of
+// its context, so it must not be one of these. Must be kept in sync with
+// pkg/gui/context/context.go.
+var BuiltInContextKeys = []string{
+	"none", "global", "status", "snake", "files", "localBranches", "remotes", "worktrees",
+	"remoteBranches", "tags", "commits", "reflogCommits", "subCommits", "commitFiles",
+	"stash", "normal", "normalSecondary", "staging", "stagingSecondary", "patchBuilding",
+	"patchBuildingSecondary", "mergeConflicts", "options", "appStatus", "searchPrefix",
+	"information", "limit", "statusSpacer1", "statusSpacer2", "menu", "filePicker",
+	"repoDashboard", "commandHistory", "hooks", "hookOutput", "keySequence", "confirmation",
+	"prompt", "search", "commitMessage", "commitDescription", "submodules", "suggestions",
+	"cmdLog",
+}
+
+func validateCustomPanels(customPanels []CustomPanel) error {
+	keys := map[string]bool{}
+	for _, customPanel := range customPanels {
+		if customPanel.Key == "" {
+			return fmt.Errorf("Error with custom panel '%s': key is required", customPanel.Title)
+		}
+		if slices.Contains(BuiltInContextKeys, customPanel.Key) {
+			return fmt.Errorf("Error with custom panel '%s': key is already used by a built-in panel", customPanel.Key)
+		}
+		if keys[customPanel.Key] {
+			return fmt.Errorf("Error with custom panel '%s': key is used by more than one custom panel", customPanel.Key)
+		}
+		keys[customPanel.Key] = true
+
+		if customPanel.Command == "" {
+			return fmt.Errorf("Error with custom panel '%s': command is required", customPanel.Key)
+		}
+		if err := validateEnum("customPanel.window", customPanel.Window,
+			[]string{"files", "branches", "commits", "stash"}); err != nil {
+			return err
+		}
+		if err := validateEnum("customPanel.format", customPanel.Format,
+			[]string{"", "lines", "json"}); err != nil {
+			return err
+		}
+		if customPanel.Format == "json" && customPanel.ValueFormat == "" {
+			return fmt.Errorf("Error with custom panel '%s': valueFormat is required for the json format", customPanel.Key)
+		}
+	}
+	return nil
+}
+
+func validatePlugins(plugins []PluginConfig) error {
+	for _, plugin := range plugins {
+		if plugin.Command == "" {
+			return fmt.Errorf("Error with plugin '%s': command is required", plugin.Name)
 		}
 	}
 	return nil
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -121,7 +122,22 @@ func TestUserConfigValidate_enums(t *testing.T) {
 				{value: "<disabled>", valid: true},
 				{value: "q", valid: true},
 				{value: "<c-c>", valid: true},
+				{value: "g q", valid: true},
+				{value: "<space> p r", valid: true},
+				{value: "mouse wheel up", valid: true},
 				{value: "invalid_value", valid: false},
+				{value: "g invalid_value", valid: false},
+				{value: "g <disabled>", valid: false},
+			},
+		},
+		{
+			name: "Search keybindings",
+			setup: func(config *UserConfig, value string) {
+				config.Keybinding.Universal.NextMatch = value
+			},
+			testCases: []testCase{
+				{value: "n", valid: true},
+				{value: "g n", valid: false},
 			},
 		},
 		{
@@ -152,6 +168,7 @@ func TestUserConfigValidate_enums(t *testing.T) {
 				{value: "<disabled>", valid: true},
 				{value: "q", valid: true},
 				{value: "<c-c>", valid: true},
+				{value: "g b", valid: true},
 				{value: "invalid_value", valid: false},
 			},
 		},
@@ -271,6 +288,165 @@ func TestUserConfigValidate_enums(t *testing.T) {
 				{value: "", valid: false},
 			},
 		},
+		{
+			name: "Custom command steps",
+			setup: func(config *UserConfig, value string) {
+				config.CustomCommands = []CustomCommand{
+					{
+						Key:     "X",
+						Context: "global",
+						Command: value, // command is not allowed together with steps
+						Steps: []CustomCommandStep{
+							{Key: "version", Command: "cat VERSION"},
+							{Prompt: &CustomCommandPrompt{Type: "confirm", Title: "Tag?"}},
+							{Command: "git tag v{{.Steps.version.Output}}", OnError: &CustomCommandStep{Command: "echo failed"}},
+							{Refresh: true},
+						},
+					},
+				}
+			},
+			testCases: []testCase{
+				{value: "", valid: true},
+				{value: "echo 'hello'", valid: false},
+			},
+		},
+		{
+			name: "Custom command step kind",
+			setup: func(config *UserConfig, value string) {
+				step := CustomCommandStep{Refresh: true}
+				switch value {
+				case "none":
+					step = CustomCommandStep{}
+				case "commandAndRefresh":
+					step = CustomCommandStep{Command: "echo 'hello'", Refresh: true}
+				case "onErrorOnRefresh":
+					step = CustomCommandStep{Refresh: true, OnError: &CustomCommandStep{Command: "echo 'hello'"}}
+				}
+				config.CustomCommands = []CustomCommand{
+					{Key: "X", Context: "global", Steps: []CustomCommandStep{step}},
+				}
+			},
+			testCases: []testCase{
+				{value: "refresh", valid: true},
+				{value: "none", valid: false},
+				{value: "commandAndRefresh", valid: false},
+				{value: "onErrorOnRefresh", valid: false},
+			},
+		},
+		{
+			name: "Custom panel window",
+			setup: func(config *UserConfig, value string) {
+				config.CustomPanels = []CustomPanel{
+					{Key: "queue", Window: value, Command: "cat queue.txt"},
+				}
+			},
+			testCases: []testCase{
+				{value: "files", valid: true},
+				{value: "stash", valid: true},
+				{value: "", valid: false},
+				{value: "status", valid: false},
+			},
+		},
+		{
+			name: "Custom panel format",
+			setup: func(config *UserConfig, value string) {
+				config.CustomPanels = []CustomPanel{
+					{Key: "queue", Window: "branches", Command: "cat queue.json", Format: value, ValueFormat: "{{.id}}"},
+				}
+			},
+			testCases: []testCase{
+				{value: "", valid: true},
+				{value: "lines", valid: true},
+				{value: "json", valid: true},
+				{value: "yaml", valid: false},
+			},
+		},
+		{
+			name: "Custom panel keys",
+			setup: func(config *UserConfig, value string) {
+				config.CustomPanels = []CustomPanel{
+					{Key: "queue", Window: "branches", Command: "cat queue.txt"},
+					{Key: value, Window: "commits", Command: "ls artifacts"},
+				}
+			},
+			testCases: []testCase{
+				{value: "artifacts", valid: true},
+				{value: "queue", valid: false},
+				{value: "", valid: false},
+				{value: "files", valid: false},
+				{value: "localBranches", valid: false},
+			},
+		},
+		{
+			name: "Protected branch pattern",
+			setup: func(config *UserConfig, value string) {
+				config.Git.ProtectedBranches = []ProtectedBranchConfig{
+					{Pattern: value, NoForcePush: true},
+				}
+			},
+			testCases: []testCase{
+				{value: "main", valid: true},
+				{value: "release/*", valid: true},
+				{value: "", valid: false},
+				{value: "release/[", valid: false},
+			},
+		},
+		{
+			name: "Side window layout position",
+			setup: func(config *UserConfig, value string) {
+				config.Gui.SideWindowLayout.Position = value
+			},
+			testCases: []testCase{
+				{value: "left", valid: true},
+				{value: "right", valid: true},
+				{value: "top", valid: true},
+				{value: "bottom", valid: false},
+				{value: "", valid: false},
+			},
+		},
+		{
+			name: "Side window layout windows",
+			setup: func(config *UserConfig, value string) {
+				config.Gui.SideWindowLayout.Windows = lo.Map(lo.Compact(strings.Split(value, ",")),
+					func(window string, _ int) SideWindowConfig { return SideWindowConfig{Window: window} })
+			},
+			testCases: []testCase{
+				{value: "commits", valid: true},
+				{value: "status,files", valid: true},
+				{value: "", valid: false},
+				{value: "files,files", valid: false},
+			},
+		},
+		{
+			name: "Side window layout tabs",
+			setup: func(config *UserConfig, value string) {
+				config.Gui.SideWindowLayout.Windows = []SideWindowConfig{
+					{Window: "status"},
+					{Window: "files"},
+					{Window: "branches"},
+					{Window: "commits", Tabs: strings.Split(value, ",")},
+				}
+			},
+			testCases: []testCase{
+				{value: "stash", valid: true},
+				{value: "stash,status", valid: false},
+				{value: "branches", valid: false},
+				{value: "stash,stash", valid: false},
+				{value: "unknown", valid: false},
+			},
+		},
+		{
+			name: "Plugin command",
+			setup: func(config *UserConfig, value string) {
+				config.Plugins = []PluginConfig{
+					{Name: "jira", Command: value},
+				}
+			},
+			testCases: []testCase{
+				{value: "python3 jira.py", valid: true},
+				{value: "", valid: false},
+			},
+		},
 	}
 
 	for _, s := range scenarios {
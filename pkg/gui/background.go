@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/file_watcher"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -20,6 +21,10 @@ type BackgroundRoutineMgr struct {
 
 	// a channel to trigger an immediate background fetch; we use this when switching repos
 	triggerFetch chan struct{}
+
+	// watches the current repo (or polls it if watching fails) if
+	// refresher.watchFiles is on; nil otherwise
+	filesRefresher *file_watcher.Refresher
 }
 
 func (self *BackgroundRoutineMgr) PauseBackgroundRefreshes(pause bool) {
@@ -43,6 +48,9 @@ func (self *BackgroundRoutineMgr) startBackgroundRoutines() {
 	if userConfig.Git.AutoRefresh {
 		refreshInterval := userConfig.Refresher.RefreshInterval
 		if refreshInterval > 0 {
+			if userConfig.Refresher.WatchFiles {
+				self.filesRefresher = self.newFilesRefresher()
+			}
 			go utils.Safe(self.startBackgroundFilesRefresh)
 		} else {
 			self.gui.c.Log.Errorf(
@@ -109,13 +117,51 @@ func (self *BackgroundRoutineMgr) startBackgroundFetch() {
 func (self *BackgroundRoutineMgr) startBackgroundFilesRefresh() {
 	self.gui.waitForIntro.Wait()
 
+	if self.filesRefresher != nil {
+		self.filesRefresher.Start()
+		return
+	}
+
+	self.startPolling(self.gui.stopChan)
+}
+
+// Refreshes the files of the current repo periodically until stop is closed
+// (or the gui is stopped)
+func (self *BackgroundRoutineMgr) startPolling(stop chan struct{}) {
 	userConfig := self.gui.UserConfig()
-	self.goEvery(userConfig.Refresher.RefreshIntervalDuration(), self.gui.stopChan, func(_ bool) error {
+	self.goEvery(userConfig.Refresher.RefreshIntervalDuration(), stop, func(_ bool) error {
 		self.gui.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.FILES}})
 		return nil
 	})
 }
 
+func (self *BackgroundRoutineMgr) newFilesRefresher() *file_watcher.Refresher {
+	return file_watcher.NewRefresher(
+		func(onError func(error)) file_watcher.IWatcher {
+			return file_watcher.NewWatcher(
+				self.gui.c,
+				func() bool { return self.pauseBackgroundRefreshes },
+				onError,
+			)
+		},
+		self.startPolling,
+		func(f func()) {
+			self.gui.c.OnWorker(func(gocui.Task) error {
+				f()
+				return nil
+			})
+		},
+		self.gui.c.Log,
+	)
+}
+
+// Moves the file watcher to the repo we just switched to
+func (self *BackgroundRoutineMgr) onNewRepo() {
+	if self.filesRefresher != nil {
+		self.filesRefresher.OnNewRepo()
+	}
+}
+
 // returns a channel that can be used to trigger the callback immediately
 func (self *BackgroundRoutineMgr) goEvery(interval time.Duration, stop chan struct{}, function func(bool) error) chan struct{} {
 	done := make(chan struct{})
@@ -146,6 +192,8 @@ func (self *BackgroundRoutineMgr) goEvery(interval time.Duration, stop chan stru
 				doit(true)
 			case <-stop:
 				return
+			case <-self.gui.stopChan:
+				return
 			}
 		}
 	})
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/constants"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -52,6 +53,16 @@ func (gui *Gui) LogCommand(cmdStr string, commandLine bool) {
 	fmt.Fprint(gui.Views.Extras, "\n"+textStyle.Sprint(indentedCmdStr))
 }
 
+// Called by the command runner when a command that we logged has finished, so
+// that it ends up in the persistent command history of the repo
+func (gui *Gui) recordCommand(result oscommands.CmdResult) {
+	if gui.helpers == nil {
+		return
+	}
+
+	gui.helpers.CommandHistory.Record(result)
+}
+
 func (gui *Gui) printCommandLogHeader() {
 	introStr := fmt.Sprintf(
 		gui.c.Tr.CommandLogHeader,
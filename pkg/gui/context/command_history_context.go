@@ -0,0 +1,68 @@
+package context
+
+import (
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// A popup listing the commands that were run in the current repo, most recent
+// first, as saved in its command history. It's a persistent popup so that it
+// stays open underneath the popups that show a command's details or ask for
+// confirmation to re-run it.
+type CommandHistoryContext struct {
+	*FilteredListViewModel[*models.CommandHistoryEntry]
+	*ListContextTrait
+
+	entries []*models.CommandHistoryEntry
+}
+
+var (
+	_ types.IListContext       = (*CommandHistoryContext)(nil)
+	_ types.IFilterableContext = (*CommandHistoryContext)(nil)
+)
+
+func NewCommandHistoryContext(c *ContextCommon) *CommandHistoryContext {
+	ctx := &CommandHistoryContext{}
+
+	viewModel := NewFilteredListViewModel(
+		func() []*models.CommandHistoryEntry { return ctx.entries },
+		func(entry *models.CommandHistoryEntry) []string {
+			return []string{entry.Command}
+		},
+	)
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		return presentation.GetCommandHistoryListDisplayStrings(viewModel.GetItems(), time.Now(), c.UserConfig())
+	}
+
+	ctx.FilteredListViewModel = viewModel
+	ctx.ListContextTrait = &ListContextTrait{
+		Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:                  c.Views().CommandHistory,
+			WindowName:            "commandHistory",
+			Key:                   COMMAND_HISTORY_CONTEXT_KEY,
+			Kind:                  types.PERSISTENT_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+		ListRenderer: ListRenderer{
+			list:              viewModel,
+			getDisplayStrings: getDisplayStrings,
+		},
+		c: c,
+	}
+
+	return ctx
+}
+
+func (self *CommandHistoryContext) ReInit(entries []*models.CommandHistoryEntry) {
+	self.entries = entries
+
+	self.ClearFilter()
+	self.SetSelection(0)
+	self.GetView().SetOriginY(0)
+	self.GetView().Title = self.c.Tr.CommandHistoryTitle
+}
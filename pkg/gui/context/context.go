@@ -2,6 +2,7 @@ package context
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
 )
 
 const (
@@ -40,6 +41,12 @@ const (
 	STATUS_SPACER2_CONTEXT_KEY types.ContextKey = "statusSpacer2"
 
 	MENU_CONTEXT_KEY               types.ContextKey = "menu"
+	FILE_PICKER_CONTEXT_KEY        types.ContextKey = "filePicker"
+	REPO_DASHBOARD_CONTEXT_KEY     types.ContextKey = "repoDashboard"
+	COMMAND_HISTORY_CONTEXT_KEY    types.ContextKey = "commandHistory"
+	HOOKS_CONTEXT_KEY              types.ContextKey = "hooks"
+	HOOK_OUTPUT_CONTEXT_KEY        types.ContextKey = "hookOutput"
+	KEY_SEQUENCE_CONTEXT_KEY       types.ContextKey = "keySequence"
 	CONFIRMATION_CONTEXT_KEY       types.ContextKey = "confirmation"
 	PROMPT_CONTEXT_KEY             types.ContextKey = "prompt"
 	SEARCH_CONTEXT_KEY             types.ContextKey = "search"
@@ -73,6 +80,12 @@ var AllContextKeys = []types.ContextKey{
 	MERGE_CONFLICTS_CONTEXT_KEY,
 
 	MENU_CONTEXT_KEY,
+	FILE_PICKER_CONTEXT_KEY,
+	REPO_DASHBOARD_CONTEXT_KEY,
+	COMMAND_HISTORY_CONTEXT_KEY,
+	HOOKS_CONTEXT_KEY,
+	HOOK_OUTPUT_CONTEXT_KEY,
+	KEY_SEQUENCE_CONTEXT_KEY,
 	CONFIRMATION_CONTEXT_KEY,
 	PROMPT_CONTEXT_KEY,
 	SEARCH_CONTEXT_KEY,
@@ -88,6 +101,12 @@ type ContextTree struct {
 	Snake                       types.Context
 	Files                       *WorkingTreeContext
 	Menu                        *MenuContext
+	FilePicker                  *FilePickerContext
+	RepoDashboard               *RepoDashboardContext
+	CommandHistory              *CommandHistoryContext
+	Hooks                       *HooksContext
+	HookOutput                  *HookOutputContext
+	KeySequence                 *KeySequenceContext
 	Branches                    *BranchesContext
 	Tags                        *TagsContext
 	LocalCommits                *LocalCommitsContext
@@ -112,6 +131,7 @@ type ContextTree struct {
 	CommitMessage               *CommitMessageContext
 	CommitDescription           types.Context
 	CommandLog                  types.Context
+	CustomPanels                []*CustomPanelContext
 
 	// display contexts
 	AppStatus     types.Context
@@ -126,8 +146,11 @@ type ContextTree struct {
 
 // the order of this decides which context is initially at the top of its window
 func (self *ContextTree) Flatten() []types.Context {
-	return []types.Context{
-		self.Global,
+	customPanels := lo.Map(self.CustomPanels, func(context *CustomPanelContext, _ int) types.Context {
+		return context
+	})
+
+	return append(append([]types.Context{self.Global}, customPanels...),
 		self.Status,
 		self.Snake,
 		self.Submodules,
@@ -143,6 +166,12 @@ func (self *ContextTree) Flatten() []types.Context {
 		self.LocalCommits,
 		self.Stash,
 		self.Menu,
+		self.FilePicker,
+		self.RepoDashboard,
+		self.CommandHistory,
+		self.Hooks,
+		self.HookOutput,
+		self.KeySequence,
 		self.Confirmation,
 		self.Prompt,
 		self.CommitMessage,
@@ -166,7 +195,7 @@ func (self *ContextTree) Flatten() []types.Context {
 		self.Limit,
 		self.StatusSpacer1,
 		self.StatusSpacer2,
-	}
+	)
 }
 
 type TabView struct {
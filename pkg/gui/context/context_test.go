@@ -0,0 +1,15 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Custom panels with one of these keys are rejected when validating the config
+func TestBuiltInContextKeysAreReserved(t *testing.T) {
+	for _, key := range AllContextKeys {
+		assert.Contains(t, config.BuiltInContextKeys, string(key))
+	}
+}
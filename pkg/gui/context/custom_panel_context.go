@@ -0,0 +1,77 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// A user-defined panel that shows the output of a command as a list. See
+// config.CustomPanel.
+type CustomPanelContext struct {
+	*FilteredListViewModel[*models.CustomPanelItem]
+	*ListContextTrait
+
+	panel config.CustomPanel
+	items []*models.CustomPanelItem
+	// the error from the last time we ran the panel's command, if any
+	loadError error
+}
+
+var _ types.IListContext = (*CustomPanelContext)(nil)
+
+func NewCustomPanelContext(c *ContextCommon, panel config.CustomPanel) *CustomPanelContext {
+	self := &CustomPanelContext{panel: panel}
+
+	viewModel := NewFilteredListViewModel(
+		func() []*models.CustomPanelItem { return self.items },
+		func(item *models.CustomPanelItem) []string {
+			return []string{utils.Decolorise(item.Label)}
+		},
+	)
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		return lo.Map(viewModel.GetItems(), func(item *models.CustomPanelItem, _ int) []string {
+			return []string{item.Label}
+		})
+	}
+
+	self.FilteredListViewModel = viewModel
+	self.ListContextTrait = &ListContextTrait{
+		Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:       c.Views().CustomPanels[panel.Key],
+			WindowName: panel.Window,
+			Key:        types.ContextKey(panel.Key),
+			Kind:       types.SIDE_CONTEXT,
+			Focusable:  true,
+		})),
+		ListRenderer: ListRenderer{
+			list:              viewModel,
+			getDisplayStrings: getDisplayStrings,
+		},
+		c: c,
+	}
+
+	return self
+}
+
+func (self *CustomPanelContext) GetPanel() config.CustomPanel {
+	return self.panel
+}
+
+func (self *CustomPanelContext) SetItems(items []*models.CustomPanelItem, loadError error) {
+	self.items = items
+	self.loadError = loadError
+}
+
+func (self *CustomPanelContext) GetLoadError() error {
+	return self.loadError
+}
+
+// The name of the view of a custom panel. We prefix the panel's key so that it
+// can't clash with the names of our own views.
+func CustomPanelViewName(key string) string {
+	return "customPanel:" + key
+}
@@ -0,0 +1,135 @@
+package context
+
+import (
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/icons"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// A popup that lets the user pick one or more files from a tree, used by the
+// filePicker prompt of custom commands
+type FilePickerContext struct {
+	*filetree.CommitFileTreeViewModel
+	*ListContextTrait
+
+	files         []*models.CommitFile
+	unfilteredLen int
+	multiSelect   bool
+	selectedPaths *set.Set[string]
+	onConfirm     func([]string) error
+}
+
+var (
+	_ types.IListContext       = (*FilePickerContext)(nil)
+	_ types.IFilterableContext = (*FilePickerContext)(nil)
+)
+
+type FilePickerOpts struct {
+	Title string
+	Paths []string
+	// If true, the user can select several files (or whole directories) before
+	// confirming
+	MultiSelect bool
+	// Called with the picked paths; a single one unless MultiSelect is set
+	HandleConfirm func([]string) error
+}
+
+func NewFilePickerContext(c *ContextCommon) *FilePickerContext {
+	ctx := &FilePickerContext{
+		selectedPaths: set.New[string](),
+	}
+
+	viewModel := filetree.NewCommitFileTreeViewModel(
+		func() []*models.CommitFile { return ctx.files },
+		c.Common,
+		true,
+	)
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		if viewModel.Len() == 0 {
+			return [][]string{{style.FgRed.Sprint("(none)")}}
+		}
+
+		showFileIcons := icons.IsIconEnabled() && c.UserConfig().Gui.ShowFileIcons
+		lines := presentation.RenderFilePickerTree(viewModel, ctx.IsSelected, showFileIcons, &c.UserConfig().Gui.CustomIcons)
+		return lo.Map(lines, func(line string, _ int) []string {
+			return []string{line}
+		})
+	}
+
+	ctx.CommitFileTreeViewModel = viewModel
+	ctx.ListContextTrait = &ListContextTrait{
+		Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:                  c.Views().FilePicker,
+			WindowName:            "filePicker",
+			Key:                   FILE_PICKER_CONTEXT_KEY,
+			Kind:                  types.TEMPORARY_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+		ListRenderer: ListRenderer{
+			list:              viewModel,
+			getDisplayStrings: getDisplayStrings,
+		},
+		c: c,
+	}
+
+	return ctx
+}
+
+func (self *FilePickerContext) ReInit(opts FilePickerOpts) {
+	self.files = lo.Map(opts.Paths, func(path string, _ int) *models.CommitFile {
+		// the blank status keeps unselected files aligned with directories
+		return &models.CommitFile{Path: path, ChangeStatus: " "}
+	})
+	self.multiSelect = opts.MultiSelect
+	self.selectedPaths = set.New[string]()
+	self.onConfirm = opts.HandleConfirm
+
+	self.ClearFilter()
+	self.ExpandAll()
+	self.unfilteredLen = self.Len()
+	self.SetSelection(0)
+	self.GetView().SetOriginY(0)
+	self.GetView().Title = opts.Title
+}
+
+// The number of nodes of the fully expanded tree
+func (self *FilePickerContext) UnfilteredLen() int {
+	return self.unfilteredLen
+}
+
+func (self *FilePickerContext) IsMultiSelect() bool {
+	return self.multiSelect
+}
+
+func (self *FilePickerContext) IsSelected(path string) bool {
+	return self.selectedPaths.Includes(path)
+}
+
+// Selects all files of the node, or deselects them if they are all selected
+// already
+func (self *FilePickerContext) ToggleSelected(node *filetree.CommitFileNode) {
+	paths := node.GetFilePathsMatching(func(*models.CommitFile) bool { return true })
+	if lo.EveryBy(paths, self.IsSelected) {
+		self.selectedPaths.RemoveSlice(paths)
+	} else {
+		self.selectedPaths.Add(paths...)
+	}
+}
+
+// Returns the selected paths in the order in which they were passed to ReInit
+func (self *FilePickerContext) SelectedPaths() []string {
+	return lo.FilterMap(self.files, func(file *models.CommitFile, _ int) (string, bool) {
+		return file.Path, self.IsSelected(file.Path)
+	})
+}
+
+func (self *FilePickerContext) Confirm(paths []string) error {
+	return self.onConfirm(paths)
+}
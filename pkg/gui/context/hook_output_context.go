@@ -0,0 +1,51 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// A popup showing the output of a hook, which can be long, so it can be
+// scrolled. If the hook made a command fail, it offers to run the command
+// again without hooks.
+type HookOutputContext struct {
+	*SimpleContext
+	c *ContextCommon
+
+	output  string
+	onRetry func() error
+}
+
+var _ types.Context = (*HookOutputContext)(nil)
+
+func NewHookOutputContext(c *ContextCommon) *HookOutputContext {
+	return &HookOutputContext{
+		c: c,
+		SimpleContext: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:                  c.Views().HookOutput,
+			WindowName:            "hookOutput",
+			Key:                   HOOK_OUTPUT_CONTEXT_KEY,
+			Kind:                  types.TEMPORARY_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+	}
+}
+
+// Sets the output to show. onRetry is nil if there's nothing to retry.
+func (self *HookOutputContext) SetOutput(title string, output string, onRetry func() error) {
+	self.output = output
+	self.onRetry = onRetry
+
+	view := self.GetView()
+	view.Title = title
+	view.SetOriginY(0)
+	self.c.SetViewContent(view, output)
+}
+
+func (self *HookOutputContext) GetOutput() string {
+	return self.output
+}
+
+func (self *HookOutputContext) GetOnRetry() func() error {
+	return self.onRetry
+}
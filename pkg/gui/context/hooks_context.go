@@ -0,0 +1,68 @@
+package context
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// A popup listing the hooks in the effective hooks directory of the repo. It's
+// a persistent popup so that it stays open underneath the popup that shows the
+// output of a hook that was run from it.
+type HooksContext struct {
+	*ListViewModel[*models.Hook]
+	*ListContextTrait
+
+	hooks []*models.Hook
+}
+
+var _ types.IListContext = (*HooksContext)(nil)
+
+func NewHooksContext(c *ContextCommon) *HooksContext {
+	ctx := &HooksContext{}
+
+	viewModel := NewListViewModel(func() []*models.Hook { return ctx.hooks })
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		return presentation.GetHookListDisplayStrings(viewModel.GetItems(), c.Tr)
+	}
+
+	ctx.ListViewModel = viewModel
+	ctx.ListContextTrait = &ListContextTrait{
+		Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:                  c.Views().Hooks,
+			WindowName:            "hooks",
+			Key:                   HOOKS_CONTEXT_KEY,
+			Kind:                  types.PERSISTENT_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+		ListRenderer: ListRenderer{
+			list:              viewModel,
+			getDisplayStrings: getDisplayStrings,
+		},
+		c: c,
+	}
+
+	return ctx
+}
+
+// Shows the given hooks, keeping the selection on the hook with the same name
+// if it's still there
+func (self *HooksContext) SetHooks(hooks []*models.Hook, hooksPath string) {
+	selectedName := ""
+	if selected := self.GetSelected(); selected != nil {
+		selectedName = selected.Name
+	}
+
+	self.hooks = hooks
+
+	self.SetSelection(0)
+	for i, hook := range hooks {
+		if hook.Name == selectedName {
+			self.SetSelection(i)
+		}
+	}
+	self.GetView().Title = self.c.Tr.HooksTitle
+	self.GetView().Subtitle = hooksPath
+}
@@ -0,0 +1,105 @@
+package context
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// A popup that is shown while the user is typing a key sequence (e.g. "g b"),
+// listing the bindings that the sequence can still be completed to
+type KeySequenceContext struct {
+	*SimpleContext
+	c *ContextCommon
+
+	pressedKeys []types.Key
+	candidates  []*types.Binding
+}
+
+var _ types.Context = (*KeySequenceContext)(nil)
+
+func NewKeySequenceContext(c *ContextCommon) *KeySequenceContext {
+	return &KeySequenceContext{
+		c: c,
+		SimpleContext: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:       c.Views().KeySequence,
+			WindowName: "keySequence",
+			Key:        KEY_SEQUENCE_CONTEXT_KEY,
+			// not a temporary popup, so that it can be shown on top of a menu
+			// without closing it
+			Kind:                  types.PERSISTENT_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+	}
+}
+
+// Starts a new sequence with the given first key. The candidates are the
+// bindings whose key sequence starts with that key.
+func (self *KeySequenceContext) Start(key types.Key, candidates []*types.Binding) {
+	self.pressedKeys = []types.Key{key}
+	self.candidates = candidates
+	self.render()
+}
+
+// Adds a key to the sequence. Returns the binding whose key sequence has been
+// completed by it, if any, and whether the sequence can still be completed
+// otherwise.
+func (self *KeySequenceContext) Press(key types.Key) (*types.Binding, bool) {
+	self.pressedKeys = append(self.pressedKeys, key)
+	self.candidates = lo.Filter(self.candidates, func(binding *types.Binding, _ int) bool {
+		keys := keySequenceOf(binding)
+		return len(keys) >= len(self.pressedKeys) && keys[len(self.pressedKeys)-1] == key
+	})
+
+	// if one sequence is a prefix of another, the shorter one wins
+	if binding, ok := lo.Find(self.candidates, func(binding *types.Binding) bool {
+		return len(keySequenceOf(binding)) == len(self.pressedKeys)
+	}); ok {
+		return binding, false
+	}
+
+	if len(self.candidates) == 0 {
+		return nil, false
+	}
+
+	self.render()
+	return nil, true
+}
+
+// The number of lines of the popup's content
+func (self *KeySequenceContext) ContentHeight() int {
+	return max(len(self.candidates), 1)
+}
+
+func (self *KeySequenceContext) render() {
+	self.GetView().Title = strings.Join(lo.Map(self.pressedKeys, func(key types.Key, _ int) string {
+		return keybindings.LabelFromKey(key)
+	}), " ")
+
+	displayStrings := lo.Map(self.candidates, func(binding *types.Binding, _ int) []string {
+		remainingKeys := keySequenceOf(binding)[len(self.pressedKeys):]
+		keyLabel := strings.Join(lo.Map(remainingKeys, func(key types.Key, _ int) string {
+			return keybindings.LabelFromKey(key)
+		}), " ")
+		return []string{style.FgCyan.Sprint(keyLabel), binding.GetDescription()}
+	})
+	lines, _ := utils.RenderDisplayStrings(displayStrings, nil)
+	self.GetView().SetContent(strings.Join(lines, "\n"))
+}
+
+func (self *KeySequenceContext) HandleFocus(opts types.OnFocusOpts) {
+	self.SimpleContext.HandleFocus(opts)
+
+	// the view is only editable so that it receives all keys; there's no text
+	// to edit
+	self.c.GocuiGui().Cursor = false
+}
+
+func keySequenceOf(binding *types.Binding) []types.Key {
+	return keybindings.GetKeySequence(binding.Key.(types.KeySequence))
+}
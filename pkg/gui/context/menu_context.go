@@ -57,6 +57,8 @@ type MenuViewModel struct {
 	columnAlignment           []utils.Alignment
 	allowFilteringKeybindings bool
 	keybindingsTakePrecedence bool
+	useFuzzyFilter            bool
+	onCancel                  func()
 	*FilteredListViewModel[*types.MenuItem]
 }
 
@@ -122,6 +124,29 @@ func (self *MenuViewModel) SetKeybindingsTakePrecedence(value bool) {
 	self.keybindingsTakePrecedence = value
 }
 
+func (self *MenuViewModel) SetUseFuzzyFilter(value bool) {
+	self.useFuzzyFilter = value
+}
+
+func (self *MenuViewModel) SetFilter(filter string, useFuzzySearch bool) {
+	self.FilteredListViewModel.SetFilter(filter, useFuzzySearch || self.useFuzzyFilter)
+}
+
+func (self *MenuViewModel) ReApplyFilter(useFuzzySearch bool) {
+	self.FilteredListViewModel.ReApplyFilter(useFuzzySearch || self.useFuzzyFilter)
+}
+
+func (self *MenuViewModel) SetOnCancel(onCancel func()) {
+	self.onCancel = onCancel
+}
+
+// Called when the menu is closed without choosing an item
+func (self *MenuViewModel) OnCancel() {
+	if self.onCancel != nil {
+		self.onCancel()
+	}
+}
+
 // TODO: move into presentation package
 func (self *MenuViewModel) GetDisplayStrings(_ int, _ int) [][]string {
 	menuItems := self.FilteredListViewModel.GetItems()
@@ -236,6 +261,15 @@ func (self *MenuContext) OnMenuPress(selectedItem *types.MenuItem) error {
 		return nil
 	}
 
+	if selectedItem != nil && selectedItem.KeepOpen {
+		if err := selectedItem.OnPress(); err != nil {
+			return err
+		}
+
+		self.HandleRender()
+		return nil
+	}
+
 	self.c.Context().Pop()
 
 	if selectedItem == nil {
@@ -0,0 +1,87 @@
+package context
+
+import (
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// A popup listing several repos together with a summary of their state, so
+// that it's easy to see which of them need attention
+type RepoDashboardContext struct {
+	*FilteredListViewModel[*models.RepoSummary]
+	*ListContextTrait
+
+	summaries       []*models.RepoSummary
+	currentRepoPath string
+}
+
+var (
+	_ types.IListContext       = (*RepoDashboardContext)(nil)
+	_ types.IFilterableContext = (*RepoDashboardContext)(nil)
+)
+
+func NewRepoDashboardContext(c *ContextCommon) *RepoDashboardContext {
+	ctx := &RepoDashboardContext{}
+
+	viewModel := NewFilteredListViewModel(
+		func() []*models.RepoSummary { return ctx.summaries },
+		func(summary *models.RepoSummary) []string {
+			return []string{summary.Name(), summary.Branch}
+		},
+	)
+
+	getDisplayStrings := func(_ int, _ int) [][]string {
+		return presentation.GetRepoSummaryListDisplayStrings(
+			viewModel.GetItems(), ctx.currentRepoPath, c.State().GetItemOperation, c.Tr, time.Now(), c.UserConfig())
+	}
+
+	ctx.FilteredListViewModel = viewModel
+	ctx.ListContextTrait = &ListContextTrait{
+		Context: NewSimpleContext(NewBaseContext(NewBaseContextOpts{
+			View:                  c.Views().RepoDashboard,
+			WindowName:            "repoDashboard",
+			Key:                   REPO_DASHBOARD_CONTEXT_KEY,
+			Kind:                  types.TEMPORARY_POPUP,
+			Focusable:             true,
+			HasUncontrolledBounds: true,
+		})),
+		ListRenderer: ListRenderer{
+			list:              viewModel,
+			getDisplayStrings: getDisplayStrings,
+		},
+		c: c,
+	}
+
+	return ctx
+}
+
+// Shows the given repos, all of them not loaded yet
+func (self *RepoDashboardContext) ReInit(paths []string, currentRepoPath string) {
+	self.summaries = lo.Map(paths, func(path string, _ int) *models.RepoSummary {
+		return &models.RepoSummary{Path: path}
+	})
+	self.currentRepoPath = currentRepoPath
+
+	self.ClearFilter()
+	self.SetSelection(0)
+	self.GetView().SetOriginY(0)
+	self.GetView().Title = self.c.Tr.RepoDashboardTitle
+}
+
+// Replaces the summary of the repo with the same path
+func (self *RepoDashboardContext) SetSummary(summary *models.RepoSummary) {
+	for i, existing := range self.summaries {
+		if existing.Path == summary.Path {
+			self.summaries[i] = summary
+		}
+	}
+}
+
+// Returns the summaries of all repos, regardless of the filter
+func (self *RepoDashboardContext) Summaries() []*models.RepoSummary {
+	return self.summaries
+}
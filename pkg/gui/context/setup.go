@@ -1,6 +1,10 @@
 package context
 
-import "github.com/jesseduffield/lazygit/pkg/gui/types"
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
 
 func NewContextTree(c *ContextCommon) *ContextTree {
 	commitFilesContext := NewCommitFilesContext(c)
@@ -28,6 +32,12 @@ func NewContextTree(c *ContextCommon) *ContextTree {
 		Files:           NewWorkingTreeContext(c),
 		Submodules:      NewSubmodulesContext(c),
 		Menu:            NewMenuContext(c),
+		FilePicker:      NewFilePickerContext(c),
+		RepoDashboard:   NewRepoDashboardContext(c),
+		CommandHistory:  NewCommandHistoryContext(c),
+		Hooks:           NewHooksContext(c),
+		HookOutput:      NewHookOutputContext(c),
+		KeySequence:     NewKeySequenceContext(c),
 		Remotes:         NewRemotesContext(c),
 		Worktrees:       NewWorktreesContext(c),
 		RemoteBranches:  NewRemoteBranchesContext(c),
@@ -130,5 +140,12 @@ func NewContextTree(c *ContextCommon) *ContextTree {
 		Limit:         NewDisplayContext(LIMIT_CONTEXT_KEY, c.Views().Limit, "limit"),
 		StatusSpacer1: NewDisplayContext(STATUS_SPACER1_CONTEXT_KEY, c.Views().StatusSpacer1, "statusSpacer1"),
 		StatusSpacer2: NewDisplayContext(STATUS_SPACER2_CONTEXT_KEY, c.Views().StatusSpacer2, "statusSpacer2"),
+		// custom panels only get a view if they were configured at startup
+		CustomPanels: lo.FilterMap(c.UserConfig().CustomPanels, func(panel config.CustomPanel, _ int) (*CustomPanelContext, bool) {
+			if _, ok := c.Views().CustomPanels[panel.Key]; !ok {
+				return nil, false
+			}
+			return NewCustomPanelContext(c, panel), true
+		}),
 	}
 }
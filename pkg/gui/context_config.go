@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 )
@@ -10,13 +11,51 @@ func (gui *Gui) contextTree() *context.ContextTree {
 		IGuiCommon: gui.c.IGuiCommon,
 		Common:     gui.c.Common,
 	}
-	return context.NewContextTree(contextCommon)
+	contextTree := context.NewContextTree(contextCommon)
+
+	// windows that are shown as tabs of another window move into that window
+	layout := gui.c.UserConfig().Gui.SideWindowLayout
+	for _, ctx := range contextTree.Flatten() {
+		if hostWindow := layout.HostWindow(ctx.GetWindowName()); hostWindow != "" {
+			ctx.SetWindowName(hostWindow)
+		}
+	}
+
+	return contextTree
 }
 
 func (gui *Gui) defaultSideContext() types.Context {
-	if gui.State.Modes.Filtering.Active() {
+	layout := gui.c.UserConfig().Gui.SideWindowLayout
+	if gui.State.Modes.Filtering.Active() && isShownInLayout(gui.State.Contexts.LocalCommits, layout) {
 		return gui.State.Contexts.LocalCommits
 	}
 
-	return gui.State.Contexts.Files
+	return defaultSideContextForLayout(gui.State.Contexts, layout)
+}
+
+// Returns the files context, unless the layout hides the files window, in which
+// case it's the context of the topmost side window
+func defaultSideContextForLayout(contextTree *context.ContextTree, layout config.SideWindowLayoutConfig) types.Context {
+	if isShownInLayout(contextTree.Files, layout) {
+		return contextTree.Files
+	}
+
+	// validation makes sure there's at least one side window
+	switch layout.WindowNames()[0] {
+	case "status":
+		return contextTree.Status
+	case "branches":
+		return contextTree.Branches
+	case "commits":
+		return contextTree.LocalCommits
+	default:
+		return contextTree.Stash
+	}
+}
+
+// Whether the context's window is shown, either by itself or as tabs of another
+// window. Contexts that are shown as tabs have already been moved into the
+// other window by contextTree().
+func isShownInLayout(ctx types.Context, layout config.SideWindowLayoutConfig) bool {
+	return layout.HostWindow(ctx.GetWindowName()) != ""
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/services/custom_commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/plugins"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/remote"
 	"github.com/jesseduffield/lazygit/pkg/gui/status"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 )
@@ -27,8 +29,9 @@ func (gui *Gui) resetHelpersAndControllers() {
 	helperCommon := gui.c
 	recordDirectoryHelper := helpers.NewRecordDirectoryHelper(helperCommon)
 	reposHelper := helpers.NewRecentReposHelper(helperCommon, recordDirectoryHelper, gui.onSwitchToNewRepo)
-	rebaseHelper := helpers.NewMergeAndRebaseHelper(helperCommon)
-	refsHelper := helpers.NewRefsHelper(helperCommon, rebaseHelper)
+	protectedBranchesHelper := helpers.NewProtectedBranchesHelper(helperCommon)
+	rebaseHelper := helpers.NewMergeAndRebaseHelper(helperCommon, protectedBranchesHelper)
+	refsHelper := helpers.NewRefsHelper(helperCommon, rebaseHelper, protectedBranchesHelper)
 	suggestionsHelper := helpers.NewSuggestionsHelper(helperCommon)
 	worktreeHelper := helpers.NewWorktreeHelper(helperCommon, reposHelper, refsHelper, suggestionsHelper)
 
@@ -53,11 +56,13 @@ func (gui *Gui) resetHelpersAndControllers() {
 	)
 
 	gpgHelper := helpers.NewGpgHelper(helperCommon)
+	hooksHelper := helpers.NewHooksHelper(helperCommon)
 	viewHelper := helpers.NewViewHelper(helperCommon, gui.State.Contexts)
 	patchBuildingHelper := helpers.NewPatchBuildingHelper(helperCommon)
 	stagingHelper := helpers.NewStagingHelper(helperCommon)
 	mergeConflictsHelper := helpers.NewMergeConflictsHelper(helperCommon)
 	searchHelper := helpers.NewSearchHelper(helperCommon)
+	customPanelsHelper := helpers.NewCustomPanelsHelper(helperCommon)
 
 	refreshHelper := helpers.NewRefreshHelper(
 		helperCommon,
@@ -68,6 +73,7 @@ func (gui *Gui) resetHelpersAndControllers() {
 		mergeConflictsHelper,
 		worktreeHelper,
 		searchHelper,
+		customPanelsHelper,
 	)
 	diffHelper := helpers.NewDiffHelper(helperCommon)
 	cherryPickHelper := helpers.NewCherryPickHelper(
@@ -90,17 +96,21 @@ func (gui *Gui) resetHelpersAndControllers() {
 		modeHelper,
 	)
 
+	hostHelper := helpers.NewHostHelper(helperCommon)
+	undoJournalHelper := helpers.NewUndoJournalHelper(helperCommon)
+
 	gui.helpers = &helpers.Helpers{
 		Refs:            refsHelper,
-		Host:            helpers.NewHostHelper(helperCommon),
+		Host:            hostHelper,
+		PullRequest:     helpers.NewPullRequestHelper(helperCommon, hostHelper, refsHelper, commitsHelper, suggestionsHelper),
 		PatchBuilding:   patchBuildingHelper,
 		Staging:         stagingHelper,
 		Bisect:          bisectHelper,
 		Suggestions:     suggestionsHelper,
 		Files:           helpers.NewFilesHelper(helperCommon),
-		WorkingTree:     helpers.NewWorkingTreeHelper(helperCommon, refsHelper, commitsHelper, gpgHelper, rebaseHelper),
+		WorkingTree:     helpers.NewWorkingTreeHelper(helperCommon, refsHelper, commitsHelper, gpgHelper, rebaseHelper, protectedBranchesHelper, hooksHelper),
 		Tags:            helpers.NewTagsHelper(helperCommon, commitsHelper, gpgHelper),
-		BranchesHelper:  helpers.NewBranchesHelper(helperCommon, worktreeHelper),
+		BranchesHelper:  helpers.NewBranchesHelper(helperCommon, worktreeHelper, undoJournalHelper, protectedBranchesHelper),
 		GPG:             helpers.NewGpgHelper(helperCommon),
 		MergeAndRebase:  rebaseHelper,
 		MergeConflicts:  mergeConflictsHelper,
@@ -128,9 +138,15 @@ func (gui *Gui) resetHelpersAndControllers() {
 			modeHelper,
 			appStatusHelper,
 		),
-		Search:     searchHelper,
-		Worktree:   worktreeHelper,
-		SubCommits: helpers.NewSubCommitsHelper(helperCommon, refreshHelper),
+		Search:            searchHelper,
+		Worktree:          worktreeHelper,
+		SubCommits:        helpers.NewSubCommitsHelper(helperCommon, refreshHelper),
+		CustomPanels:      customPanelsHelper,
+		RepoDashboard:     helpers.NewRepoDashboardHelper(helperCommon, searchHelper),
+		CommandHistory:    helpers.NewCommandHistoryHelper(helperCommon),
+		UndoJournal:       undoJournalHelper,
+		ProtectedBranches: protectedBranchesHelper,
+		Hooks:             hooksHelper,
 	}
 
 	gui.CustomCommandsClient = custom_commands.NewClient(
@@ -138,6 +154,25 @@ func (gui *Gui) resetHelpersAndControllers() {
 		gui.helpers,
 	)
 
+	// plugins are started anew for each repo
+	if gui.PluginsClient != nil {
+		gui.PluginsClient.Stop()
+	}
+	gui.PluginsClient = plugins.NewClient(
+		helperCommon,
+		gui.helpers,
+	)
+
+	// the remote control server is restarted for each repo too, so that its
+	// commands act on the current one
+	if gui.RemoteControlServer != nil {
+		gui.RemoteControlServer.Stop()
+	}
+	gui.RemoteControlServer = remote.NewServer(
+		helperCommon,
+		gui.CustomCommandsClient,
+	)
+
 	common := controllers.NewControllerCommon(helperCommon, gui)
 
 	syncController := controllers.NewSyncController(
@@ -159,6 +194,11 @@ func (gui *Gui) resetHelpersAndControllers() {
 	remoteBranchesController := controllers.NewRemoteBranchesController(common)
 
 	menuController := controllers.NewMenuController(common)
+	filePickerController := controllers.NewFilePickerController(common)
+	repoDashboardController := controllers.NewRepoDashboardController(common)
+	commandHistoryController := controllers.NewCommandHistoryController(common)
+	hooksController := controllers.NewHooksController(common)
+	hookOutputController := controllers.NewHookOutputController(common)
 	localCommitsController := controllers.NewLocalCommitsController(common, syncController.HandlePull)
 	tagsController := controllers.NewTagsController(common)
 	filesController := controllers.NewFilesController(
@@ -278,6 +318,14 @@ func (gui *Gui) resetHelpersAndControllers() {
 		))
 	}
 
+	for _, context := range gui.State.Contexts.CustomPanels {
+		controllers.AttachControllers(context,
+			controllers.NewCustomPanelController(common, context),
+			sideWindowControllerFactory.Create(context),
+			controllers.NewSwitchToFocusedMainViewController(common, context),
+		)
+	}
+
 	for _, context := range []controllers.ContainsCommits{
 		gui.State.Contexts.LocalCommits,
 		gui.State.Contexts.ReflogCommits,
@@ -375,6 +423,28 @@ func (gui *Gui) resetHelpersAndControllers() {
 		menuController,
 	)
 
+	controllers.AttachControllers(gui.State.Contexts.FilePicker,
+		filePickerController,
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.RepoDashboard,
+		repoDashboardController,
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.CommandHistory,
+		commandHistoryController,
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.Hooks,
+		hooksController,
+	)
+
+	controllers.AttachControllers(gui.State.Contexts.HookOutput,
+		hookOutputController,
+		verticalScrollControllerFactory.Create(gui.State.Contexts.HookOutput),
+		viewSelectionControllerFactory.Create(gui.State.Contexts.HookOutput),
+	)
+
 	controllers.AttachControllers(gui.State.Contexts.CommitMessage,
 		commitMessageController,
 	)
@@ -433,7 +433,7 @@ func (self *BranchesController) handleCreatePullRequest(selectedBranch *models.B
 	if !selectedBranch.IsTrackingRemote() {
 		return errors.New(self.c.Tr.PullRequestNoUpstream)
 	}
-	return self.createPullRequest(selectedBranch.UpstreamBranch, "")
+	return self.createPullRequest(selectedBranch, "")
 }
 
 func (self *BranchesController) handleCreatePullRequestMenu(selectedBranch *models.Branch) error {
@@ -798,7 +798,7 @@ func (self *BranchesController) createPullRequestMenu(selectedBranch *models.Bra
 					if !checkedOutBranch.IsTrackingRemote() || !selectedBranch.IsTrackingRemote() {
 						return errors.New(self.c.Tr.PullRequestNoUpstream)
 					}
-					return self.createPullRequest(checkedOutBranch.UpstreamBranch, selectedBranch.UpstreamBranch)
+					return self.createPullRequest(checkedOutBranch, selectedBranch.UpstreamBranch)
 				},
 			},
 		)
@@ -823,26 +823,15 @@ func (self *BranchesController) promptForTargetBranchNameAndCreatePullRequest(fr
 		FindSuggestionsFunc: self.c.Helpers().Suggestions.GetRemoteBranchesForRemoteSuggestionsFunc(toRemote),
 		HandleConfirm: func(toBranch string) error {
 			self.c.Log.Debugf("PR will target branch '%s' on remote '%s'", toBranch, toRemote)
-			return self.createPullRequest(fromBranch.UpstreamBranch, toBranch)
+			return self.createPullRequest(fromBranch, toBranch)
 		},
 	})
 
 	return nil
 }
 
-func (self *BranchesController) createPullRequest(from string, to string) error {
-	url, err := self.c.Helpers().Host.GetPullRequestURL(from, to)
-	if err != nil {
-		return err
-	}
-
-	self.c.LogAction(self.c.Tr.Actions.OpenPullRequest)
-
-	if err := self.c.OS().OpenLink(url); err != nil {
-		return err
-	}
-
-	return nil
+func (self *BranchesController) createPullRequest(fromBranch *models.Branch, to string) error {
+	return self.c.Helpers().PullRequest.CreatePullRequest(fromBranch, to)
 }
 
 func (self *BranchesController) branchIsReal(branch *models.Branch) *types.DisabledReason {
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type CommandHistoryController struct {
+	baseController
+	*ListControllerTrait[*models.CommandHistoryEntry]
+	c *ControllerCommon
+}
+
+var _ types.IController = &CommandHistoryController{}
+
+func NewCommandHistoryController(
+	c *ControllerCommon,
+) *CommandHistoryController {
+	return &CommandHistoryController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().CommandHistory,
+			c.Contexts().CommandHistory.GetSelected,
+			c.Contexts().CommandHistory.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *CommandHistoryController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Key:               opts.GetKey(opts.Config.Universal.ConfirmMenu),
+			Handler:           self.withItem(self.viewDetails),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.ViewCommandDetails,
+			Tooltip:           self.c.Tr.ViewCommandDetailsTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.Select),
+			Handler:           self.withItem(self.rerun),
+			GetDisabledReason: self.require(self.singleItemSelected(self.canRerun)),
+			Description:       self.c.Tr.RerunCommand,
+			Tooltip:           self.c.Tr.RerunCommandTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.CopyToClipboard),
+			Handler:           self.withItem(self.copyToClipboard),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.CopyCommandToClipboard,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.Return),
+			Handler:         self.close,
+			Description:     self.c.Tr.CloseCancel,
+			DisplayOnScreen: true,
+		},
+	}
+
+	return bindings
+}
+
+func (self *CommandHistoryController) GetOnClick() func() error {
+	return self.withItemGraceful(self.viewDetails)
+}
+
+func (self *CommandHistoryController) viewDetails(entry *models.CommandHistoryEntry) error {
+	details := utils.ResolvePlaceholderString(self.c.Tr.CommandDetails, map[string]string{
+		"time":     entry.Time.Format(self.c.UserConfig().Gui.TimeFormat),
+		"duration": presentation.FormatCommandDuration(entry.Duration()),
+		"exitCode": strconv.Itoa(entry.ExitCode),
+	})
+	if entry.Shell {
+		details += "\n" + self.c.Tr.CommandRanInShell
+	}
+	if entry.Output != "" {
+		details += "\n\n" + self.c.Tr.CommandOutput + "\n" + entry.Output
+	}
+
+	self.c.Alert(entry.Command, details)
+	return nil
+}
+
+func (self *CommandHistoryController) rerun(entry *models.CommandHistoryEntry) error {
+	self.c.Confirm(types.ConfirmOpts{
+		Title: self.c.Tr.RerunCommand,
+		Prompt: utils.ResolvePlaceholderString(self.c.Tr.RerunCommandPrompt, map[string]string{
+			"command": entry.Command,
+		}),
+		HandleConfirm: func() error {
+			// close the history so that the user can watch the command log
+			self.c.Context().Pop()
+
+			return self.c.WithWaitingStatus(self.c.Tr.RunningCommand, func(task gocui.Task) error {
+				self.c.LogAction(self.c.Tr.Actions.RerunCommand)
+				// the command may be a push, pull or fetch that asks for credentials
+				err := self.c.OS().Cmd.New(entry.Args).SetWd(entry.Dir).
+					StreamOutput().PromptOnCredentialRequest(task).Run()
+				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+				return err
+			})
+		},
+	})
+
+	return nil
+}
+
+func (self *CommandHistoryController) canRerun(entry *models.CommandHistoryEntry) *types.DisabledReason {
+	if len(entry.Args) == 0 {
+		return &types.DisabledReason{Text: self.c.Tr.CommandCannotBeRerun}
+	}
+
+	return nil
+}
+
+func (self *CommandHistoryController) copyToClipboard(entry *models.CommandHistoryEntry) error {
+	if err := self.c.OS().CopyToClipboard(entry.Command); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.CommandCopiedToClipboard)
+	return nil
+}
+
+func (self *CommandHistoryController) close() error {
+	if self.context().IsFiltering() {
+		self.c.Helpers().Search.Cancel()
+		return nil
+	}
+
+	self.c.Context().Pop()
+	return nil
+}
+
+func (self *CommandHistoryController) context() *context.CommandHistoryContext {
+	return self.c.Contexts().CommandHistory
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// A menu of the actions of all side views, plus the global ones, that can be
+// searched fuzzily. Unlike the keybindings menu it isn't limited to the current
+// context; choosing an action of another view focuses that view first. The
+// items of menus that don't depend on the selection (e.g. the stash options)
+// are listed too, so that they can be run without going through their menu.
+type CommandPaletteAction struct {
+	c *ControllerCommon
+}
+
+type commandPaletteEntry struct {
+	binding *types.Binding
+	// if not nil, the entry is this item of the menu that the binding opens
+	menuItem *types.MenuItem
+	// nil for global bindings, and for those of the current context
+	context types.Context
+	title   string
+}
+
+func (self *CommandPaletteAction) Call() error {
+	entries := self.getEntries()
+
+	menuItems := lo.Map(entries, func(entry commandPaletteEntry, _ int) *types.MenuItem {
+		var disabledReason *types.DisabledReason
+		// the disabled reason of another context's binding may depend on that
+		// context being focused, so we only check it after switching to it
+		if entry.context == nil && entry.binding.GetDisabledReason != nil {
+			disabledReason = entry.binding.GetDisabledReason()
+		}
+
+		if entry.menuItem != nil {
+			return self.menuItemForMenuEntry(entry, disabledReason)
+		}
+
+		return &types.MenuItem{
+			LabelColumns: []string{entry.binding.GetDescription(), entry.title},
+			OpensMenu:    entry.binding.OpensMenu,
+			OnPress: func() error {
+				if entry.context != nil {
+					self.c.Context().Push(entry.context, types.OnFocusOpts{})
+				}
+
+				if entry.binding.Handler == nil {
+					return nil
+				}
+
+				return self.c.IGuiCommon.CallKeybindingHandler(entry.binding)
+			},
+			Key:            entry.binding.Key,
+			Tooltip:        entry.binding.Tooltip,
+			DisabledReason: disabledReason,
+		}
+	})
+
+	return self.c.Menu(types.CreateMenuOptions{
+		Title:                      self.c.Tr.CommandPalette,
+		Items:                      menuItems,
+		HideCancel:                 true,
+		ColumnAlignment:            []utils.Alignment{utils.AlignRight, utils.AlignLeft},
+		KeepConflictingKeybindings: true,
+		StartFiltering:             true,
+		UseFuzzyFilter:             true,
+	})
+}
+
+// Returns the entries of the current context first, then the global ones, then
+// those of the other side contexts
+func (self *CommandPaletteAction) getEntries() []commandPaletteEntry {
+	bindings, _ := self.c.GetInitialKeybindingsWithCustomCommands()
+	bindings = lo.Filter(bindings, func(binding *types.Binding, _ int) bool {
+		return binding.GetDescription() != "" && binding.Tag != "navigation"
+	})
+
+	currentContext := self.c.Context().Current()
+	entries := self.entriesForView(bindings, currentContext.GetViewName(), nil, viewDisplayName(currentContext.GetView()))
+	entries = append(entries, self.entriesForView(bindings, "", nil, self.c.Tr.KeybindingsMenuSectionGlobal)...)
+
+	sideWindows := self.c.Helpers().Window.SideWindows()
+	for _, context := range self.c.Contexts().Flatten() {
+		if context.GetKind() != types.SIDE_CONTEXT || context.IsTransient() ||
+			context.GetViewName() == currentContext.GetViewName() ||
+			!lo.Contains(sideWindows, context.GetWindowName()) {
+			continue
+		}
+
+		entries = append(entries, self.entriesForView(bindings, context.GetViewName(), context, viewDisplayName(context.GetView()))...)
+	}
+
+	return entries
+}
+
+func (self *CommandPaletteAction) entriesForView(bindings []*types.Binding, viewName string, context types.Context, title string) []commandPaletteEntry {
+	viewBindings := lo.Filter(bindings, func(binding *types.Binding, _ int) bool {
+		return binding.ViewName == viewName
+	})
+
+	return lo.FlatMap(uniqueBindings(viewBindings), func(binding *types.Binding, _ int) []commandPaletteEntry {
+		entries := []commandPaletteEntry{{binding: binding, context: context, title: title}}
+		if binding.GetMenuItems != nil {
+			for _, menuItem := range binding.GetMenuItems() {
+				entries = append(entries, commandPaletteEntry{binding: binding, menuItem: menuItem, context: context, title: title})
+			}
+		}
+		return entries
+	})
+}
+
+// Menu items are labelled with the description of the binding that opens their
+// menu, since labels like "continue" mean nothing on their own. They have no
+// key, since their key only works inside their menu.
+func (self *CommandPaletteAction) menuItemForMenuEntry(entry commandPaletteEntry, bindingDisabledReason *types.DisabledReason) *types.MenuItem {
+	label := entry.menuItem.Label
+	if len(entry.menuItem.LabelColumns) > 0 {
+		label = entry.menuItem.LabelColumns[0]
+	}
+
+	disabledReason := bindingDisabledReason
+	if disabledReason == nil {
+		disabledReason = entry.menuItem.DisabledReason
+	}
+
+	return &types.MenuItem{
+		LabelColumns: []string{entry.binding.GetDescription() + ": " + label, entry.title},
+		OpensMenu:    entry.menuItem.OpensMenu,
+		OnPress: func() error {
+			if entry.context != nil {
+				self.c.Context().Push(entry.context, types.OnFocusOpts{})
+			}
+
+			if entry.menuItem.OnPress == nil {
+				return nil
+			}
+
+			return entry.menuItem.OnPress()
+		},
+		Tooltip:        entry.menuItem.Tooltip,
+		DisabledReason: disabledReason,
+	}
+}
+
+// The name of the view as shown in its window: its tab if the window has tabs,
+// otherwise its title
+func viewDisplayName(view *gocui.View) string {
+	if view.TabIndex < len(view.Tabs) {
+		return view.Tabs[view.TabIndex]
+	}
+	return view.Title
+}
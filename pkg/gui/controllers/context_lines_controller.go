@@ -55,8 +55,8 @@ func (self *ContextLinesController) Increase() error {
 		return err
 	}
 
-	if self.c.UserConfig().Git.DiffContextSize < math.MaxUint64 {
-		self.c.UserConfig().Git.DiffContextSize++
+	if size := self.c.Git().Diff.DiffContextSize(); size < math.MaxUint64 {
+		self.c.State().GetRepoState().SetDiffContextSize(size + 1)
 	}
 	return self.applyChange()
 }
@@ -66,14 +66,14 @@ func (self *ContextLinesController) Decrease() error {
 		return err
 	}
 
-	if self.c.UserConfig().Git.DiffContextSize > 0 {
-		self.c.UserConfig().Git.DiffContextSize--
+	if size := self.c.Git().Diff.DiffContextSize(); size > 0 {
+		self.c.State().GetRepoState().SetDiffContextSize(size - 1)
 	}
 	return self.applyChange()
 }
 
 func (self *ContextLinesController) applyChange() error {
-	self.c.Toast(fmt.Sprintf(self.c.Tr.DiffContextSizeChanged, self.c.UserConfig().Git.DiffContextSize))
+	self.c.Toast(fmt.Sprintf(self.c.Tr.DiffContextSizeChanged, self.c.Git().Diff.DiffContextSize()))
 
 	currentContext := self.c.Context().CurrentSide()
 	switch currentContext.GetKey() {
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"text/template"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Controller for a user-defined custom panel. The panel itself has no
+// keybindings of its own; users add them as custom commands whose context is
+// the panel's key.
+
+type CustomPanelController struct {
+	baseController
+	*ListControllerTrait[*models.CustomPanelItem]
+	c *ControllerCommon
+
+	context *context.CustomPanelContext
+}
+
+var _ types.IController = &CustomPanelController{}
+
+func NewCustomPanelController(
+	c *ControllerCommon,
+	context *context.CustomPanelContext,
+) *CustomPanelController {
+	return &CustomPanelController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			context,
+			context.GetSelected,
+			context.GetSelectedItems,
+		),
+		c:       c,
+		context: context,
+	}
+}
+
+func (self *CustomPanelController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	return nil
+}
+
+func (self *CustomPanelController) GetOnRenderToMain() func() {
+	return func() {
+		panel := self.context.GetPanel()
+		self.c.RenderToMainViews(types.RefreshMainOpts{
+			Pair: self.c.MainViewPairs().Normal,
+			Main: &types.ViewUpdateOpts{
+				Title: panel.Title,
+				Task:  self.mainViewTask(),
+			},
+		})
+	}
+}
+
+func (self *CustomPanelController) mainViewTask() types.UpdateTask {
+	item := self.context.GetSelected()
+	if item == nil {
+		if err := self.context.GetLoadError(); err != nil {
+			return types.NewRenderStringTask(err.Error())
+		}
+		return types.NewRenderStringTask(self.c.Tr.NoItems)
+	}
+
+	preview := self.context.GetPanel().Preview
+	if preview == "" {
+		return types.NewRenderStringTask(item.Label)
+	}
+
+	cmdStr, err := utils.ResolveTemplate(
+		preview,
+		struct{ SelectedCustomItem *models.CustomPanelItem }{item},
+		template.FuncMap{"quote": self.c.OS().Quote},
+	)
+	if err != nil {
+		return types.NewRenderStringTask(err.Error())
+	}
+
+	cmdObj := self.c.OS().Cmd.NewShell(cmdStr, self.c.UserConfig().OS.ShellFunctionsFile)
+	return types.NewRunPtyTask(cmdObj.GetCmd())
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type FilePickerController struct {
+	baseController
+	*ListControllerTrait[*filetree.CommitFileNode]
+	c *ControllerCommon
+}
+
+var _ types.IController = &FilePickerController{}
+
+func NewFilePickerController(
+	c *ControllerCommon,
+) *FilePickerController {
+	return &FilePickerController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().FilePicker,
+			c.Contexts().FilePicker.GetSelected,
+			c.Contexts().FilePicker.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *FilePickerController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Key:               opts.GetKey(opts.Config.Universal.Select),
+			Handler:           self.withItem(self.toggleSelected),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.ToggleFileSelection,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.ConfirmMenu),
+			Handler:           self.withItem(self.press),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.PickFile,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.Return),
+			Handler:         self.close,
+			Description:     self.c.Tr.CloseCancel,
+			DisplayOnScreen: true,
+		},
+		{
+			Key:         opts.GetKey(opts.Config.Files.CollapseAll),
+			Handler:     self.collapseAll,
+			Description: self.c.Tr.CollapseAll,
+		},
+		{
+			Key:         opts.GetKey(opts.Config.Files.ExpandAll),
+			Handler:     self.expandAll,
+			Description: self.c.Tr.ExpandAll,
+		},
+	}
+
+	return bindings
+}
+
+func (self *FilePickerController) GetOnClick() func() error {
+	return self.withItemGraceful(self.press)
+}
+
+// Toggles a directory, or picks a file. When several files can be picked, this
+// confirms the selection, or picks just this file if nothing is selected.
+func (self *FilePickerController) press(node *filetree.CommitFileNode) error {
+	if !node.IsFile() {
+		self.context().ToggleCollapsed(node.GetInternalPath())
+		self.c.PostRefreshUpdate(self.context())
+		return nil
+	}
+
+	paths := []string{node.GetPath()}
+	if selectedPaths := self.context().SelectedPaths(); self.context().IsMultiSelect() && len(selectedPaths) > 0 {
+		paths = selectedPaths
+	}
+
+	self.c.Context().Pop()
+	return self.context().Confirm(paths)
+}
+
+func (self *FilePickerController) toggleSelected(node *filetree.CommitFileNode) error {
+	if !self.context().IsMultiSelect() {
+		return self.press(node)
+	}
+
+	self.context().ToggleSelected(node)
+	self.c.PostRefreshUpdate(self.context())
+	return nil
+}
+
+func (self *FilePickerController) close() error {
+	if self.context().IsFiltering() {
+		self.c.Helpers().Search.Cancel()
+		return nil
+	}
+
+	self.c.Context().Pop()
+	return nil
+}
+
+func (self *FilePickerController) collapseAll() error {
+	self.context().CollapseAll()
+	self.c.PostRefreshUpdate(self.context())
+	return nil
+}
+
+func (self *FilePickerController) expandAll() error {
+	self.context().ExpandAll()
+	self.c.PostRefreshUpdate(self.context())
+	return nil
+}
+
+func (self *FilePickerController) context() *context.FilePickerContext {
+	return self.c.Contexts().FilePicker
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -123,11 +124,12 @@ func (self *FilesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			DisplayOnScreen: true,
 		},
 		{
-			Key:         opts.GetKey(opts.Config.Files.ViewStashOptions),
-			Handler:     self.createStashMenu,
-			Description: self.c.Tr.ViewStashOptions,
-			Tooltip:     self.c.Tr.ViewStashOptionsTooltip,
-			OpensMenu:   true,
+			Key:          opts.GetKey(opts.Config.Files.ViewStashOptions),
+			Handler:      self.createStashMenu,
+			Description:  self.c.Tr.ViewStashOptions,
+			Tooltip:      self.c.Tr.ViewStashOptionsTooltip,
+			OpensMenu:    true,
+			GetMenuItems: self.stashMenuItems,
 		},
 		{
 			Key:         opts.GetKey(opts.Config.Files.ToggleStagedAll),
@@ -164,6 +166,7 @@ func (self *FilesController) GetKeybindings(opts types.KeybindingsOpts) []*types
 			Tooltip:         self.c.Tr.FileResetOptionsTooltip,
 			OpensMenu:       true,
 			DisplayOnScreen: true,
+			GetMenuItems:    self.resetMenuItems,
 		},
 		{
 			Key:         opts.GetKey(opts.Config.Files.ToggleTreeView),
@@ -863,6 +866,11 @@ func (self *FilesController) handleAmendCommitPress() error {
 			return self.c.Helpers().AmendHelper.AmendHead()
 		})
 	}
+	// amending rewrites the head commit, just like amending it from the
+	// commits panel
+	withProtectionCheck := func(f func() error) error {
+		return self.c.Helpers().ProtectedBranches.WithCheckedOutBranchProtectionCheck(helpers.ProtectedBranchRebase, f)
+	}
 
 	if self.isResolvingConflicts() {
 		return self.c.Menu(types.CreateMenuOptions{
@@ -885,22 +893,24 @@ func (self *FilesController) handleAmendCommitPress() error {
 				{
 					Label: self.c.Tr.AmendCommitWithConflictsAmend,
 					OnPress: func() error {
-						return doAmend()
+						return withProtectionCheck(doAmend)
 					},
 				},
 			},
 		})
 	}
 
-	return self.c.ConfirmIf(!self.c.UserConfig().Gui.SkipAmendWarning,
-		types.ConfirmOpts{
-			Title:  self.c.Tr.AmendLastCommitTitle,
-			Prompt: self.c.Tr.SureToAmend,
-			HandleConfirm: func() error {
-				return doAmend()
+	return withProtectionCheck(func() error {
+		return self.c.ConfirmIf(!self.c.UserConfig().Gui.SkipAmendWarning,
+			types.ConfirmOpts{
+				Title:  self.c.Tr.AmendLastCommitTitle,
+				Prompt: self.c.Tr.SureToAmend,
+				HandleConfirm: func() error {
+					return doAmend()
+				},
 			},
-		},
-	)
+		)
+	})
 }
 
 func (self *FilesController) isResolvingConflicts() bool {
@@ -1059,62 +1069,66 @@ func (self *FilesController) switchToMerge() error {
 func (self *FilesController) createStashMenu() error {
 	return self.c.Menu(types.CreateMenuOptions{
 		Title: self.c.Tr.StashOptions,
-		Items: []*types.MenuItem{
-			{
-				Label: self.c.Tr.StashAllChanges,
-				OnPress: func() error {
-					if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
-						return errors.New(self.c.Tr.NoFilesToStash)
-					}
-					return self.handleStashSave(self.c.Git().Stash.Push, self.c.Tr.Actions.StashAllChanges)
-				},
-				Key: 'a',
+		Items: self.stashMenuItems(),
+	})
+}
+
+func (self *FilesController) stashMenuItems() []*types.MenuItem {
+	return []*types.MenuItem{
+		{
+			Label: self.c.Tr.StashAllChanges,
+			OnPress: func() error {
+				if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
+					return errors.New(self.c.Tr.NoFilesToStash)
+				}
+				return self.handleStashSave(self.c.Git().Stash.Push, self.c.Tr.Actions.StashAllChanges)
 			},
-			{
-				Label: self.c.Tr.StashAllChangesKeepIndex,
-				OnPress: func() error {
-					if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
-						return errors.New(self.c.Tr.NoFilesToStash)
-					}
-					// if there are no staged files it behaves the same as Stash.Save
-					return self.handleStashSave(self.c.Git().Stash.StashAndKeepIndex, self.c.Tr.Actions.StashAllChangesKeepIndex)
-				},
-				Key: 'i',
+			Key: 'a',
+		},
+		{
+			Label: self.c.Tr.StashAllChangesKeepIndex,
+			OnPress: func() error {
+				if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
+					return errors.New(self.c.Tr.NoFilesToStash)
+				}
+				// if there are no staged files it behaves the same as Stash.Save
+				return self.handleStashSave(self.c.Git().Stash.StashAndKeepIndex, self.c.Tr.Actions.StashAllChangesKeepIndex)
 			},
-			{
-				Label: self.c.Tr.StashIncludeUntrackedChanges,
-				OnPress: func() error {
-					return self.handleStashSave(self.c.Git().Stash.StashIncludeUntrackedChanges, self.c.Tr.Actions.StashIncludeUntrackedChanges)
-				},
-				Key: 'U',
+			Key: 'i',
+		},
+		{
+			Label: self.c.Tr.StashIncludeUntrackedChanges,
+			OnPress: func() error {
+				return self.handleStashSave(self.c.Git().Stash.StashIncludeUntrackedChanges, self.c.Tr.Actions.StashIncludeUntrackedChanges)
 			},
-			{
-				Label: self.c.Tr.StashStagedChanges,
-				OnPress: func() error {
-					// there must be something in staging otherwise the current implementation mucks the stash up
-					if !self.c.Helpers().WorkingTree.AnyStagedFilesExceptSubmodules() {
-						return errors.New(self.c.Tr.NoTrackedStagedFilesStash)
-					}
-					return self.handleStashSave(self.c.Git().Stash.SaveStagedChanges, self.c.Tr.Actions.StashStagedChanges)
-				},
-				Key: 's',
+			Key: 'U',
+		},
+		{
+			Label: self.c.Tr.StashStagedChanges,
+			OnPress: func() error {
+				// there must be something in staging otherwise the current implementation mucks the stash up
+				if !self.c.Helpers().WorkingTree.AnyStagedFilesExceptSubmodules() {
+					return errors.New(self.c.Tr.NoTrackedStagedFilesStash)
+				}
+				return self.handleStashSave(self.c.Git().Stash.SaveStagedChanges, self.c.Tr.Actions.StashStagedChanges)
 			},
-			{
-				Label: self.c.Tr.StashUnstagedChanges,
-				OnPress: func() error {
-					if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
-						return errors.New(self.c.Tr.NoFilesToStash)
-					}
-					if self.c.Helpers().WorkingTree.AnyStagedFilesExceptSubmodules() {
-						return self.handleStashSave(self.c.Git().Stash.StashUnstagedChanges, self.c.Tr.Actions.StashUnstagedChanges)
-					}
-					// ordinary stash
-					return self.handleStashSave(self.c.Git().Stash.Push, self.c.Tr.Actions.StashUnstagedChanges)
-				},
-				Key: 'u',
+			Key: 's',
+		},
+		{
+			Label: self.c.Tr.StashUnstagedChanges,
+			OnPress: func() error {
+				if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
+					return errors.New(self.c.Tr.NoFilesToStash)
+				}
+				if self.c.Helpers().WorkingTree.AnyStagedFilesExceptSubmodules() {
+					return self.handleStashSave(self.c.Git().Stash.StashUnstagedChanges, self.c.Tr.Actions.StashUnstagedChanges)
+				}
+				// ordinary stash
+				return self.handleStashSave(self.c.Git().Stash.Push, self.c.Tr.Actions.StashUnstagedChanges)
 			},
+			Key: 'u',
 		},
-	})
+	}
 }
 
 func (self *FilesController) openMergeConflictMenu(nodes []*filetree.FileNode) error {
@@ -43,6 +43,7 @@ func (self *GlobalController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			Tooltip:           self.c.Tr.ViewMergeRebaseOptionsTooltip,
 			OpensMenu:         true,
 			GetDisabledReason: self.canShowRebaseOptions,
+			GetMenuItems:      self.c.Helpers().MergeAndRebase.RebaseOptionsMenuItems,
 		},
 		{
 			Key:         opts.GetKey(opts.Config.Universal.Refresh),
@@ -94,6 +95,12 @@ func (self *GlobalController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			DisplayOnScreen:   true,
 			GetDisabledReason: self.optionsMenuDisabledReason,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Universal.CommandPalette),
+			Handler:     opts.Guards.NoPopupPanel(self.openCommandPalette),
+			Description: self.c.Tr.OpenCommandPalette,
+			Tooltip:     self.c.Tr.OpenCommandPaletteTooltip,
+		},
 		{
 			ViewName:    "",
 			Key:         opts.GetKey(opts.Config.Universal.FilteringMenu),
@@ -205,6 +212,10 @@ func (self *GlobalController) createOptionsMenu() error {
 	return (&OptionsMenuAction{c: self.c}).Call()
 }
 
+func (self *GlobalController) openCommandPalette() error {
+	return (&CommandPaletteAction{c: self.c}).Call()
+}
+
 func (self *GlobalController) optionsMenuDisabledReason() *types.DisabledReason {
 	ctx := self.c.Context().Current()
 	// Don't show options menu while displaying popup.
@@ -15,18 +15,34 @@ import (
 )
 
 type BranchesHelper struct {
-	c              *HelperCommon
-	worktreeHelper *WorktreeHelper
+	c                       *HelperCommon
+	worktreeHelper          *WorktreeHelper
+	undoJournalHelper       *UndoJournalHelper
+	protectedBranchesHelper *ProtectedBranchesHelper
 }
 
-func NewBranchesHelper(c *HelperCommon, worktreeHelper *WorktreeHelper) *BranchesHelper {
+func NewBranchesHelper(
+	c *HelperCommon,
+	worktreeHelper *WorktreeHelper,
+	undoJournalHelper *UndoJournalHelper,
+	protectedBranchesHelper *ProtectedBranchesHelper,
+) *BranchesHelper {
 	return &BranchesHelper{
-		c:              c,
-		worktreeHelper: worktreeHelper,
+		c:                       c,
+		worktreeHelper:          worktreeHelper,
+		undoJournalHelper:       undoJournalHelper,
+		protectedBranchesHelper: protectedBranchesHelper,
 	}
 }
 
 func (self *BranchesHelper) ConfirmLocalDelete(branches []*models.Branch) error {
+	branchNames := lo.Map(branches, func(branch *models.Branch, _ int) string { return branch.Name })
+	return self.protectedBranchesHelper.WithProtectionCheck(ProtectedBranchDelete, branchNames, func() error {
+		return self.confirmLocalDelete(branches)
+	})
+}
+
+func (self *BranchesHelper) confirmLocalDelete(branches []*models.Branch) error {
 	if len(branches) > 1 {
 		if lo.SomeBy(branches, func(branch *models.Branch) bool { return self.checkedOutByOtherWorktree(branch) }) {
 			return errors.New(self.c.Tr.SomeBranchesCheckedOutByWorktreeError)
@@ -47,6 +63,7 @@ func (self *BranchesHelper) ConfirmLocalDelete(branches []*models.Branch) error
 			if err := self.c.Git().Branch.LocalDelete(branchNames, true); err != nil {
 				return err
 			}
+			self.undoJournalHelper.RecordBranchDeletion(branches)
 
 			self.c.Contexts().Branches.CollapseRangeSelectionToTop()
 			self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.BRANCHES}})
@@ -83,6 +100,13 @@ func (self *BranchesHelper) ConfirmLocalDelete(branches []*models.Branch) error
 }
 
 func (self *BranchesHelper) ConfirmDeleteRemote(remoteBranches []*models.RemoteBranch, resetRemoteBranchesSelection bool) error {
+	branchNames := lo.Map(remoteBranches, func(branch *models.RemoteBranch, _ int) string { return branch.Name })
+	return self.protectedBranchesHelper.WithProtectionCheck(ProtectedBranchDelete, branchNames, func() error {
+		return self.confirmDeleteRemote(remoteBranches, resetRemoteBranchesSelection)
+	})
+}
+
+func (self *BranchesHelper) confirmDeleteRemote(remoteBranches []*models.RemoteBranch, resetRemoteBranchesSelection bool) error {
 	var title string
 	if len(remoteBranches) == 1 {
 		title = utils.ResolvePlaceholderString(
@@ -127,6 +151,19 @@ func (self *BranchesHelper) ConfirmDeleteRemote(remoteBranches []*models.RemoteB
 }
 
 func (self *BranchesHelper) ConfirmLocalAndRemoteDelete(branches []*models.Branch) error {
+	branchNames := lo.FlatMap(branches, func(branch *models.Branch, _ int) []string {
+		// a branch without an upstream has no remote branch to delete
+		if branch.UpstreamBranch == "" {
+			return []string{branch.Name}
+		}
+		return []string{branch.Name, branch.UpstreamBranch}
+	})
+	return self.protectedBranchesHelper.WithProtectionCheck(ProtectedBranchDelete, branchNames, func() error {
+		return self.confirmLocalAndRemoteDelete(branches)
+	})
+}
+
+func (self *BranchesHelper) confirmLocalAndRemoteDelete(branches []*models.Branch) error {
 	if lo.SomeBy(branches, func(branch *models.Branch) bool { return self.checkedOutByOtherWorktree(branch) }) {
 		return errors.New(self.c.Tr.SomeBranchesCheckedOutByWorktreeError)
 	}
@@ -182,6 +219,7 @@ func (self *BranchesHelper) ConfirmLocalAndRemoteDelete(branches []*models.Branc
 				if err := self.c.Git().Branch.LocalDelete(branchNames, true); err != nil {
 					return err
 				}
+				self.undoJournalHelper.RecordBranchDeletion(branches)
 
 				self.c.Contexts().Branches.CollapseRangeSelectionToTop()
 				self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.BRANCHES, types.REMOTES}})
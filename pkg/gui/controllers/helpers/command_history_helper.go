@@ -0,0 +1,257 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// Output longer than this is truncated before it is saved to the history
+const maxCommandHistoryOutputLength = 10000
+
+// Saves the commands shown in the command log to a history file per repo, and
+// loads them again for the command history popup
+type CommandHistoryHelper struct {
+	c *HelperCommon
+
+	// guards the history files and entryCounts
+	mutex sync.Mutex
+	// the number of entries in each history file that we appended to, so that
+	// we don't have to count them again for every command
+	entryCounts map[string]int
+
+	// entries that were recorded but not written yet, in the order they were
+	// recorded, and whether a worker is currently writing them
+	queueMutex sync.Mutex
+	queue      []queuedCommandHistoryEntry
+	writing    bool
+}
+
+type queuedCommandHistoryEntry struct {
+	path       string
+	maxEntries int
+	entry      *models.CommandHistoryEntry
+}
+
+func NewCommandHistoryHelper(c *HelperCommon) *CommandHistoryHelper {
+	return &CommandHistoryHelper{
+		c:           c,
+		entryCounts: map[string]int{},
+	}
+}
+
+// Adds the given command to the history of the repo that it ran in. Can be
+// called from any goroutine; the entry is written in the background so that
+// the command runner doesn't have to wait for the file I/O.
+func (self *CommandHistoryHelper) Record(result oscommands.CmdResult) {
+	historyConfig := self.c.UserConfig().CommandHistory
+	if !historyConfig.Enabled || self.c.Git() == nil {
+		return
+	}
+
+	// commands without an explicit directory run in our working directory,
+	// which is the worktree of the current repo; the others (e.g. fetching
+	// another repo from the repo dashboard) belong to the history of the repo
+	// they ran in
+	repoPath := self.c.Git().RepoPaths.WorktreePath()
+	if result.Dir != "" {
+		repoPath = result.Dir
+	}
+	entry := &models.CommandHistoryEntry{
+		Repo:       repoPath,
+		Command:    result.Command,
+		Args:       result.Args,
+		Dir:        repoPath,
+		Shell:      result.Shell,
+		Time:       result.Start,
+		DurationMs: result.Duration.Milliseconds(),
+		ExitCode:   result.ExitCode,
+	}
+	if historyConfig.SaveOutput {
+		entry.Output = truncateCommandOutput(result.Output, maxCommandHistoryOutputLength)
+	}
+
+	path, err := config.CommandHistoryPath(repoPath)
+	if err != nil {
+		self.c.Log.Error(err)
+		return
+	}
+
+	self.queueMutex.Lock()
+	defer self.queueMutex.Unlock()
+
+	self.queue = append(self.queue, queuedCommandHistoryEntry{path: path, maxEntries: historyConfig.MaxEntries, entry: entry})
+	if !self.writing {
+		self.writing = true
+		self.c.OnWorker(self.writeQueuedEntries)
+	}
+}
+
+// Writes the queued entries one by one until the queue is empty. Only one
+// worker does this at a time, so that the entries are written in order.
+func (self *CommandHistoryHelper) writeQueuedEntries(gocui.Task) error {
+	for {
+		self.queueMutex.Lock()
+		if len(self.queue) == 0 {
+			self.writing = false
+			self.queueMutex.Unlock()
+			return nil
+		}
+		queued := self.queue[0]
+		self.queue = self.queue[1:]
+		self.queueMutex.Unlock()
+
+		self.write(queued)
+	}
+}
+
+func (self *CommandHistoryHelper) write(queued queuedCommandHistoryEntry) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	count, ok := self.entryCounts[queued.path]
+	if !ok {
+		entries, err := loadCommandHistoryFile(queued.path)
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+		count = len(entries)
+	}
+
+	count, err := appendCommandHistoryEntry(queued.path, queued.maxEntries, count, queued.entry)
+	if err != nil {
+		self.c.Log.Error(err)
+		return
+	}
+	self.entryCounts[queued.path] = count
+}
+
+func (self *CommandHistoryHelper) Open() error {
+	entries, err := self.Load()
+	if err != nil {
+		return err
+	}
+
+	self.c.Contexts().CommandHistory.ReInit(entries)
+	self.c.PostRefreshUpdate(self.c.Contexts().CommandHistory)
+	self.c.Context().Push(self.c.Contexts().CommandHistory, types.OnFocusOpts{})
+	return nil
+}
+
+// Returns the history of the current repo, most recent command first
+func (self *CommandHistoryHelper) Load() ([]*models.CommandHistoryEntry, error) {
+	path, err := config.CommandHistoryPath(self.c.Git().RepoPaths.WorktreePath())
+	if err != nil {
+		return nil, err
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return loadCommandHistory(path)
+}
+
+// Appends the entry to the history file at the given path, which currently
+// holds the given number of entries. If it is full, it is first moved aside so
+// that a new one is started, replacing the one that was moved aside before.
+// Returns the number of entries in the file afterwards.
+func appendCommandHistoryEntry(path string, maxEntries int, count int, entry *models.CommandHistoryEntry) (int, error) {
+	if count >= maxEntries {
+		if err := os.Rename(path, rotatedCommandHistoryPath(path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return count, err
+		}
+		count = 0
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return count, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return count, err
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return count, err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return count, err
+	}
+	return count + 1, nil
+}
+
+// Loads the entries of the history file at the given path and of the one that
+// was rotated out before it, most recent entry first
+func loadCommandHistory(path string) ([]*models.CommandHistoryEntry, error) {
+	rotatedEntries, err := loadCommandHistoryFile(rotatedCommandHistoryPath(path))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := loadCommandHistoryFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = append(rotatedEntries, entries...)
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// Returns the entries of a single history file in the order they were added.
+// A missing file counts as empty, and lines that can't be parsed (e.g. because
+// we were killed while writing them) are skipped.
+func loadCommandHistoryFile(path string) ([]*models.CommandHistoryEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := []*models.CommandHistoryEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		var entry models.CommandHistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Cuts the output down to at most maxLength bytes (plus an ellipsis), without
+// splitting a multi-byte character
+func truncateCommandOutput(output string, maxLength int) string {
+	if len(output) <= maxLength {
+		return output
+	}
+
+	end := maxLength
+	for end > 0 && !utf8.RuneStart(output[end]) {
+		end--
+	}
+	return output[:end] + "…"
+}
+
+func rotatedCommandHistoryPath(path string) string {
+	return path + ".1"
+}
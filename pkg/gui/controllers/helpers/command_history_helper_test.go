@@ -0,0 +1,104 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandHistoryRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "command_history", "repo.jsonl")
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	count := 0
+	for i, command := range []string{"git one", "git two", "git three", "git four", "git five"} {
+		var err error
+		count, err = appendCommandHistoryEntry(path, 2, count, &models.CommandHistoryEntry{
+			Repo:     "/repo",
+			Command:  command,
+			Time:     start.Add(time.Duration(i) * time.Second),
+			ExitCode: i % 2,
+		})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, count)
+
+	entries, err := loadCommandHistory(path)
+	assert.NoError(t, err)
+	// the oldest file was replaced when the second rotation happened
+	assert.Equal(t,
+		[]string{"git five", "git four", "git three"},
+		lo.Map(entries, func(entry *models.CommandHistoryEntry, _ int) string { return entry.Command }),
+	)
+	assert.Equal(t, start.Add(4*time.Second), entries[0].Time.UTC())
+	assert.True(t, entries[0].Succeeded())
+	assert.False(t, entries[1].Succeeded())
+}
+
+func TestLoadCommandHistoryFile(t *testing.T) {
+	dir := t.TempDir()
+
+	entries, err := loadCommandHistoryFile(filepath.Join(dir, "missing.jsonl"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	path := filepath.Join(dir, "history.jsonl")
+	content := `{"repo":"/repo","command":"git status","time":"2024-01-01T12:00:00Z","durationMs":12,"exitCode":0}
+{"repo":"/repo","command":"git pu
+{"repo":"/repo","command":"git push","time":"2024-01-01T12:00:01Z","durationMs":3400,"exitCode":128,"output":"rejected"}
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	entries, err = loadCommandHistoryFile(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "git status", entries[0].Command)
+	assert.Equal(t, 3400*time.Millisecond, entries[1].Duration())
+	assert.Equal(t, 128, entries[1].ExitCode)
+	assert.Equal(t, "rejected", entries[1].Output)
+}
+
+func TestTruncateCommandOutput(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		output    string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "short output",
+			output:    "done",
+			maxLength: 4,
+			expected:  "done",
+		},
+		{
+			name:      "long output",
+			output:    "done.",
+			maxLength: 4,
+			expected:  "done…",
+		},
+		{
+			name:      "limit in the middle of a multi-byte character",
+			output:    "abcäöü",
+			maxLength: 6,
+			expected:  "abcä…",
+		},
+		{
+			name:      "limit at the start of a multi-byte character",
+			output:    "abcäöü",
+			maxLength: 5,
+			expected:  "abcä…",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, truncateCommandOutput(s.output, s.maxLength))
+		})
+	}
+}
@@ -318,6 +318,18 @@ func (self *ConfirmationHelper) ResizeCurrentPopupPanels() {
 		switch c {
 		case self.c.Contexts().Menu:
 			self.resizeMenu(parentPopupContext)
+		case self.c.Contexts().FilePicker:
+			self.resizeFilePicker(parentPopupContext)
+		case self.c.Contexts().RepoDashboard:
+			self.resizeRepoDashboard(parentPopupContext)
+		case self.c.Contexts().CommandHistory:
+			self.resizeCommandHistory(parentPopupContext)
+		case self.c.Contexts().Hooks:
+			self.resizeHooks(parentPopupContext)
+		case self.c.Contexts().HookOutput:
+			self.resizeHookOutput(parentPopupContext)
+		case self.c.Contexts().KeySequence:
+			self.resizeKeySequence(parentPopupContext)
 		case self.c.Contexts().Confirmation:
 			self.resizeConfirmationPanel(parentPopupContext)
 		case self.c.Contexts().Prompt, self.c.Contexts().Suggestions:
@@ -352,6 +364,53 @@ func (self *ConfirmationHelper) resizeMenu(parentPopupContext types.Context) {
 	_, _ = self.c.GocuiGui().SetView(self.c.Views().Tooltip.Name(), x0, tooltipTop, x1, tooltipTop+tooltipHeight-1, 0)
 }
 
+func (self *ConfirmationHelper) resizeFilePicker(parentPopupContext types.Context) {
+	// like for the menu, we use the unfiltered length so that the panel doesn't
+	// resize when filtering or collapsing directories
+	itemCount := self.c.Contexts().FilePicker.UnfilteredLen()
+	contentWidth := self.getPopupPanelWidth(90) - 2 // minus 2 for the frame
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, max(itemCount, 1), parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(self.c.Views().FilePicker.Name(), x0, y0, x1, y1, 0)
+}
+
+func (self *ConfirmationHelper) resizeRepoDashboard(parentPopupContext types.Context) {
+	itemCount := len(self.c.Contexts().RepoDashboard.Summaries())
+	contentWidth := self.getPopupPanelWidth(120) - 2 // minus 2 for the frame
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, max(itemCount, 1), parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(self.c.Views().RepoDashboard.Name(), x0, y0, x1, y1, 0)
+}
+
+func (self *ConfirmationHelper) resizeCommandHistory(parentPopupContext types.Context) {
+	// use the unfiltered length so that the panel doesn't resize when filtering
+	itemCount := self.c.Contexts().CommandHistory.UnfilteredLen()
+	contentWidth := self.getPopupPanelWidth(120) - 2 // minus 2 for the frame
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, max(itemCount, 1), parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(self.c.Views().CommandHistory.Name(), x0, y0, x1, y1, 0)
+}
+
+func (self *ConfirmationHelper) resizeHooks(parentPopupContext types.Context) {
+	itemCount := self.c.Contexts().Hooks.Len()
+	contentWidth := self.getPopupPanelWidth(80) - 2 // minus 2 for the frame
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, max(itemCount, 1), parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(self.c.Views().Hooks.Name(), x0, y0, x1, y1, 0)
+}
+
+func (self *ConfirmationHelper) resizeHookOutput(parentPopupContext types.Context) {
+	// the height is capped at 3/4 of the screen; the rest can be scrolled to
+	contentWidth := self.getPopupPanelWidth(120) - 2 // minus 2 for the frame
+	hookOutputView := self.c.Views().HookOutput
+	contentHeight := getMessageHeight(true, false, self.c.Contexts().HookOutput.GetOutput(), contentWidth, hookOutputView.TabWidth)
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, max(contentHeight, 1), parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(hookOutputView.Name(), x0, y0, x1, y1, 0)
+}
+
+func (self *ConfirmationHelper) resizeKeySequence(parentPopupContext types.Context) {
+	contentWidth := self.getPopupPanelWidth(60) - 2 // minus 2 for the frame
+	contentHeight := self.c.Contexts().KeySequence.ContentHeight()
+	x0, y0, x1, y1 := self.getPopupPanelDimensionsForContentHeight(contentWidth, contentHeight, parentPopupContext)
+	_, _ = self.c.GocuiGui().SetView(self.c.Views().KeySequence.Name(), x0, y0, x1, y1, 0)
+}
+
 // Wraps the lines of the menu prompt to the available width and rerenders the
 // menu if needed. Returns the number of lines the prompt takes up.
 func (self *ConfirmationHelper) layoutMenuPrompt(contentWidth int) int {
@@ -0,0 +1,138 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// Loads the items of the user-defined custom panels by running their commands.
+
+type CustomPanelsHelper struct {
+	c *HelperCommon
+}
+
+func NewCustomPanelsHelper(c *HelperCommon) *CustomPanelsHelper {
+	return &CustomPanelsHelper{
+		c: c,
+	}
+}
+
+func (self *CustomPanelsHelper) Refresh() {
+	for _, customPanelContext := range self.c.Contexts().CustomPanels {
+		items, err := self.loadItems(customPanelContext.GetPanel())
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+		customPanelContext.SetItems(items, err)
+		self.c.PostRefreshUpdate(customPanelContext)
+	}
+}
+
+func (self *CustomPanelsHelper) loadItems(panel config.CustomPanel) ([]*models.CustomPanelItem, error) {
+	output, err := self.c.OS().Cmd.NewShell(panel.Command, self.c.UserConfig().OS.ShellFunctionsFile).DontLog().RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	var fieldsList []map[string]string
+	if panel.Format == "json" {
+		fieldsList, err = parseJsonItems(output)
+	} else {
+		fieldsList, err = parseLineItems(output, panel.Filter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing output of custom panel '%s': %w", panel.Key, err)
+	}
+
+	valueTemplate, err := template.New("valueFormat").Parse(panel.ValueFormat)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse valueFormat of custom panel '%s': %w", panel.Key, err)
+	}
+	labelTemplate, err := template.New("labelFormat").Funcs(style.TemplateFuncMapAddColors(template.FuncMap{})).Parse(panel.LabelFormat)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse labelFormat of custom panel '%s': %w", panel.Key, err)
+	}
+
+	items := make([]*models.CustomPanelItem, 0, len(fieldsList))
+	for _, fields := range fieldsList {
+		item := &models.CustomPanelItem{Fields: fields}
+
+		if panel.ValueFormat == "" {
+			item.Value = fields["line"]
+		} else if item.Value, err = executeItemTemplate(valueTemplate, fields); err != nil {
+			return nil, err
+		}
+
+		if panel.LabelFormat == "" {
+			item.Label = item.Value
+		} else if item.Label, err = executeItemTemplate(labelTemplate, fields); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Returns the fields of each line: the named groups of the filter, plus the
+// whole line as "line". Lines not matching the filter are skipped.
+func parseLineItems(output string, filter string) ([]map[string]string, error) {
+	regex, err := regexp.Compile(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []map[string]string{}
+	for _, line := range utils.SplitLines(output) {
+		match := regex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		fields := map[string]string{"line": line}
+		for groupIdx, group := range regex.SubexpNames() {
+			if group != "" {
+				fields[group] = match[groupIdx]
+			}
+		}
+		result = append(result, fields)
+	}
+
+	return result, nil
+}
+
+// Expects a JSON array of objects and returns the fields of each object
+func parseJsonItems(output string) ([]map[string]string, error) {
+	var objects []map[string]any
+	if err := json.Unmarshal([]byte(output), &objects); err != nil {
+		return nil, err
+	}
+
+	return lo.Map(objects, func(object map[string]any, _ int) map[string]string {
+		return lo.MapValues(object, func(value any, _ string) string {
+			if value == nil {
+				return ""
+			}
+			return fmt.Sprint(value)
+		})
+	}), nil
+}
+
+func executeItemTemplate(tmpl *template.Template, fields map[string]string) (string, error) {
+	buf := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(buf, fields); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -24,6 +24,15 @@ func NewGpgHelper(c *HelperCommon) *GpgHelper {
 // fix this bug, or just stop running subprocesses from within there, given that
 // we don't need to see a loading status if we're in a subprocess.
 func (self *GpgHelper) WithGpgHandling(cmdObj *oscommands.CmdObj, configKey git_commands.GpgConfigKey, waitingStatus string, onSuccess func() error, refreshScope []types.RefreshableView) error {
+	return self.WithGpgHandlingAndOnError(cmdObj, configKey, waitingStatus, onSuccess, nil, refreshScope)
+}
+
+// Like WithGpgHandling, but if the command fails, onError is called with the
+// error that contains the command's output. If it returns true, the error has
+// been dealt with; otherwise we show a generic error as usual. onError isn't
+// called if we run the command in a subprocess, because the user has seen the
+// output in the terminal already.
+func (self *GpgHelper) WithGpgHandlingAndOnError(cmdObj *oscommands.CmdObj, configKey git_commands.GpgConfigKey, waitingStatus string, onSuccess func() error, onError func(error) bool, refreshScope []types.RefreshableView) error {
 	useSubprocess := self.c.Git().Config.NeedsGpgSubprocess(configKey)
 	if useSubprocess {
 		success, err := self.c.RunSubprocess(cmdObj)
@@ -37,13 +46,16 @@ func (self *GpgHelper) WithGpgHandling(cmdObj *oscommands.CmdObj, configKey git_
 		return err
 	}
 
-	return self.runAndStream(cmdObj, waitingStatus, onSuccess, refreshScope)
+	return self.runAndStream(cmdObj, waitingStatus, onSuccess, onError, refreshScope)
 }
 
-func (self *GpgHelper) runAndStream(cmdObj *oscommands.CmdObj, waitingStatus string, onSuccess func() error, refreshScope []types.RefreshableView) error {
+func (self *GpgHelper) runAndStream(cmdObj *oscommands.CmdObj, waitingStatus string, onSuccess func() error, onError func(error) bool, refreshScope []types.RefreshableView) error {
 	return self.c.WithWaitingStatus(waitingStatus, func(gocui.Task) error {
 		if err := cmdObj.StreamOutput().Run(); err != nil {
 			self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: refreshScope})
+			if onError != nil && onError(err) {
+				return nil
+			}
 			return fmt.Errorf(
 				self.c.Tr.GitCommandFailed, self.c.UserConfig().Keybinding.Universal.ExtrasMenu,
 			)
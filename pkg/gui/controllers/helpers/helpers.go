@@ -28,6 +28,7 @@ type Helpers struct {
 	MergeConflicts *MergeConflictsHelper
 	CherryPick     *CherryPickHelper
 	Host           *HostHelper
+	PullRequest    *PullRequestHelper
 	PatchBuilding  *PatchBuildingHelper
 	Staging        *StagingHelper
 	GPG            *GpgHelper
@@ -53,6 +54,12 @@ type Helpers struct {
 	Search            *SearchHelper
 	Worktree          *WorktreeHelper
 	SubCommits        *SubCommitsHelper
+	CustomPanels      *CustomPanelsHelper
+	RepoDashboard     *RepoDashboardHelper
+	CommandHistory    *CommandHistoryHelper
+	UndoJournal       *UndoJournalHelper
+	ProtectedBranches *ProtectedBranchesHelper
+	Hooks             *HooksHelper
 }
 
 func NewStubHelpers() *Helpers {
@@ -67,6 +74,7 @@ func NewStubHelpers() *Helpers {
 		MergeConflicts:    &MergeConflictsHelper{},
 		CherryPick:        &CherryPickHelper{},
 		Host:              &HostHelper{},
+		PullRequest:       &PullRequestHelper{},
 		PatchBuilding:     &PatchBuildingHelper{},
 		Staging:           &StagingHelper{},
 		GPG:               &GpgHelper{},
@@ -90,5 +98,11 @@ func NewStubHelpers() *Helpers {
 		Search:            &SearchHelper{},
 		Worktree:          &WorktreeHelper{},
 		SubCommits:        &SubCommitsHelper{},
+		CustomPanels:      &CustomPanelsHelper{},
+		RepoDashboard:     &RepoDashboardHelper{},
+		CommandHistory:    &CommandHistoryHelper{},
+		UndoJournal:       &UndoJournalHelper{},
+		ProtectedBranches: &ProtectedBranchesHelper{},
+		Hooks:             &HooksHelper{},
 	}
 }
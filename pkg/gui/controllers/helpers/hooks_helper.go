@@ -0,0 +1,202 @@
+package helpers
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// Lists, toggles and runs the hooks of the repo, and shows the output of hooks
+// that made a command fail
+type HooksHelper struct {
+	c *HelperCommon
+}
+
+func NewHooksHelper(c *HelperCommon) *HooksHelper {
+	return &HooksHelper{
+		c: c,
+	}
+}
+
+func (self *HooksHelper) Open() error {
+	hooks, hooksPath, err := self.load()
+	if err != nil {
+		return err
+	}
+
+	if len(hooks) == 0 {
+		return errors.New(utils.ResolvePlaceholderString(self.c.Tr.NoHooksFound, map[string]string{
+			"hooksPath": hooksPath,
+		}))
+	}
+
+	self.c.Contexts().Hooks.SetHooks(hooks, hooksPath)
+	self.c.PostRefreshUpdate(self.c.Contexts().Hooks)
+	self.c.Context().Push(self.c.Contexts().Hooks, types.OnFocusOpts{})
+	return nil
+}
+
+// Reloads the hooks after one of them was changed
+func (self *HooksHelper) Reload() error {
+	hooks, hooksPath, err := self.load()
+	if err != nil {
+		return err
+	}
+
+	self.c.Contexts().Hooks.SetHooks(hooks, hooksPath)
+	self.c.PostRefreshUpdate(self.c.Contexts().Hooks)
+	return nil
+}
+
+func (self *HooksHelper) load() ([]*models.Hook, string, error) {
+	hooksPath, err := self.c.Git().Hook.HooksPath()
+	if err != nil {
+		return nil, "", err
+	}
+
+	hooks, err := self.c.Git().Hook.List()
+	return hooks, hooksPath, err
+}
+
+func (self *HooksHelper) ToggleEnabled(hook *models.Hook) error {
+	if hook.Enabled {
+		self.c.LogAction(self.c.Tr.Actions.DisableHook)
+	} else {
+		self.c.LogAction(self.c.Tr.Actions.EnableHook)
+	}
+
+	if err := self.c.Git().Hook.SetEnabled(hook, !hook.Enabled); err != nil {
+		return err
+	}
+
+	return self.Reload()
+}
+
+// Runs the hook with the arguments that git would pass to it in the current
+// state of the repo, and shows its output
+func (self *HooksHelper) Run(hook *models.Hook) error {
+	return self.c.WithWaitingStatus(self.c.Tr.RunningHook, func(gocui.Task) error {
+		self.c.LogAction(self.c.Tr.Actions.RunHook)
+		output, err := self.c.Git().Hook.RunCmdObj(hook, self.hookArgs(hook)).RunWithOutput()
+
+		title := self.c.Tr.HookSucceeded
+		if err != nil {
+			title = self.c.Tr.HookFailed
+			output = err.Error()
+		}
+		title = utils.ResolvePlaceholderString(title, map[string]string{"hook": hook.Name})
+		if strings.TrimSpace(output) == "" {
+			output = self.c.Tr.HookProducedNoOutput
+		}
+
+		self.c.OnUIThread(func() error {
+			self.ShowOutput(title, output, nil)
+			return nil
+		})
+
+		// hooks are free to change anything in the repo
+		self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return nil
+	})
+}
+
+// Shows the output of a hook in a scrollable popup. If onRetry is not nil, the
+// popup offers to call it. Must be called on the UI thread.
+func (self *HooksHelper) ShowOutput(title string, output string, onRetry func() error) {
+	self.c.Contexts().HookOutput.SetOutput(title, output, onRetry)
+	self.c.Context().Push(self.c.Contexts().HookOutput, types.OnFocusOpts{})
+}
+
+// To be called when a command failed, with the name of the hook that made it
+// fail according to HookCommands.TraceFailures. If there is one, we show the
+// error in the hook output popup, which offers to retry the command without
+// hooks, and return true. Can be called from any goroutine.
+func (self *HooksHelper) HandleCommandFailure(err error, failedHook string, retryWithoutHooks func() error) bool {
+	if failedHook == "" {
+		return false
+	}
+
+	// If we asked for credentials, the hook most likely failed because they
+	// were wrong, and retrying without hooks won't help with that
+	var credentialsErr *oscommands.CredentialsRequestedError
+	if errors.As(err, &credentialsErr) {
+		return false
+	}
+
+	output := err.Error()
+	var streamedCmdErr *oscommands.StreamedCmdError
+	if errors.As(err, &streamedCmdErr) {
+		output = streamedCmdErr.Output
+	}
+
+	title := utils.ResolvePlaceholderString(self.c.Tr.CommandFailedWithHooks, map[string]string{
+		"hook": failedHook,
+	})
+	self.c.OnUIThread(func() error {
+		self.ShowOutput(title, strings.TrimSpace(output), retryWithoutHooks)
+		return nil
+	})
+
+	return true
+}
+
+// The arguments that git passes to the hook. We only know them for the hooks
+// that are most likely to be run by hand; the others are run without arguments.
+func (self *HooksHelper) hookArgs(hook *models.Hook) []string {
+	switch hook.Name {
+	case "applypatch-msg", "prepare-commit-msg", "commit-msg":
+		return []string{filepath.Join(self.c.Git().RepoPaths.WorktreeGitDirPath(), "COMMIT_EDITMSG")}
+	case "pre-push":
+		remote := self.pushRemote()
+		if remote == nil {
+			return nil
+		}
+		url := ""
+		if len(remote.Urls) > 0 {
+			url = remote.Urls[0]
+		}
+		return []string{remote.Name, url}
+	case "post-checkout":
+		head := self.headHash()
+		return []string{head, head, "1"}
+	case "post-merge":
+		return []string{"0"}
+	}
+
+	return nil
+}
+
+// The upstream remote of the checked-out branch, or else the first remote
+func (self *HooksHelper) pushRemote() *models.Remote {
+	remotes := self.c.Model().Remotes
+	if len(remotes) == 0 {
+		return nil
+	}
+
+	if branch := self.checkedOutBranch(); branch != nil {
+		if remote, ok := lo.Find(remotes, func(remote *models.Remote) bool { return remote.Name == branch.UpstreamRemote }); ok {
+			return remote
+		}
+	}
+
+	return remotes[0]
+}
+
+func (self *HooksHelper) headHash() string {
+	if branch := self.checkedOutBranch(); branch != nil {
+		return branch.CommitHash
+	}
+	return ""
+}
+
+func (self *HooksHelper) checkedOutBranch() *models.Branch {
+	branch, _ := lo.Find(self.c.Model().Branches, func(branch *models.Branch) bool { return branch.Head })
+	return branch
+}
@@ -1,6 +1,8 @@
 package helpers
 
 import (
+	"os"
+
 	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
 )
 
@@ -34,6 +36,34 @@ func (self *HostHelper) GetCommitURL(commitHash string) (string, error) {
 	return mgr.GetCommitURL(commitHash)
 }
 
+// Returns the API token for the hosting service of the origin remote, or an
+// empty string if none is configured or the service doesn't support creating
+// pull requests through its API.
+func (self *HostHelper) GetApiToken() (string, error) {
+	mgr, err := self.getHostingServiceMgr()
+	if err != nil {
+		return "", err
+	}
+	envVar, err := mgr.GetDefaultTokenEnvVar()
+	if err != nil || envVar == "" {
+		return "", err
+	}
+
+	if configuredEnvVar := self.c.UserConfig().Git.PullRequests.TokenEnvVar; configuredEnvVar != "" {
+		envVar = configuredEnvVar
+	}
+
+	return os.Getenv(envVar), nil
+}
+
+func (self *HostHelper) CreatePullRequest(pr hosting_service.PullRequest, token string) (string, error) {
+	mgr, err := self.getHostingServiceMgr()
+	if err != nil {
+		return "", err
+	}
+	return mgr.CreatePullRequest(pr, token)
+}
+
 // getting this on every request rather than storing it in state in case our remoteURL changes
 // from one invocation to the next.
 func (self *HostHelper) getHostingServiceMgr() (*hosting_service.HostingServiceMgr, error) {
@@ -17,14 +17,17 @@ import (
 )
 
 type MergeAndRebaseHelper struct {
-	c *HelperCommon
+	c                       *HelperCommon
+	protectedBranchesHelper *ProtectedBranchesHelper
 }
 
 func NewMergeAndRebaseHelper(
 	c *HelperCommon,
+	protectedBranchesHelper *ProtectedBranchesHelper,
 ) *MergeAndRebaseHelper {
 	return &MergeAndRebaseHelper{
-		c: c,
+		c:                       c,
+		protectedBranchesHelper: protectedBranchesHelper,
 	}
 }
 
@@ -37,6 +40,11 @@ const (
 )
 
 func (self *MergeAndRebaseHelper) CreateRebaseOptionsMenu() error {
+	title := self.c.Git().Status.WorkingTreeState().OptionsMenuTitle(self.c.Tr)
+	return self.c.Menu(types.CreateMenuOptions{Title: title, Items: self.RebaseOptionsMenuItems()})
+}
+
+func (self *MergeAndRebaseHelper) RebaseOptionsMenuItems() []*types.MenuItem {
 	type optionAndKey struct {
 		option string
 		key    types.Key
@@ -53,7 +61,7 @@ func (self *MergeAndRebaseHelper) CreateRebaseOptionsMenu() error {
 		})
 	}
 
-	menuItems := lo.Map(options, func(row optionAndKey, _ int) *types.MenuItem {
+	return lo.Map(options, func(row optionAndKey, _ int) *types.MenuItem {
 		return &types.MenuItem{
 			Label: row.option,
 			OnPress: func() error {
@@ -62,9 +70,6 @@ func (self *MergeAndRebaseHelper) CreateRebaseOptionsMenu() error {
 			Key: row.key,
 		}
 	})
-
-	title := self.c.Git().Status.WorkingTreeState().OptionsMenuTitle(self.c.Tr)
-	return self.c.Menu(types.CreateMenuOptions{Title: title, Items: menuItems})
 }
 
 func (self *MergeAndRebaseHelper) ContinueRebase() error {
@@ -263,6 +268,12 @@ func (self *MergeAndRebaseHelper) PromptToContinueRebase() error {
 }
 
 func (self *MergeAndRebaseHelper) RebaseOntoRef(ref string) error {
+	return self.protectedBranchesHelper.WithCheckedOutBranchProtectionCheck(ProtectedBranchRebase, func() error {
+		return self.rebaseOntoRef(ref)
+	})
+}
+
+func (self *MergeAndRebaseHelper) rebaseOntoRef(ref string) error {
 	checkedOutBranch := self.c.Model().Branches[0]
 	checkedOutBranchName := checkedOutBranch.Name
 	var disabledReason, baseBranchDisabledReason *types.DisabledReason
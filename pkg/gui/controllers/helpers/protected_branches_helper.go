@@ -0,0 +1,147 @@
+package helpers
+
+import (
+	"errors"
+	"path"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+type ProtectedBranchAction int
+
+const (
+	ProtectedBranchForcePush ProtectedBranchAction = iota
+	ProtectedBranchDirectCommit
+	ProtectedBranchReset
+	ProtectedBranchRebase
+	ProtectedBranchDelete
+)
+
+// Enforces the rules of the git.protectedBranches config
+type ProtectedBranchesHelper struct {
+	c *HelperCommon
+}
+
+func NewProtectedBranchesHelper(c *HelperCommon) *ProtectedBranchesHelper {
+	return &ProtectedBranchesHelper{
+		c: c,
+	}
+}
+
+// Calls f unless a protected branch rule restricts the action for one of the
+// given branches. In that case we either return an error that explains why, or,
+// if the rule allows it, let the user override the restriction by typing the
+// branch name.
+func (self *ProtectedBranchesHelper) WithProtectionCheck(action ProtectedBranchAction, branchNames []string, f func() error) error {
+	branchName, rule, found := findProtectedBranchRule(self.c.UserConfig().Git.ProtectedBranches, action, branchNames)
+	if !found {
+		return f()
+	}
+
+	reason := utils.ResolvePlaceholderString(self.c.Tr.ProtectedBranchRestricted, map[string]string{
+		"action":  self.actionDescription(action),
+		"branch":  branchName,
+		"pattern": rule.Pattern,
+	})
+	if !rule.AllowOverride {
+		return errors.New(reason)
+	}
+
+	self.c.Confirm(types.ConfirmOpts{
+		Title:  self.c.Tr.ProtectedBranch,
+		Prompt: reason + "\n\n" + self.c.Tr.ProtectedBranchOverridePrompt,
+		HandleConfirm: func() error {
+			self.c.Prompt(types.PromptOpts{
+				Title: utils.ResolvePlaceholderString(self.c.Tr.TypeBranchNameToOverride, map[string]string{
+					"branch": branchName,
+				}),
+				HandleConfirm: func(response string) error {
+					if response != branchName {
+						return errors.New(self.c.Tr.ProtectedBranchNameMismatch)
+					}
+
+					return f()
+				},
+			})
+			return nil
+		},
+	})
+
+	return nil
+}
+
+// Like WithProtectionCheck, for actions that change the checked-out branch
+func (self *ProtectedBranchesHelper) WithCheckedOutBranchProtectionCheck(action ProtectedBranchAction, f func() error) error {
+	checkedOutBranch := self.c.Model().CheckedOutBranch
+	if checkedOutBranch == "" {
+		return f()
+	}
+
+	return self.WithProtectionCheck(action, []string{checkedOutBranch}, f)
+}
+
+func (self *ProtectedBranchesHelper) actionDescription(action ProtectedBranchAction) string {
+	switch action {
+	case ProtectedBranchForcePush:
+		return self.c.Tr.ProtectedBranchForcePush
+	case ProtectedBranchDirectCommit:
+		return self.c.Tr.ProtectedBranchDirectCommit
+	case ProtectedBranchReset:
+		return self.c.Tr.ProtectedBranchReset
+	case ProtectedBranchRebase:
+		return self.c.Tr.ProtectedBranchRebase
+	default:
+		return self.c.Tr.ProtectedBranchDelete
+	}
+}
+
+// Returns the first of the given branches that a rule restricts the action for,
+// together with that rule. If several rules restrict the action, we prefer one
+// that doesn't allow overriding it.
+func findProtectedBranchRule(rules []config.ProtectedBranchConfig, action ProtectedBranchAction, branchNames []string) (string, config.ProtectedBranchConfig, bool) {
+	var foundBranchName string
+	var foundRule config.ProtectedBranchConfig
+	found := false
+	for _, branchName := range branchNames {
+		// an empty name (e.g. of a missing upstream branch) would match "*"
+		if branchName == "" {
+			continue
+		}
+
+		for _, rule := range rules {
+			if !restricts(rule, action) {
+				continue
+			}
+			if matches, _ := path.Match(rule.Pattern, branchName); !matches {
+				continue
+			}
+
+			if !rule.AllowOverride {
+				return branchName, rule, true
+			}
+			if !found {
+				foundBranchName, foundRule, found = branchName, rule, true
+			}
+		}
+	}
+
+	return foundBranchName, foundRule, found
+}
+
+func restricts(rule config.ProtectedBranchConfig, action ProtectedBranchAction) bool {
+	switch action {
+	case ProtectedBranchForcePush:
+		return rule.NoForcePush
+	case ProtectedBranchDirectCommit:
+		return rule.NoDirectCommit
+	case ProtectedBranchReset:
+		return rule.NoReset
+	case ProtectedBranchRebase:
+		return rule.NoRebase
+	case ProtectedBranchDelete:
+		return rule.NoDelete
+	}
+	return false
+}
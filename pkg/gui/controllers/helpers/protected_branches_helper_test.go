@@ -0,0 +1,83 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindProtectedBranchRule(t *testing.T) {
+	rules := []config.ProtectedBranchConfig{
+		{Pattern: "main", NoForcePush: true, NoReset: true, AllowOverride: true},
+		{Pattern: "release/*", NoForcePush: true, NoDelete: true},
+		{Pattern: "*", NoReset: true},
+	}
+
+	scenarios := []struct {
+		name               string
+		action             ProtectedBranchAction
+		branchNames        []string
+		expectedFound      bool
+		expectedBranchName string
+		expectedPattern    string
+	}{
+		{
+			name:          "no matching rule",
+			action:        ProtectedBranchForcePush,
+			branchNames:   []string{"feature"},
+			expectedFound: false,
+		},
+		{
+			name:          "matching rule doesn't restrict the action",
+			action:        ProtectedBranchDelete,
+			branchNames:   []string{"main"},
+			expectedFound: false,
+		},
+		{
+			name:               "glob pattern",
+			action:             ProtectedBranchDelete,
+			branchNames:        []string{"feature", "release/1.0"},
+			expectedFound:      true,
+			expectedBranchName: "release/1.0",
+			expectedPattern:    "release/*",
+		},
+		{
+			name:          "empty branch name doesn't match *",
+			action:        ProtectedBranchReset,
+			branchNames:   []string{""},
+			expectedFound: false,
+		},
+		{
+			name:          "glob pattern doesn't match nested branches",
+			action:        ProtectedBranchDelete,
+			branchNames:   []string{"release/1.0/hotfix"},
+			expectedFound: false,
+		},
+		{
+			name:               "first rule that allows overriding",
+			action:             ProtectedBranchForcePush,
+			branchNames:        []string{"main", "feature"},
+			expectedFound:      true,
+			expectedBranchName: "main",
+			expectedPattern:    "main",
+		},
+		{
+			name:               "rule that doesn't allow overriding wins",
+			action:             ProtectedBranchReset,
+			branchNames:        []string{"main"},
+			expectedFound:      true,
+			expectedBranchName: "main",
+			expectedPattern:    "*",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			branchName, rule, found := findProtectedBranchRule(rules, s.action, s.branchNames)
+			assert.Equal(t, s.expectedFound, found)
+			assert.Equal(t, s.expectedBranchName, branchName)
+			assert.Equal(t, s.expectedPattern, rule.Pattern)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting_service"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Creates pull requests, either through the hosting service's API if we have
+// a token for it, or by opening the service's 'compare' page in the browser.
+
+type PullRequestHelper struct {
+	c             *HelperCommon
+	host          *HostHelper
+	refsHelper    *RefsHelper
+	commitsHelper *CommitsHelper
+	suggestions   *SuggestionsHelper
+}
+
+func NewPullRequestHelper(
+	c *HelperCommon,
+	host *HostHelper,
+	refsHelper *RefsHelper,
+	commitsHelper *CommitsHelper,
+	suggestions *SuggestionsHelper,
+) *PullRequestHelper {
+	return &PullRequestHelper{
+		c:             c,
+		host:          host,
+		refsHelper:    refsHelper,
+		commitsHelper: commitsHelper,
+		suggestions:   suggestions,
+	}
+}
+
+// the locations where hosting services look for pull request templates, in
+// the order in which we try them
+var pullRequestTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+	".gitlab/merge_request_templates/Default.md",
+	".gitea/pull_request_template.md",
+}
+
+// Creates a pull request for the upstream of the given branch. If `to` is
+// empty, the pull request targets the branch's base branch (when using the
+// API) or the repo's default branch (when using the browser).
+func (self *PullRequestHelper) CreatePullRequest(branch *models.Branch, to string) error {
+	token, err := self.host.GetApiToken()
+	if err != nil {
+		return err
+	}
+
+	if token == "" {
+		return self.openInBrowser(branch.UpstreamBranch, to)
+	}
+
+	config := self.c.UserConfig().Git.PullRequests
+	pr := &hosting_service.PullRequest{
+		From:      branch.UpstreamBranch,
+		To:        to,
+		Draft:     config.Draft,
+		Reviewers: append([]string{}, config.Reviewers...),
+	}
+	if pr.To == "" {
+		pr.To = self.getDefaultTargetBranch(branch)
+	}
+	pr.Title, pr.Body = self.getInitialTitleAndBody(branch, pr.To)
+
+	self.openTitleAndBodyPanel(branch, pr, token)
+	return nil
+}
+
+func (self *PullRequestHelper) openInBrowser(from string, to string) error {
+	url, err := self.host.GetPullRequestURL(from, to)
+	if err != nil {
+		return err
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.OpenPullRequest)
+
+	return self.c.OS().OpenLink(url)
+}
+
+func (self *PullRequestHelper) getDefaultTargetBranch(branch *models.Branch) string {
+	baseBranch, err := self.c.Git().Loaders.BranchLoader.GetBaseBranch(branch, self.c.Model().MainBranches)
+	if err != nil || baseBranch == "" {
+		return ""
+	}
+
+	baseBranch = ShortBranchName(baseBranch)
+	if _, branchName, found := self.refsHelper.ParseRemoteBranchName(baseBranch); found {
+		return branchName
+	}
+	return baseBranch
+}
+
+// The title is the subject of the commit if there's only one, or the branch
+// name otherwise. The body is the repo's pull request template followed by the
+// list of commits.
+func (self *PullRequestHelper) getInitialTitleAndBody(branch *models.Branch, to string) (string, string) {
+	var subjects []string
+	if to != "" {
+		var err error
+		subjects, err = self.c.Git().Commit.GetCommitSubjectsInRange(branch.UpstreamRemote+"/"+to, branch.FullRefName())
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+	}
+
+	title := branch.Name
+	if len(subjects) == 1 {
+		title = subjects[0]
+	}
+
+	sections := []string{}
+	if template := self.readTemplate(); template != "" {
+		sections = append(sections, template)
+	}
+	if len(subjects) > 0 {
+		sections = append(sections, strings.Join(lo.Map(subjects, func(subject string, _ int) string {
+			return "- " + subject
+		}), "\n"))
+	}
+
+	return title, strings.Join(sections, "\n\n")
+}
+
+func (self *PullRequestHelper) readTemplate() string {
+	paths := pullRequestTemplatePaths
+	if configuredPath := self.c.UserConfig().Git.PullRequests.TemplatePath; configuredPath != "" {
+		paths = []string{configuredPath}
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(filepath.Join(self.c.Git().RepoPaths.WorktreePath(), path))
+		if err == nil {
+			return strings.TrimSpace(string(content))
+		}
+	}
+
+	return ""
+}
+
+func (self *PullRequestHelper) openTitleAndBodyPanel(branch *models.Branch, pr *hosting_service.PullRequest, token string) {
+	self.commitsHelper.OpenCommitMessagePanel(
+		&OpenCommitMessagePanelOpts{
+			CommitIndex:      context.NoCommitIndex,
+			InitialMessage:   pr.Title + "\n" + pr.Body,
+			SummaryTitle:     self.c.Tr.PullRequestTitle,
+			DescriptionTitle: self.c.Tr.PullRequestDescription,
+			PreserveMessage:  false,
+			OnConfirm: func(title string, body string) error {
+				pr.Title = title
+				pr.Body = body
+				return self.showOptionsMenu(branch, pr, token)
+			},
+		},
+	)
+}
+
+func (self *PullRequestHelper) showOptionsMenu(branch *models.Branch, pr *hosting_service.PullRequest, token string) error {
+	valueOrNone := func(value string) string {
+		if value == "" {
+			return self.c.Tr.NoneValue
+		}
+		return value
+	}
+
+	var submitDisabledReason *types.DisabledReason
+	if pr.To == "" {
+		submitDisabledReason = &types.DisabledReason{Text: self.c.Tr.PullRequestNoBaseBranch}
+	}
+
+	menuItems := []*types.MenuItem{
+		{
+			LabelColumns: []string{self.c.Tr.SubmitPullRequest, fmt.Sprintf("%s → %s", pr.From, valueOrNone(pr.To))},
+			Key:          's',
+			OnPress: func() error {
+				return self.submit(pr, token)
+			},
+			DisabledReason: submitDisabledReason,
+		},
+		{
+			LabelColumns: []string{self.c.Tr.EditPullRequestTitleAndDescription, pr.Title},
+			Key:          'e',
+			OnPress: func() error {
+				self.openTitleAndBodyPanel(branch, pr, token)
+				return nil
+			},
+		},
+		{
+			LabelColumns: []string{self.c.Tr.PullRequestBaseBranch, valueOrNone(pr.To)},
+			Key:          'b',
+			OnPress: func() error {
+				self.c.Prompt(types.PromptOpts{
+					Title:               self.c.Tr.PullRequestBaseBranch,
+					InitialContent:      pr.To,
+					FindSuggestionsFunc: self.suggestions.GetRemoteBranchesForRemoteSuggestionsFunc(branch.UpstreamRemote),
+					HandleConfirm: func(to string) error {
+						pr.To = to
+						return self.showOptionsMenu(branch, pr, token)
+					},
+				})
+				return nil
+			},
+		},
+		{
+			LabelColumns: []string{self.c.Tr.PullRequestDraft},
+			Widget:       types.MakeMenuCheckBox(pr.Draft),
+			Key:          'd',
+			OnPress: func() error {
+				pr.Draft = !pr.Draft
+				return self.showOptionsMenu(branch, pr, token)
+			},
+		},
+		{
+			LabelColumns: []string{self.c.Tr.PullRequestReviewers, valueOrNone(strings.Join(pr.Reviewers, ", "))},
+			Key:          'r',
+			OnPress: func() error {
+				self.c.Prompt(types.PromptOpts{
+					Title:           self.c.Tr.PullRequestReviewersPrompt,
+					InitialContent:  strings.Join(pr.Reviewers, ", "),
+					AllowEmptyInput: true,
+					HandleConfirm: func(reviewers string) error {
+						pr.Reviewers = lo.Compact(lo.Map(strings.Split(reviewers, ","), func(reviewer string, _ int) string {
+							return strings.TrimSpace(reviewer)
+						}))
+						return self.showOptionsMenu(branch, pr, token)
+					},
+				})
+				return nil
+			},
+		},
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{Title: self.c.Tr.CreatePullRequest, Items: menuItems})
+}
+
+func (self *PullRequestHelper) submit(pr *hosting_service.PullRequest, token string) error {
+	return self.c.WithWaitingStatus(self.c.Tr.CreatingPullRequestStatus, func(gocui.Task) error {
+		self.c.LogAction(self.c.Tr.Actions.CreatePullRequest)
+		url, err := self.host.CreatePullRequest(*pr, token)
+		if err != nil {
+			return err
+		}
+
+		self.c.Toast(fmt.Sprintf(self.c.Tr.PullRequestCreated, url))
+		return nil
+	})
+}
@@ -27,6 +27,7 @@ type RefreshHelper struct {
 	mergeConflictsHelper *MergeConflictsHelper
 	worktreeHelper       *WorktreeHelper
 	searchHelper         *SearchHelper
+	customPanelsHelper   *CustomPanelsHelper
 }
 
 func NewRefreshHelper(
@@ -38,6 +39,7 @@ func NewRefreshHelper(
 	mergeConflictsHelper *MergeConflictsHelper,
 	worktreeHelper *WorktreeHelper,
 	searchHelper *SearchHelper,
+	customPanelsHelper *CustomPanelsHelper,
 ) *RefreshHelper {
 	return &RefreshHelper{
 		c:                    c,
@@ -48,6 +50,7 @@ func NewRefreshHelper(
 		mergeConflictsHelper: mergeConflictsHelper,
 		worktreeHelper:       worktreeHelper,
 		searchHelper:         searchHelper,
+		customPanelsHelper:   customPanelsHelper,
 	}
 }
 
@@ -91,6 +94,7 @@ func (self *RefreshHelper) Refresh(options types.RefreshOptions) {
 				types.STATUS,
 				types.BISECT_INFO,
 				types.STAGING,
+				types.CUSTOM_PANELS,
 			})
 		} else {
 			scopeSet = set.NewFromSlice(options.Scope)
@@ -171,6 +175,10 @@ func (self *RefreshHelper) Refresh(options types.RefreshOptions) {
 			refresh("worktrees", func() { self.refreshWorktrees() })
 		}
 
+		if scopeSet.Includes(types.CUSTOM_PANELS) && len(self.c.Contexts().CustomPanels) > 0 {
+			refresh("custom panels", func() { self.customPanelsHelper.Refresh() })
+		}
+
 		if scopeSet.Includes(types.STAGING) {
 			refresh("staging", func() {
 				fileWg.Wait()
@@ -206,29 +214,36 @@ func (self *RefreshHelper) Refresh(options types.RefreshOptions) {
 	f()
 }
 
-func getScopeNames(scopes []types.RefreshableView) []string {
-	scopeNameMap := map[types.RefreshableView]string{
-		types.COMMITS:         "commits",
-		types.BRANCHES:        "branches",
-		types.FILES:           "files",
-		types.SUBMODULES:      "submodules",
-		types.SUB_COMMITS:     "subCommits",
-		types.STASH:           "stash",
-		types.REFLOG:          "reflog",
-		types.TAGS:            "tags",
-		types.REMOTES:         "remotes",
-		types.WORKTREES:       "worktrees",
-		types.STATUS:          "status",
-		types.BISECT_INFO:     "bisect",
-		types.STAGING:         "staging",
-		types.MERGE_CONFLICTS: "mergeConflicts",
-	}
+var scopeNameMap = map[types.RefreshableView]string{
+	types.COMMITS:         "commits",
+	types.BRANCHES:        "branches",
+	types.FILES:           "files",
+	types.SUBMODULES:      "submodules",
+	types.SUB_COMMITS:     "subCommits",
+	types.STASH:           "stash",
+	types.REFLOG:          "reflog",
+	types.TAGS:            "tags",
+	types.REMOTES:         "remotes",
+	types.WORKTREES:       "worktrees",
+	types.STATUS:          "status",
+	types.BISECT_INFO:     "bisect",
+	types.STAGING:         "staging",
+	types.MERGE_CONFLICTS: "mergeConflicts",
+	types.CUSTOM_PANELS:   "customPanels",
+}
 
+func getScopeNames(scopes []types.RefreshableView) []string {
 	return lo.Map(scopes, func(scope types.RefreshableView, _ int) string {
 		return scopeNameMap[scope]
 	})
 }
 
+// Returns the scope with the given name, e.g. "files". Used by plugins to
+// request a refresh.
+func ScopeFromName(name string) (types.RefreshableView, bool) {
+	return lo.FindKey(scopeNameMap, name)
+}
+
 func getModeName(mode types.RefreshMode) string {
 	switch mode {
 	case types.SYNC:
@@ -330,6 +345,7 @@ func (self *RefreshHelper) refreshCommitsWithLimit() error {
 			All:                  self.c.Contexts().LocalCommits.GetShowWholeGitGraph(),
 			MainBranches:         self.c.Model().MainBranches,
 			HashPool:             self.c.Model().HashPool,
+			UseCache:             true,
 		},
 	)
 	if err != nil {
@@ -337,6 +353,11 @@ func (self *RefreshHelper) refreshCommitsWithLimit() error {
 	}
 	self.c.Model().Commits = commits
 	self.RefreshAuthors(commits)
+	if hash := self.c.State().GetRepoState().TakeCommitHashToSelect(); hash != "" {
+		if _, idx, found := lo.FindIndexOf(commits, func(c *models.Commit) bool { return c.Hash() == hash }); found {
+			self.c.Contexts().LocalCommits.SetSelectedLineIdx(idx)
+		}
+	}
 	self.c.Model().WorkingTreeStateAtLastCommitRefresh = self.c.Git().Status.WorkingTreeState()
 	if checkedOutRef != nil {
 		self.c.Model().CheckedOutBranch = checkedOutRef.RefName()
@@ -483,7 +504,11 @@ func (self *RefreshHelper) refreshBranches(refreshWorktrees bool, keepBranchSele
 		self.refreshView(self.c.Contexts().Worktrees)
 	}
 
-	if !keepBranchSelectionIndex && prevSelectedBranch != nil {
+	if name := self.c.State().GetRepoState().TakeBranchToSelect(); name != "" {
+		if _, idx, found := lo.FindIndexOf(branches, func(b *models.Branch) bool { return b.Name == name }); found {
+			self.c.Contexts().Branches.SetSelectedLineIdx(idx)
+		}
+	} else if !keepBranchSelectionIndex && prevSelectedBranch != nil {
 		self.searchHelper.ReApplyFilter(self.c.Contexts().Branches)
 
 		_, idx, found := lo.FindIndexOf(self.c.Contexts().Branches.GetItems(),
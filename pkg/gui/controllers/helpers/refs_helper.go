@@ -18,16 +18,19 @@ import (
 type RefsHelper struct {
 	c *HelperCommon
 
-	rebaseHelper *MergeAndRebaseHelper
+	rebaseHelper            *MergeAndRebaseHelper
+	protectedBranchesHelper *ProtectedBranchesHelper
 }
 
 func NewRefsHelper(
 	c *HelperCommon,
 	rebaseHelper *MergeAndRebaseHelper,
+	protectedBranchesHelper *ProtectedBranchesHelper,
 ) *RefsHelper {
 	return &RefsHelper{
-		c:            c,
-		rebaseHelper: rebaseHelper,
+		c:                       c,
+		rebaseHelper:            rebaseHelper,
+		protectedBranchesHelper: protectedBranchesHelper,
 	}
 }
 
@@ -188,6 +191,10 @@ func (self *RefsHelper) GetCheckedOutRef() *models.Branch {
 }
 
 func (self *RefsHelper) ResetToRef(ref string, strength string, envVars []string) error {
+	if strength == "hard" {
+		self.c.Git().Snapshot.TakeBeforeDiscarding("hard reset to " + ref)
+	}
+
 	if err := self.c.Git().Commit.ResetToCommit(ref, strength, envVars); err != nil {
 		return err
 	}
@@ -245,6 +252,12 @@ func (self *RefsHelper) CreateSortOrderMenu(sortOptionsOrder []string, menuPromp
 }
 
 func (self *RefsHelper) CreateGitResetMenu(name string, ref string) error {
+	return self.protectedBranchesHelper.WithCheckedOutBranchProtectionCheck(ProtectedBranchReset, func() error {
+		return self.createGitResetMenu(name, ref)
+	})
+}
+
+func (self *RefsHelper) createGitResetMenu(name string, ref string) error {
 	type strengthWithKey struct {
 		strength string
 		label    string
@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Opens the repo dashboard and loads the summaries of its repos in the
+// background
+type RepoDashboardHelper struct {
+	c            *HelperCommon
+	searchHelper *SearchHelper
+}
+
+func NewRepoDashboardHelper(c *HelperCommon, searchHelper *SearchHelper) *RepoDashboardHelper {
+	return &RepoDashboardHelper{
+		c:            c,
+		searchHelper: searchHelper,
+	}
+}
+
+func (self *RepoDashboardHelper) Open() error {
+	paths := self.repoPaths()
+	self.c.Contexts().RepoDashboard.ReInit(paths, self.c.Git().RepoPaths.WorktreePath())
+	self.c.Context().Push(self.c.Contexts().RepoDashboard, types.OnFocusOpts{})
+	self.LoadSummaries(paths)
+	return nil
+}
+
+// Loads the summaries of the given repos concurrently, updating the dashboard
+// whenever one of them is done
+func (self *RepoDashboardHelper) LoadSummaries(paths []string) {
+	for _, path := range paths {
+		self.c.OnWorker(func(gocui.Task) error {
+			self.UpdateSummary(self.c.Git().Loaders.RepoSummaryLoader.Load(path))
+			return nil
+		})
+	}
+}
+
+// Shows the given summary in place of the old one of the same repo. Can be
+// called from any goroutine.
+func (self *RepoDashboardHelper) UpdateSummary(summary *models.RepoSummary) {
+	self.c.OnUIThread(func() error {
+		self.c.Contexts().RepoDashboard.SetSummary(summary)
+		self.searchHelper.ReApplyFilter(self.c.Contexts().RepoDashboard)
+		self.c.PostRefreshUpdate(self.c.Contexts().RepoDashboard)
+		return nil
+	})
+}
+
+// The recent repos, followed by the repos in the configured root directories
+func (self *RepoDashboardHelper) repoPaths() []string {
+	paths := append([]string{}, self.c.GetAppState().RecentRepos...)
+
+	for _, rootDir := range self.c.UserConfig().RepoDashboard.RootDirectories {
+		rootDir = expandHomeDir(rootDir)
+		entries, err := os.ReadDir(rootDir)
+		if err != nil {
+			self.c.Log.Error(err)
+			continue
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(rootDir, entry.Name())
+			if !entry.IsDir() {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	return lo.Uniq(paths)
+}
+
+func expandHomeDir(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, path[1:])
+}
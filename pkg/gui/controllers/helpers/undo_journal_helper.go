@@ -0,0 +1,214 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/samber/lo"
+)
+
+// When the journal gets longer than this, the oldest entries are dropped
+const maxUndoJournalEntries = 100
+
+// Keeps a journal of the actions that can be undone but aren't recorded in the
+// reflog: deleting branches and tags, and dropping stash entries. The undo
+// controller merges the journal with the reflog, so each entry records how long
+// the reflog was at the time.
+type UndoJournalHelper struct {
+	c *HelperCommon
+}
+
+func NewUndoJournalHelper(c *HelperCommon) *UndoJournalHelper {
+	return &UndoJournalHelper{
+		c: c,
+	}
+}
+
+func (self *UndoJournalHelper) RecordBranchDeletion(branches []*models.Branch) {
+	self.record(models.UndoJournalDeleteBranches, lo.Map(branches, func(branch *models.Branch, _ int) *models.UndoJournalRef {
+		return &models.UndoJournalRef{
+			Name:           branch.Name,
+			Hash:           branch.CommitHash,
+			UpstreamRemote: branch.UpstreamRemote,
+			UpstreamBranch: branch.UpstreamBranch,
+		}
+	}))
+}
+
+func (self *UndoJournalHelper) RecordTagDeletion(tagName string, hash string) {
+	self.record(models.UndoJournalDeleteTag, []*models.UndoJournalRef{{Name: tagName, Hash: hash}})
+}
+
+func (self *UndoJournalHelper) RecordStashDrop(stashEntries []*models.StashEntry) {
+	self.record(models.UndoJournalDropStashes, lo.Map(stashEntries, func(stashEntry *models.StashEntry, _ int) *models.UndoJournalRef {
+		return &models.UndoJournalRef{
+			Name:    stashEntry.RefName(),
+			Hash:    stashEntry.Hash,
+			Message: stashEntry.Name,
+		}
+	}))
+}
+
+func (self *UndoJournalHelper) RecordUndo() {
+	self.record(models.UndoJournalUndo, nil)
+}
+
+func (self *UndoJournalHelper) RecordRedo() {
+	self.record(models.UndoJournalRedo, nil)
+}
+
+// Errors are only logged, because the action itself has already succeeded at
+// this point
+func (self *UndoJournalHelper) record(kind models.UndoJournalEntryKind, refs []*models.UndoJournalRef) {
+	// we ask git rather than the model, because the model is only updated by
+	// an async refresh, so it may not contain the latest reflog entries yet
+	reflogCount, err := self.c.Git().Loaders.ReflogCommitLoader.GetReflogCount()
+	if err != nil {
+		self.c.Log.Error(err)
+		reflogCount = len(self.c.Model().ReflogCommits)
+	}
+
+	entry := &models.UndoJournalEntry{
+		Kind:        kind,
+		ReflogCount: reflogCount,
+		Refs:        refs,
+	}
+	if err := appendUndoJournalEntry(self.path(), entry); err != nil {
+		self.c.Log.Error(err)
+	}
+}
+
+// Returns the entries of the journal, most recent first
+func (self *UndoJournalHelper) Load() ([]*models.UndoJournalEntry, error) {
+	entries, err := loadUndoJournal(self.path())
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+// Recreates the refs that were deleted by the given action
+func (self *UndoJournalHelper) Restore(entry *models.UndoJournalEntry) error {
+	switch entry.Kind {
+	case models.UndoJournalDeleteBranches:
+		for _, ref := range entry.Refs {
+			if err := self.c.Git().Branch.NewWithoutCheckout(ref.Name, ref.Hash); err != nil {
+				return err
+			}
+			if ref.UpstreamRemote != "" && ref.UpstreamBranch != "" {
+				// the upstream may have been deleted in the meantime, but that
+				// shouldn't stop us from restoring the other branches
+				if err := self.c.Git().Branch.SetUpstream(ref.UpstreamRemote, ref.UpstreamBranch, ref.Name); err != nil {
+					self.c.Log.Error(err)
+				}
+			}
+		}
+	case models.UndoJournalDeleteTag:
+		for _, ref := range entry.Refs {
+			// a lightweight tag pointing at the tag object of an annotated tag
+			// is the same as the annotated tag itself
+			if err := self.c.Git().Tag.CreateLightweightObj(ref.Name, ref.Hash, false).Run(); err != nil {
+				return err
+			}
+		}
+	case models.UndoJournalDropStashes:
+		// the refs are ordered newest first, so we store them in reverse order
+		// to get the newest one back on top
+		for _, ref := range lo.Reverse(slices.Clone(entry.Refs)) {
+			if err := self.c.Git().Stash.Store(ref.Hash, ref.Message); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Deletes the refs again that were deleted by the given action and then
+// restored by undoing it
+func (self *UndoJournalHelper) DeleteAgain(entry *models.UndoJournalEntry) error {
+	switch entry.Kind {
+	case models.UndoJournalDeleteBranches:
+		return self.c.Git().Branch.LocalDelete(entry.RefNames(), true)
+	case models.UndoJournalDeleteTag:
+		for _, ref := range entry.Refs {
+			if err := self.c.Git().Tag.LocalDelete(ref.Name); err != nil {
+				return err
+			}
+		}
+	case models.UndoJournalDropStashes:
+		for _, ref := range entry.Refs {
+			// the stash entries may have moved since they were restored
+			stashEntry, ok := lo.Find(self.c.Git().Loaders.StashLoader.GetStashEntries(""), func(stashEntry *models.StashEntry) bool {
+				return stashEntry.Hash == ref.Hash
+			})
+			if !ok {
+				return errors.New(self.c.Tr.StashEntryForRedoNotFound)
+			}
+			if err := self.c.Git().Stash.Drop(stashEntry.Index); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *UndoJournalHelper) path() string {
+	return filepath.Join(self.c.Git().RepoPaths.WorktreeGitDirPath(), "lazygit-undo-journal.jsonl")
+}
+
+// Appends the entry to the journal at the given path, dropping the oldest
+// entries if the journal has become too long
+func appendUndoJournalEntry(path string, entry *models.UndoJournalEntry) error {
+	entries, err := loadUndoJournal(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > maxUndoJournalEntries {
+		entries = entries[len(entries)-maxUndoJournalEntries:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// Returns the entries of the journal at the given path in the order they were
+// added. A missing journal counts as empty.
+func loadUndoJournal(path string) ([]*models.UndoJournalEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entries := []*models.UndoJournalEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		var entry models.UndoJournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
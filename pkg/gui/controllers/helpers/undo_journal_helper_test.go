@@ -0,0 +1,54 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendUndoJournalEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lazygit-undo-journal.jsonl")
+
+	for i := range maxUndoJournalEntries + 2 {
+		err := appendUndoJournalEntry(path, &models.UndoJournalEntry{
+			Kind:        models.UndoJournalDeleteBranches,
+			ReflogCount: i,
+			Refs:        []*models.UndoJournalRef{{Name: "branch", Hash: "abc"}},
+		})
+		assert.NoError(t, err)
+	}
+
+	entries, err := loadUndoJournal(path)
+	assert.NoError(t, err)
+	// the oldest entries were dropped
+	assert.Len(t, entries, maxUndoJournalEntries)
+	assert.Equal(t, 2, entries[0].ReflogCount)
+	assert.Equal(t, maxUndoJournalEntries+1, entries[len(entries)-1].ReflogCount)
+	assert.Equal(t, []string{"branch"}, entries[0].RefNames())
+}
+
+func TestLoadUndoJournal(t *testing.T) {
+	dir := t.TempDir()
+
+	entries, err := loadUndoJournal(filepath.Join(dir, "missing.jsonl"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	path := filepath.Join(dir, "journal.jsonl")
+	content := `{"kind":"dropStashes","reflogCount":3,"refs":[{"name":"stash@{0}","hash":"abc","message":"On master: wip"}]}
+{"kind":"und
+{"kind":"undo","reflogCount":4}
+`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	entries, err = loadUndoJournal(path)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, models.UndoJournalDropStashes, entries[0].Kind)
+	assert.Equal(t, "On master: wip", entries[0].Refs[0].Message)
+	assert.Equal(t, models.UndoJournalUndo, entries[1].Kind)
+	assert.Empty(t, entries[1].Refs)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 	"golang.org/x/exp/slices"
 )
 
@@ -110,6 +111,10 @@ func shouldUsePortraitMode(args WindowArrangementArgs) bool {
 		return args.UserConfig.Gui.EnlargedSideViewLocation == "top"
 	}
 
+	if args.UserConfig.Gui.SideWindowLayout.Position == "top" {
+		return true
+	}
+
 	switch args.UserConfig.Gui.PortraitMode {
 	case "never":
 		return false
@@ -124,7 +129,8 @@ func GetWindowDimensions(args WindowArrangementArgs) map[string]boxlayout.Dimens
 	sideSectionWeight, mainSectionWeight := getMidSectionWeights(args)
 
 	sidePanelsDirection := boxlayout.COLUMN
-	if shouldUsePortraitMode(args) {
+	portraitMode := shouldUsePortraitMode(args)
+	if portraitMode {
 		sidePanelsDirection = boxlayout.ROW
 	}
 
@@ -143,18 +149,7 @@ func GetWindowDimensions(args WindowArrangementArgs) map[string]boxlayout.Dimens
 			{
 				Direction: sidePanelsDirection,
 				Weight:    1,
-				Children: []*boxlayout.Box{
-					{
-						Direction:           boxlayout.ROW,
-						Weight:              sideSectionWeight,
-						ConditionalChildren: sidePanelChildren(args),
-					},
-					{
-						Direction: boxlayout.ROW,
-						Weight:    mainSectionWeight,
-						Children:  mainPanelChildren(args),
-					},
-				},
+				Children:  midSectionChildren(args, portraitMode, sideSectionWeight, mainSectionWeight),
 			},
 			{
 				Direction: boxlayout.COLUMN,
@@ -170,6 +165,26 @@ func GetWindowDimensions(args WindowArrangementArgs) map[string]boxlayout.Dimens
 	return MergeMaps(layerOneWindows, limitWindows)
 }
 
+func midSectionChildren(args WindowArrangementArgs, portraitMode bool, sideSectionWeight int, mainSectionWeight int) []*boxlayout.Box {
+	sideSection := &boxlayout.Box{
+		Direction:           boxlayout.ROW,
+		Weight:              sideSectionWeight,
+		ConditionalChildren: sidePanelChildren(args),
+	}
+	mainSection := &boxlayout.Box{
+		Direction: boxlayout.ROW,
+		Weight:    mainSectionWeight,
+		Children:  mainPanelChildren(args),
+	}
+
+	// in portrait mode the side section is always on top
+	if !portraitMode && args.UserConfig.Gui.SideWindowLayout.Position == "right" {
+		return []*boxlayout.Box{mainSection, sideSection}
+	}
+
+	return []*boxlayout.Box{sideSection, mainSection}
+}
+
 func mainPanelChildren(args WindowArrangementArgs) []*boxlayout.Box {
 	mainPanelsDirection := boxlayout.ROW
 	if splitMainPanelSideBySide(args) {
@@ -402,17 +417,24 @@ func getExtrasWindowSize(args WindowArrangementArgs) int {
 	return baseSize + frameSize
 }
 
-// The stash window by default only contains one line so that it's not hogging
-// too much space, but if you access it it should take up some space. This is
-// the default behaviour when accordion mode is NOT in effect. If it is in effect
-// then when it's accessed it will have weight 2, not 1.
-func getDefaultStashWindowBox(args WindowArrangementArgs) *boxlayout.Box {
-	box := &boxlayout.Box{Window: "stash"}
-	// if the stash window is anywhere in our stack we should enlargen it
-	if args.CurrentSideWindow == "stash" {
-		box.Weight = 1
-	} else {
+// Returns the box of a side window when there is enough room for all windows
+// and no window is enlarged. Unless the user configured a size or weight, the
+// status window only takes up the one line it needs, and the stash window
+// likewise only contains one line so that it's not hogging too much space, but
+// if you access it it should take up some space.
+func getDefaultSideWindowBox(args WindowArrangementArgs, sideWindow config.SideWindowConfig) *boxlayout.Box {
+	box := &boxlayout.Box{Window: sideWindow.Window}
+	switch {
+	case sideWindow.Size > 0:
+		box.Size = sideWindow.Size
+	case sideWindow.Weight > 0:
+		box.Weight = sideWindow.Weight
+	case sideWindow.Window == "status":
+		box.Size = 3
+	case sideWindow.Window == "stash" && args.CurrentSideWindow != "stash":
 		box.Size = 3
+	default:
+		box.Weight = 1
 	}
 
 	return box
@@ -420,51 +442,40 @@ func getDefaultStashWindowBox(args WindowArrangementArgs) *boxlayout.Box {
 
 func sidePanelChildren(args WindowArrangementArgs) func(width int, height int) []*boxlayout.Box {
 	return func(width int, height int) []*boxlayout.Box {
+		sideWindows := args.UserConfig.Gui.SideWindowLayout.Windows
+
 		if args.ScreenMode == types.SCREEN_FULL || args.ScreenMode == types.SCREEN_HALF {
-			fullHeightBox := func(window string) *boxlayout.Box {
-				if window == args.CurrentSideWindow {
+			fullHeightBox := func(sideWindow config.SideWindowConfig, _ int) *boxlayout.Box {
+				if sideWindow.Window == args.CurrentSideWindow {
 					return &boxlayout.Box{
-						Window: window,
+						Window: sideWindow.Window,
 						Weight: 1,
 					}
 				}
 
 				return &boxlayout.Box{
-					Window: window,
+					Window: sideWindow.Window,
 					Size:   0,
 				}
 			}
 
-			return []*boxlayout.Box{
-				fullHeightBox("status"),
-				fullHeightBox("files"),
-				fullHeightBox("branches"),
-				fullHeightBox("commits"),
-				fullHeightBox("stash"),
-			}
+			return lo.Map(sideWindows, fullHeightBox)
 		} else if height >= 28 {
 			accordionMode := args.UserConfig.Gui.ExpandFocusedSidePanel
-			accordionBox := func(defaultBox *boxlayout.Box) *boxlayout.Box {
-				if accordionMode && defaultBox.Window == args.CurrentSideWindow {
+			accordionBox := func(sideWindow config.SideWindowConfig, _ int) *boxlayout.Box {
+				// the status window only ever shows one line, so there's no
+				// point in expanding it
+				if accordionMode && sideWindow.Window == args.CurrentSideWindow && sideWindow.Window != "status" {
 					return &boxlayout.Box{
-						Window: defaultBox.Window,
+						Window: sideWindow.Window,
 						Weight: args.UserConfig.Gui.ExpandedSidePanelWeight,
 					}
 				}
 
-				return defaultBox
+				return getDefaultSideWindowBox(args, sideWindow)
 			}
 
-			return []*boxlayout.Box{
-				{
-					Window: "status",
-					Size:   3,
-				},
-				accordionBox(&boxlayout.Box{Window: "files", Weight: 1}),
-				accordionBox(&boxlayout.Box{Window: "branches", Weight: 1}),
-				accordionBox(&boxlayout.Box{Window: "commits", Weight: 1}),
-				accordionBox(getDefaultStashWindowBox(args)),
-			}
+			return lo.Map(sideWindows, accordionBox)
 		}
 
 		squashedHeight := 1
@@ -472,26 +483,20 @@ func sidePanelChildren(args WindowArrangementArgs) func(width int, height int) [
 			squashedHeight = 3
 		}
 
-		squashedSidePanelBox := func(window string) *boxlayout.Box {
-			if window == args.CurrentSideWindow {
+		squashedSidePanelBox := func(sideWindow config.SideWindowConfig, _ int) *boxlayout.Box {
+			if sideWindow.Window == args.CurrentSideWindow {
 				return &boxlayout.Box{
-					Window: window,
+					Window: sideWindow.Window,
 					Weight: 1,
 				}
 			}
 
 			return &boxlayout.Box{
-				Window: window,
+				Window: sideWindow.Window,
 				Size:   squashedHeight,
 			}
 		}
 
-		return []*boxlayout.Box{
-			squashedSidePanelBox("status"),
-			squashedSidePanelBox("files"),
-			squashedSidePanelBox("branches"),
-			squashedSidePanelBox("commits"),
-			squashedSidePanelBox("stash"),
-		}
+		return lo.Map(sideWindows, squashedSidePanelBox)
 	}
 }
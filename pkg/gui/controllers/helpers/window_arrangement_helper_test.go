@@ -282,6 +282,121 @@ func TestGetWindowDimensions(t *testing.T) {
 			B: information
 			`,
 		},
+		{
+			name: "side windows on the right",
+			mutateArgs: func(args *WindowArrangementArgs) {
+				args.UserConfig.Gui.SideWindowLayout.Position = "right"
+			},
+			expected: `
+			╭main────────────────────────────────────────────╮╭status─────────────────╮
+			│                                                ││                       │
+			│                                                │╰───────────────────────╯
+			│                                                │╭files──────────────────╮
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                │╰───────────────────────╯
+			│                                                │╭branches───────────────╮
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                │╰───────────────────────╯
+			│                                                │╭commits────────────────╮
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                ││                       │
+			│                                                │╰───────────────────────╯
+			│                                                │╭stash──────────────────╮
+			│                                                ││                       │
+			╰────────────────────────────────────────────────╯╰───────────────────────╯
+			<options──────────────────────────────────────────────────────>A<B────────>
+			A: statusSpacer1
+			B: information
+			`,
+		},
+		{
+			name: "side windows on top",
+			mutateArgs: func(args *WindowArrangementArgs) {
+				args.Height = 20
+				args.UserConfig.Gui.SideWindowLayout.Position = "top"
+			},
+			expected: `
+			<status───────────────────────────────────────────────────────────────────>
+			╭files────────────────────────────────────────────────────────────────────╮
+			│                                                                         │
+			╰─────────────────────────────────────────────────────────────────────────╯
+			<branches─────────────────────────────────────────────────────────────────>
+			<commits──────────────────────────────────────────────────────────────────>
+			<stash────────────────────────────────────────────────────────────────────>
+			╭main─────────────────────────────────────────────────────────────────────╮
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			│                                                                         │
+			╰─────────────────────────────────────────────────────────────────────────╯
+			<options──────────────────────────────────────────────────────>A<B────────>
+			A: statusSpacer1
+			B: information
+			`,
+		},
+		{
+			name: "custom side windows",
+			mutateArgs: func(args *WindowArrangementArgs) {
+				args.UserConfig.Gui.SideWindowLayout.Windows = []config.SideWindowConfig{
+					{Window: "files", Size: 6},
+					{Window: "commits", Weight: 2, Tabs: []string{"stash"}},
+					{Window: "branches"},
+				}
+			},
+			expected: `
+			╭files──────────────────╮╭main────────────────────────────────────────────╮
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			╰───────────────────────╯│                                                │
+			╭commits────────────────╮│                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			╰───────────────────────╯│                                                │
+			╭branches───────────────╮│                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			│                       ││                                                │
+			╰───────────────────────╯╰────────────────────────────────────────────────╯
+			<options──────────────────────────────────────────────────────>A<B────────>
+			A: statusSpacer1
+			B: information
+			`,
+		},
 		{
 			name: "half screen mode, enlargedSideViewLocation left",
 			mutateArgs: func(args *WindowArrangementArgs) {
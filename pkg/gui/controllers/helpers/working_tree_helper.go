@@ -17,11 +17,13 @@ import (
 )
 
 type WorkingTreeHelper struct {
-	c                    *HelperCommon
-	refHelper            *RefsHelper
-	commitsHelper        *CommitsHelper
-	gpgHelper            *GpgHelper
-	mergeAndRebaseHelper *MergeAndRebaseHelper
+	c                       *HelperCommon
+	refHelper               *RefsHelper
+	commitsHelper           *CommitsHelper
+	gpgHelper               *GpgHelper
+	mergeAndRebaseHelper    *MergeAndRebaseHelper
+	protectedBranchesHelper *ProtectedBranchesHelper
+	hooksHelper             *HooksHelper
 }
 
 func NewWorkingTreeHelper(
@@ -30,13 +32,17 @@ func NewWorkingTreeHelper(
 	commitsHelper *CommitsHelper,
 	gpgHelper *GpgHelper,
 	mergeAndRebaseHelper *MergeAndRebaseHelper,
+	protectedBranchesHelper *ProtectedBranchesHelper,
+	hooksHelper *HooksHelper,
 ) *WorkingTreeHelper {
 	return &WorkingTreeHelper{
-		c:                    c,
-		refHelper:            refHelper,
-		commitsHelper:        commitsHelper,
-		gpgHelper:            gpgHelper,
-		mergeAndRebaseHelper: mergeAndRebaseHelper,
+		c:                       c,
+		refHelper:               refHelper,
+		commitsHelper:           commitsHelper,
+		gpgHelper:               gpgHelper,
+		mergeAndRebaseHelper:    mergeAndRebaseHelper,
+		protectedBranchesHelper: protectedBranchesHelper,
+		hooksHelper:             hooksHelper,
 	}
 }
 
@@ -115,36 +121,46 @@ func (self *WorkingTreeHelper) OpenMergeTool() error {
 }
 
 func (self *WorkingTreeHelper) HandleCommitPressWithMessage(initialMessage string, forceSkipHooks bool) error {
-	return self.WithEnsureCommittableFiles(func() error {
-		self.commitsHelper.OpenCommitMessagePanel(
-			&OpenCommitMessagePanelOpts{
-				CommitIndex:      context.NoCommitIndex,
-				InitialMessage:   initialMessage,
-				SummaryTitle:     self.c.Tr.CommitSummaryTitle,
-				DescriptionTitle: self.c.Tr.CommitDescriptionTitle,
-				PreserveMessage:  true,
-				OnConfirm: func(summary string, description string) error {
-					return self.handleCommit(summary, description, forceSkipHooks)
+	return self.protectedBranchesHelper.WithCheckedOutBranchProtectionCheck(ProtectedBranchDirectCommit, func() error {
+		return self.WithEnsureCommittableFiles(func() error {
+			self.commitsHelper.OpenCommitMessagePanel(
+				&OpenCommitMessagePanelOpts{
+					CommitIndex:      context.NoCommitIndex,
+					InitialMessage:   initialMessage,
+					SummaryTitle:     self.c.Tr.CommitSummaryTitle,
+					DescriptionTitle: self.c.Tr.CommitDescriptionTitle,
+					PreserveMessage:  true,
+					OnConfirm: func(summary string, description string) error {
+						return self.handleCommit(summary, description, forceSkipHooks)
+					},
+					OnSwitchToEditor: func(filepath string) error {
+						return self.switchFromCommitMessagePanelToEditor(filepath, forceSkipHooks)
+					},
+					ForceSkipHooks:  forceSkipHooks,
+					SkipHooksPrefix: self.c.UserConfig().Git.SkipHookPrefix,
 				},
-				OnSwitchToEditor: func(filepath string) error {
-					return self.switchFromCommitMessagePanelToEditor(filepath, forceSkipHooks)
-				},
-				ForceSkipHooks:  forceSkipHooks,
-				SkipHooksPrefix: self.c.UserConfig().Git.SkipHookPrefix,
-			},
-		)
+			)
 
-		return nil
+			return nil
+		})
 	})
 }
 
 func (self *WorkingTreeHelper) handleCommit(summary string, description string, forceSkipHooks bool) error {
 	cmdObj := self.c.Git().Commit.CommitCmdObj(summary, description, forceSkipHooks)
+	// hooks can also be skipped by using the skipHookPrefix in the summary
+	skipsHooks := lo.Contains(cmdObj.Args(), "--no-verify")
+	failedHook := self.c.Git().Hook.TraceFailures(cmdObj)
 	self.c.LogAction(self.c.Tr.Actions.Commit)
-	return self.gpgHelper.WithGpgHandling(cmdObj, git_commands.CommitGpgSign, self.c.Tr.CommittingStatus,
+	return self.gpgHelper.WithGpgHandlingAndOnError(cmdObj, git_commands.CommitGpgSign, self.c.Tr.CommittingStatus,
 		func() error {
 			self.commitsHelper.ClearPreservedCommitMessage()
 			return nil
+		},
+		func(err error) bool {
+			return !skipsHooks && self.hooksHelper.HandleCommandFailure(err, failedHook(), func() error {
+				return self.handleCommit(summary, description, true)
+			})
 		}, nil)
 }
 
@@ -166,15 +182,17 @@ func (self *WorkingTreeHelper) switchFromCommitMessagePanelToEditor(filepath str
 // HandleCommitEditorPress - handle when the user wants to commit changes via
 // their editor rather than via the popup panel
 func (self *WorkingTreeHelper) HandleCommitEditorPress() error {
-	return self.WithEnsureCommittableFiles(func() error {
-		// See reasoning in switchFromCommitMessagePanelToEditor for why it makes sense
-		// to clear this message before calling into the editor
-		self.commitsHelper.ClearPreservedCommitMessage()
-
-		self.c.LogAction(self.c.Tr.Actions.Commit)
-		return self.c.RunSubprocessAndRefresh(
-			self.c.Git().Commit.CommitEditorCmdObj(),
-		)
+	return self.protectedBranchesHelper.WithCheckedOutBranchProtectionCheck(ProtectedBranchDirectCommit, func() error {
+		return self.WithEnsureCommittableFiles(func() error {
+			// See reasoning in switchFromCommitMessagePanelToEditor for why it makes sense
+			// to clear this message before calling into the editor
+			self.commitsHelper.ClearPreservedCommitMessage()
+
+			self.c.LogAction(self.c.Tr.Actions.Commit)
+			return self.c.RunSubprocessAndRefresh(
+				self.c.Git().Commit.CommitEditorCmdObj(),
+			)
+		})
 	})
 }
 
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type HookOutputController struct {
+	baseController
+	c *ControllerCommon
+}
+
+var _ types.IController = &HookOutputController{}
+
+func NewHookOutputController(
+	c *ControllerCommon,
+) *HookOutputController {
+	return &HookOutputController{
+		baseController: baseController{},
+		c:              c,
+	}
+}
+
+func (self *HookOutputController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Key:               opts.GetKey(opts.Config.Universal.ConfirmMenu),
+			Handler:           self.retryWithoutHooks,
+			GetDisabledReason: self.canRetry,
+			Description:       self.c.Tr.RetryWithoutHooks,
+			Tooltip:           self.c.Tr.RetryWithoutHooksTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.CopyToClipboard),
+			Handler:         self.copyToClipboard,
+			Description:     self.c.Tr.CopyToClipboardMenu,
+			DisplayOnScreen: true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.Return),
+			Handler:         self.close,
+			Description:     self.c.Tr.CloseCancel,
+			DisplayOnScreen: true,
+		},
+	}
+
+	return bindings
+}
+
+func (self *HookOutputController) Context() types.Context {
+	return self.context()
+}
+
+func (self *HookOutputController) canRetry() *types.DisabledReason {
+	if self.context().GetOnRetry() == nil {
+		return &types.DisabledReason{Text: self.c.Tr.NothingToRetry}
+	}
+
+	return nil
+}
+
+func (self *HookOutputController) retryWithoutHooks() error {
+	onRetry := self.context().GetOnRetry()
+	self.c.Context().Pop()
+	return onRetry()
+}
+
+func (self *HookOutputController) copyToClipboard() error {
+	if err := self.c.OS().CopyToClipboard(self.context().GetOutput()); err != nil {
+		return err
+	}
+
+	self.c.Toast(self.c.Tr.MessageCopiedToClipboard)
+	return nil
+}
+
+func (self *HookOutputController) close() error {
+	self.c.Context().Pop()
+	return nil
+}
+
+func (self *HookOutputController) context() *context.HookOutputContext {
+	return self.c.Contexts().HookOutput
+}
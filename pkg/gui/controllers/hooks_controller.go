@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+type HooksController struct {
+	baseController
+	*ListControllerTrait[*models.Hook]
+	c *ControllerCommon
+}
+
+var _ types.IController = &HooksController{}
+
+func NewHooksController(
+	c *ControllerCommon,
+) *HooksController {
+	return &HooksController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().Hooks,
+			c.Contexts().Hooks.GetSelected,
+			c.Contexts().Hooks.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *HooksController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Key:               opts.GetKey(opts.Config.Universal.Select),
+			Handler:           self.withItem(self.c.Helpers().Hooks.ToggleEnabled),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.ToggleHookEnabled,
+			Tooltip:           self.c.Tr.ToggleHookEnabledTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.ConfirmMenu),
+			Handler:           self.withItem(self.c.Helpers().Hooks.Run),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.RunHook,
+			Tooltip:           self.c.Tr.RunHookTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.Edit),
+			Handler:           self.withItem(self.edit),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.EditHook,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.Return),
+			Handler:         self.close,
+			Description:     self.c.Tr.CloseCancel,
+			DisplayOnScreen: true,
+		},
+	}
+
+	return bindings
+}
+
+func (self *HooksController) edit(hook *models.Hook) error {
+	return self.c.Helpers().Files.EditFiles([]string{hook.Path})
+}
+
+func (self *HooksController) close() error {
+	self.c.Context().Pop()
+	return nil
+}
@@ -32,8 +32,9 @@ func (self *JumpToSideWindowController) Context() types.Context {
 func (self *JumpToSideWindowController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
 	windows := self.c.Helpers().Window.SideWindows()
 
-	if len(opts.Config.Universal.JumpToBlock) != len(windows) {
-		log.Fatal("Jump to block keybindings cannot be set. Exactly 5 keybindings must be supplied.")
+	// the layout may hide some side windows, in which case the last keys are unused
+	if len(opts.Config.Universal.JumpToBlock) < len(windows) {
+		log.Fatalf("Jump to block keybindings cannot be set. At least %d keybindings must be supplied.", len(windows))
 	}
 
 	return lo.Map(windows, func(window string, index int) *types.Binding {
@@ -52,13 +52,21 @@ func NewLocalCommitsController(
 	}
 }
 
+// Makes the handler of an action that rewrites or adds to the history of the
+// checked-out branch respect the protected branch rules
+func (self *LocalCommitsController) protected(action helpers.ProtectedBranchAction, handler func() error) func() error {
+	return func() error {
+		return self.c.Helpers().ProtectedBranches.WithCheckedOutBranchProtectionCheck(action, handler)
+	}
+}
+
 func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
 	editCommitKey := opts.Config.Universal.Edit
 
 	bindings := []*types.Binding{
 		{
 			Key:     opts.GetKey(opts.Config.Commits.SquashDown),
-			Handler: opts.Guards.OutsideFilterMode(self.withItemsRange(self.squashDown)),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.withItemsRange(self.squashDown))),
 			GetDisabledReason: self.require(
 				self.itemRangeSelected(
 					self.midRebaseCommandEnabled,
@@ -71,7 +79,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.MarkCommitAsFixup),
-			Handler: opts.Guards.OutsideFilterMode(self.withItemsRange(self.fixup)),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.withItemsRange(self.fixup))),
 			GetDisabledReason: self.require(
 				self.itemRangeSelected(
 					self.midRebaseCommandEnabled,
@@ -93,7 +101,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.RenameCommit),
-			Handler: self.withItem(self.reword),
+			Handler: self.protected(helpers.ProtectedBranchRebase, self.withItem(self.reword)),
 			GetDisabledReason: self.require(
 				self.singleItemSelected(self.rewordEnabled),
 			),
@@ -104,7 +112,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.RenameCommitWithEditor),
-			Handler: self.withItem(self.rewordEditor),
+			Handler: self.protected(helpers.ProtectedBranchRebase, self.withItem(self.rewordEditor)),
 			GetDisabledReason: self.require(
 				self.singleItemSelected(self.rewordEnabled),
 			),
@@ -112,7 +120,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Universal.Remove),
-			Handler: self.withItemsRange(self.drop),
+			Handler: self.protected(helpers.ProtectedBranchRebase, self.withItemsRange(self.drop)),
 			GetDisabledReason: self.require(
 				self.itemRangeSelected(
 					self.canDropCommits,
@@ -124,7 +132,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(editCommitKey),
-			Handler: opts.Guards.OutsideFilterMode(self.withItemsRange(self.edit)),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.withItemsRange(self.edit))),
 			GetDisabledReason: self.require(
 				self.itemRangeSelected(self.midRebaseCommandEnabled),
 			),
@@ -138,7 +146,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 			// we're calling it 'quick-start interactive rebase' to differentiate it from
 			// when you manually select the base commit.
 			Key:               opts.GetKey(opts.Config.Commits.StartInteractiveRebase),
-			Handler:           opts.Guards.OutsideFilterMode(self.quickStartInteractiveRebase),
+			Handler:           self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.quickStartInteractiveRebase)),
 			GetDisabledReason: self.require(self.notMidRebase(self.c.Tr.AlreadyRebasing), self.canFindCommitForQuickStart),
 			Description:       self.c.Tr.QuickStartInteractiveRebase,
 			Tooltip: utils.ResolvePlaceholderString(self.c.Tr.QuickStartInteractiveRebaseTooltip, map[string]string{
@@ -156,7 +164,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:               opts.GetKey(opts.Config.Commits.CreateFixupCommit),
-			Handler:           opts.Guards.OutsideFilterMode(self.withItem(self.createFixupCommit)),
+			Handler:           self.protected(helpers.ProtectedBranchDirectCommit, opts.Guards.OutsideFilterMode(self.withItem(self.createFixupCommit))),
 			GetDisabledReason: self.require(self.singleItemSelected()),
 			Description:       self.c.Tr.CreateFixupCommit,
 			Tooltip: utils.ResolvePlaceholderString(
@@ -168,7 +176,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.SquashAboveCommits),
-			Handler: opts.Guards.OutsideFilterMode(self.squashFixupCommits),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.squashFixupCommits)),
 			GetDisabledReason: self.require(
 				self.notMidRebase(self.c.Tr.AlreadyRebasing),
 			),
@@ -178,7 +186,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.MoveDownCommit),
-			Handler: opts.Guards.OutsideFilterMode(self.withItemsRange(self.moveDown)),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.withItemsRange(self.moveDown))),
 			GetDisabledReason: self.require(self.itemRangeSelected(
 				self.midRebaseMoveCommandEnabled,
 				self.canMoveDown,
@@ -187,7 +195,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:     opts.GetKey(opts.Config.Commits.MoveUpCommit),
-			Handler: opts.Guards.OutsideFilterMode(self.withItemsRange(self.moveUp)),
+			Handler: self.protected(helpers.ProtectedBranchRebase, opts.Guards.OutsideFilterMode(self.withItemsRange(self.moveUp))),
 			GetDisabledReason: self.require(self.itemRangeSelected(
 				self.midRebaseMoveCommandEnabled,
 				self.canMoveUp,
@@ -196,7 +204,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:               opts.GetKey(opts.Config.Commits.PasteCommits),
-			Handler:           opts.Guards.OutsideFilterMode(self.paste),
+			Handler:           self.protected(helpers.ProtectedBranchDirectCommit, opts.Guards.OutsideFilterMode(self.paste)),
 			GetDisabledReason: self.require(self.canPaste),
 			Description:       self.c.Tr.PasteCommits,
 			DisplayStyle:      &style.FgCyan,
@@ -218,7 +226,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:               opts.GetKey(opts.Config.Commits.AmendToCommit),
-			Handler:           self.withItem(self.amendTo),
+			Handler:           self.protected(helpers.ProtectedBranchRebase, self.withItem(self.amendTo)),
 			GetDisabledReason: self.require(self.singleItemSelected(self.canAmend)),
 			Description:       self.c.Tr.Amend,
 			Tooltip:           self.c.Tr.AmendCommitTooltip,
@@ -226,7 +234,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:               opts.GetKey(opts.Config.Commits.ResetCommitAuthor),
-			Handler:           self.withItemsRange(self.amendAttribute),
+			Handler:           self.protected(helpers.ProtectedBranchRebase, self.withItemsRange(self.amendAttribute)),
 			GetDisabledReason: self.require(self.itemRangeSelected(self.canAmendRange)),
 			Description:       self.c.Tr.AmendCommitAttribute,
 			Tooltip:           self.c.Tr.AmendCommitAttributeTooltip,
@@ -234,7 +242,7 @@ func (self *LocalCommitsController) GetKeybindings(opts types.KeybindingsOpts) [
 		},
 		{
 			Key:               opts.GetKey(opts.Config.Commits.RevertCommit),
-			Handler:           self.withItemsRange(self.revert),
+			Handler:           self.protected(helpers.ProtectedBranchDirectCommit, self.withItemsRange(self.revert)),
 			GetDisabledReason: self.require(self.itemRangeSelected()),
 			Description:       self.c.Tr.Revert,
 			Tooltip:           self.c.Tr.RevertCommitTooltip,
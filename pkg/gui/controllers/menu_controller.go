@@ -79,6 +79,7 @@ func (self *MenuController) close() error {
 	}
 
 	self.c.Context().Pop()
+	self.context().OnCancel()
 	return nil
 }
 
@@ -186,7 +186,7 @@ func (self *PatchBuildingController) getDisabledReasonForDiscard() *types.Disabl
 	if self.c.Git().Status.WorkingTreeState().Any() {
 		return &types.DisabledReason{Text: self.c.Tr.CantPatchWhileRebasingError, ShowErrorInPanel: true}
 	}
-	if self.c.UserConfig().Git.DiffContextSize == 0 {
+	if self.c.Git().Diff.DiffContextSize() == 0 {
 		text := fmt.Sprintf(self.c.Tr.Actions.NotEnoughContextToRemoveLines,
 			keybindings.Label(self.c.UserConfig().Keybinding.Universal.IncreaseContextInDiffView))
 		return &types.DisabledReason{Text: text, ShowErrorInPanel: true}
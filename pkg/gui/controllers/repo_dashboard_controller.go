@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+type RepoDashboardController struct {
+	baseController
+	*ListControllerTrait[*models.RepoSummary]
+	c *ControllerCommon
+}
+
+var _ types.IController = &RepoDashboardController{}
+
+func NewRepoDashboardController(
+	c *ControllerCommon,
+) *RepoDashboardController {
+	return &RepoDashboardController{
+		baseController: baseController{},
+		ListControllerTrait: NewListControllerTrait(
+			c,
+			c.Contexts().RepoDashboard,
+			c.Contexts().RepoDashboard.GetSelected,
+			c.Contexts().RepoDashboard.GetSelectedItems,
+		),
+		c: c,
+	}
+}
+
+func (self *RepoDashboardController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
+	bindings := []*types.Binding{
+		{
+			Key:               opts.GetKey(opts.Config.Universal.ConfirmMenu),
+			Handler:           self.withItem(self.open),
+			GetDisabledReason: self.require(self.singleItemSelected()),
+			Description:       self.c.Tr.OpenRepo,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Files.Fetch),
+			Handler:         self.fetchAll,
+			Description:     self.c.Tr.FetchAllRepos,
+			Tooltip:         self.c.Tr.FetchAllReposTooltip,
+			DisplayOnScreen: true,
+		},
+		{
+			Key:               opts.GetKey(opts.Config.Universal.Pull),
+			Handler:           self.pullAll,
+			GetDisabledReason: self.getDisabledReasonForPullAll,
+			Description:       self.c.Tr.PullAllRepos,
+			Tooltip:           self.c.Tr.PullAllReposTooltip,
+			DisplayOnScreen:   true,
+		},
+		{
+			Key:         opts.GetKey(opts.Config.Universal.Refresh),
+			Handler:     self.refresh,
+			Description: self.c.Tr.Refresh,
+		},
+		{
+			Key:             opts.GetKey(opts.Config.Universal.Return),
+			Handler:         self.close,
+			Description:     self.c.Tr.CloseCancel,
+			DisplayOnScreen: true,
+		},
+	}
+
+	return bindings
+}
+
+func (self *RepoDashboardController) GetOnClick() func() error {
+	return self.withItemGraceful(self.open)
+}
+
+func (self *RepoDashboardController) open(summary *models.RepoSummary) error {
+	self.c.Context().Pop()
+
+	if summary.Path == self.c.Git().RepoPaths.WorktreePath() {
+		return nil
+	}
+
+	// like when switching repos via the recent repos menu, forget about the
+	// submodules we entered
+	self.c.State().GetRepoPathStack().Clear()
+	return self.c.Helpers().Repos.DispatchSwitchToRepo(summary.Path, context.NO_CONTEXT)
+}
+
+func (self *RepoDashboardController) fetchAll() error {
+	self.c.LogAction(self.c.Tr.Actions.FetchAllRepos)
+	self.runInRepos(self.context().Summaries(), types.ItemOperationFetching, self.c.Git().Sync.FetchInRepo)
+	return nil
+}
+
+func (self *RepoDashboardController) getDisabledReasonForPullAll() *types.DisabledReason {
+	if len(self.fastForwardableRepos()) == 0 {
+		return &types.DisabledReason{Text: self.c.Tr.NoFastForwardableRepos}
+	}
+
+	return nil
+}
+
+func (self *RepoDashboardController) pullAll() error {
+	self.c.LogAction(self.c.Tr.Actions.PullAllRepos)
+	self.runInRepos(self.fastForwardableRepos(), types.ItemOperationFastForwarding, self.c.Git().Sync.FastForwardInRepo)
+	return nil
+}
+
+func (self *RepoDashboardController) fastForwardableRepos() []*models.RepoSummary {
+	return lo.Filter(self.context().Summaries(), func(summary *models.RepoSummary, _ int) bool {
+		return summary.IsFastForwardable()
+	})
+}
+
+// Runs the given action in all the given repos concurrently. Rather than
+// showing a popup for each repo where it fails, we show the error in the
+// repo's row.
+func (self *RepoDashboardController) runInRepos(summaries []*models.RepoSummary, operation types.ItemOperation, action func(path string) error) {
+	for _, summary := range summaries {
+		_ = self.c.WithInlineStatus(summary, operation, context.REPO_DASHBOARD_CONTEXT_KEY, func(gocui.Task) error {
+			err := action(summary.Path)
+			newSummary := self.c.Git().Loaders.RepoSummaryLoader.Load(summary.Path)
+			if err != nil && newSummary.Err == nil {
+				newSummary.Err = err
+			}
+			self.c.Helpers().RepoDashboard.UpdateSummary(newSummary)
+			return nil
+		})
+	}
+}
+
+func (self *RepoDashboardController) refresh() error {
+	paths := lo.Map(self.context().Summaries(), func(summary *models.RepoSummary, _ int) string {
+		return summary.Path
+	})
+	self.c.Helpers().RepoDashboard.LoadSummaries(paths)
+	return nil
+}
+
+func (self *RepoDashboardController) close() error {
+	if self.context().IsFiltering() {
+		self.c.Helpers().Search.Cancel()
+		return nil
+	}
+
+	self.c.Context().Pop()
+	return nil
+}
+
+func (self *RepoDashboardController) context() *context.RepoDashboardContext {
+	return self.c.Contexts().RepoDashboard
+}
@@ -203,7 +203,7 @@ func (self *StagingController) TogglePanel() error {
 }
 
 func (self *StagingController) ToggleStaged() error {
-	if self.c.UserConfig().Git.DiffContextSize == 0 {
+	if self.c.Git().Diff.DiffContextSize() == 0 {
 		return fmt.Errorf(self.c.Tr.Actions.NotEnoughContextToStage,
 			keybindings.Label(self.c.UserConfig().Keybinding.Universal.IncreaseContextInDiffView))
 	}
@@ -212,7 +212,7 @@ func (self *StagingController) ToggleStaged() error {
 }
 
 func (self *StagingController) DiscardSelection() error {
-	if self.c.UserConfig().Git.DiffContextSize == 0 {
+	if self.c.Git().Diff.DiffContextSize() == 0 {
 		return fmt.Errorf(self.c.Tr.Actions.NotEnoughContextToDiscard,
 			keybindings.Label(self.c.UserConfig().Keybinding.Universal.IncreaseContextInDiffView))
 	}
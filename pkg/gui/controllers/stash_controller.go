@@ -167,9 +167,14 @@ func (self *StashController) handleStashDrop(stashEntries []*models.StashEntry)
 				err := self.c.Git().Stash.Drop(stashEntries[i].Index)
 				self.c.Refresh(types.RefreshOptions{Scope: []types.RefreshableView{types.STASH}})
 				if err != nil {
+					// the entries that were dropped before can still be restored
+					if i < len(stashEntries)-1 {
+						self.c.Helpers().UndoJournal.RecordStashDrop(stashEntries[i+1:])
+					}
 					return err
 				}
 			}
+			self.c.Helpers().UndoJournal.RecordStashDrop(stashEntries)
 			self.context().CollapseRangeSelectionToTop()
 			return nil
 		},
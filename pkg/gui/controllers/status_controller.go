@@ -3,10 +3,12 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/constants"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -58,6 +60,18 @@ func (self *StatusController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			Description:     self.c.Tr.SwitchRepo,
 			DisplayOnScreen: true,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Status.RepoDashboard),
+			Handler:     self.c.Helpers().RepoDashboard.Open,
+			Description: self.c.Tr.OpenRepoDashboard,
+			Tooltip:     self.c.Tr.OpenRepoDashboardTooltip,
+		},
+		{
+			Key:         opts.GetKey(opts.Config.Status.Hooks),
+			Handler:     self.c.Helpers().Hooks.Open,
+			Description: self.c.Tr.OpenHooks,
+			Tooltip:     self.c.Tr.OpenHooksTooltip,
+		},
 		{
 			Key:         opts.GetKey(opts.Config.Status.AllBranchesLogGraph),
 			Handler:     func() error { self.switchToOrRotateAllBranchesLogs(); return nil },
@@ -68,11 +82,52 @@ func (self *StatusController) GetKeybindings(opts types.KeybindingsOpts) []*type
 			Handler:     func() error { self.switchToOrRotateAllBranchesLogsBackward(); return nil },
 			Description: self.c.Tr.AllBranchesLogGraphReverse,
 		},
+		{
+			Key:         opts.GetKey(opts.Config.Status.SelectTheme),
+			Handler:     self.openThemeMenu,
+			Description: self.c.Tr.SelectTheme,
+			OpensMenu:   true,
+		},
 	}
 
 	return bindings
 }
 
+func (self *StatusController) openThemeMenu() error {
+	themes, err := config.LoadThemes(self.c.UserConfig(), self.c.GetConfig().GetUserConfigDir())
+	if err != nil {
+		return err
+	}
+
+	currentTheme := self.c.GetAppState().Theme
+	if _, ok := themes[currentTheme]; !ok {
+		currentTheme = ""
+	}
+
+	themeMenuItem := func(name string, label string) *types.MenuItem {
+		return &types.MenuItem{
+			Label:  label,
+			Widget: types.MakeMenuRadioButton(name == currentTheme),
+			OnPress: func() error {
+				self.c.GetAppState().Theme = name
+				self.c.SaveAppStateAndLogError()
+				self.c.ApplyTheme()
+				return nil
+			},
+		}
+	}
+
+	configuredThemeItem := themeMenuItem("", self.c.Tr.ConfiguredTheme)
+	configuredThemeItem.Tooltip = self.c.Tr.ConfiguredThemeTooltip
+	names := lo.Keys(themes)
+	slices.Sort(names)
+	menuItems := append([]*types.MenuItem{configuredThemeItem}, lo.Map(names, func(name string, _ int) *types.MenuItem {
+		return themeMenuItem(name, name)
+	})...)
+
+	return self.c.Menu(types.CreateMenuOptions{Title: self.c.Tr.SelectTheme, Items: menuItems})
+}
+
 func (self *StatusController) GetMouseKeybindings(opts types.KeybindingsOpts) []*gocui.ViewMouseBinding {
 	return []*gocui.ViewMouseBinding{
 		{
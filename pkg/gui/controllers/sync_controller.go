@@ -9,6 +9,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -184,6 +185,7 @@ type pushOpts struct {
 	upstreamRemote string
 	upstreamBranch string
 	setUpstream    bool
+	noVerify       bool
 
 	// If this is false, we can't tell ahead of time whether a force-push will
 	// be necessary, so we start with a normal push and offer to force-push if
@@ -195,7 +197,7 @@ type pushOpts struct {
 func (self *SyncController) pushAux(currentBranch *models.Branch, opts pushOpts) error {
 	return self.c.WithInlineStatus(currentBranch, types.ItemOperationPushing, context.LOCAL_BRANCHES_CONTEXT_KEY, func(task gocui.Task) error {
 		self.c.LogAction(self.c.Tr.Actions.Push)
-		err := self.c.Git().Sync.Push(
+		cmdObj, err := self.c.Git().Sync.PushCmdObj(
 			task,
 			git_commands.PushOpts{
 				Force:          opts.force,
@@ -204,8 +206,13 @@ func (self *SyncController) pushAux(currentBranch *models.Branch, opts pushOpts)
 				UpstreamRemote: opts.upstreamRemote,
 				UpstreamBranch: opts.upstreamBranch,
 				SetUpstream:    opts.setUpstream,
+				NoVerify:       opts.noVerify,
 			})
 		if err != nil {
+			return err
+		}
+		failedHook := self.c.Git().Hook.TraceFailures(cmdObj)
+		if err := cmdObj.Run(); err != nil {
 			if !opts.force && !opts.forceWithLease && strings.Contains(err.Error(), "Updates were rejected") {
 				if opts.remoteBranchStoredLocally {
 					return errors.New(self.c.Tr.UpdatesRejected)
@@ -215,16 +222,26 @@ func (self *SyncController) pushAux(currentBranch *models.Branch, opts pushOpts)
 				if forcePushDisabled {
 					return errors.New(self.c.Tr.UpdatesRejectedAndForcePushDisabled)
 				}
-				self.c.Confirm(types.ConfirmOpts{
-					Title:  self.c.Tr.ForcePush,
-					Prompt: self.forcePushPrompt(),
-					HandleConfirm: func() error {
-						newOpts := opts
-						newOpts.force = true
-
-						return self.pushAux(currentBranch, newOpts)
-					},
+				return self.withForcePushProtectionCheck(currentBranch, opts, func() error {
+					self.c.Confirm(types.ConfirmOpts{
+						Title:  self.c.Tr.ForcePush,
+						Prompt: self.forcePushPrompt(),
+						HandleConfirm: func() error {
+							newOpts := opts
+							newOpts.force = true
+
+							return self.pushAux(currentBranch, newOpts)
+						},
+					})
+					return nil
 				})
+			}
+			retryWithoutHooks := func() error {
+				newOpts := opts
+				newOpts.noVerify = true
+				return self.pushAux(currentBranch, newOpts)
+			}
+			if !opts.noVerify && self.c.Helpers().Hooks.HandleCommandFailure(err, failedHook(), retryWithoutHooks) {
 				return nil
 			}
 			return err
@@ -240,16 +257,30 @@ func (self *SyncController) requestToForcePush(currentBranch *models.Branch, opt
 		return errors.New(self.c.Tr.ForcePushDisabled)
 	}
 
-	self.c.Confirm(types.ConfirmOpts{
-		Title:  self.c.Tr.ForcePush,
-		Prompt: self.forcePushPrompt(),
-		HandleConfirm: func() error {
-			opts.forceWithLease = true
-			return self.pushAux(currentBranch, opts)
-		},
+	return self.withForcePushProtectionCheck(currentBranch, opts, func() error {
+		self.c.Confirm(types.ConfirmOpts{
+			Title:  self.c.Tr.ForcePush,
+			Prompt: self.forcePushPrompt(),
+			HandleConfirm: func() error {
+				opts.forceWithLease = true
+				return self.pushAux(currentBranch, opts)
+			},
+		})
+
+		return nil
 	})
+}
 
-	return nil
+// Both the local branch and the remote branch that we push to can be protected
+func (self *SyncController) withForcePushProtectionCheck(currentBranch *models.Branch, opts pushOpts, f func() error) error {
+	branchNames := []string{currentBranch.Name}
+	if opts.upstreamBranch != "" {
+		branchNames = append(branchNames, opts.upstreamBranch)
+	} else if currentBranch.UpstreamBranch != "" {
+		branchNames = append(branchNames, currentBranch.UpstreamBranch)
+	}
+
+	return self.c.Helpers().ProtectedBranches.WithProtectionCheck(helpers.ProtectedBranchForcePush, branchNames, f)
 }
 
 func (self *SyncController) forcePushPrompt() string {
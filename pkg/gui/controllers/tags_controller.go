@@ -161,12 +161,28 @@ func (self *TagsController) checkout(tag *models.Tag) error {
 func (self *TagsController) localDelete(tag *models.Tag) error {
 	return self.c.WithWaitingStatus(self.c.Tr.DeletingStatus, func(gocui.Task) error {
 		self.c.LogAction(self.c.Tr.Actions.DeleteLocalTag)
-		err := self.c.Git().Tag.LocalDelete(tag.Name)
+		err := self.deleteLocalTag(tag)
 		self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.COMMITS, types.TAGS}})
 		return err
 	})
 }
 
+// Deletes the tag and records it in the undo journal so that the deletion can
+// be undone
+func (self *TagsController) deleteLocalTag(tag *models.Tag) error {
+	hash, err := self.c.Git().Tag.Hash(tag.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := self.c.Git().Tag.LocalDelete(tag.Name); err != nil {
+		return err
+	}
+
+	self.c.Helpers().UndoJournal.RecordTagDeletion(tag.Name, hash)
+	return nil
+}
+
 func (self *TagsController) remoteDelete(tag *models.Tag) error {
 	title := utils.ResolvePlaceholderString(
 		self.c.Tr.SelectRemoteTagUpstream,
@@ -255,7 +271,7 @@ func (self *TagsController) localAndRemoteDelete(tag *models.Tag) error {
 						}
 
 						self.c.LogAction(self.c.Tr.Actions.DeleteLocalTag)
-						if err := self.c.Git().Tag.LocalDelete(tag.Name); err != nil {
+						if err := self.deleteLocalTag(tag); err != nil {
 							return err
 						}
 						self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.COMMITS, types.TAGS}})
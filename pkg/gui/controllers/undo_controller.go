@@ -3,10 +3,13 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 // Quick summary of how this all works:
@@ -18,6 +21,10 @@ import (
 // actions we can skip. E.g. if I do three things, A, B, and C, and hit undo twice,
 // the reflog will read UUCBA, and when I read the first two undos, I know to skip the following
 // two user actions, meaning we end up undoing reflog entry C. Redoing works in a similar way.
+// Deleting branches, tags and stash entries doesn't leave a trace in the reflog, so we record
+// those actions (and our undos/redos of them) in a journal of our own. Each journal entry knows
+// how long the reflog was when it was recorded, which lets us slot it in between the reflog
+// entries and then treat it like any other entry.
 
 type UndoController struct {
 	baseController
@@ -42,12 +49,15 @@ const (
 	COMMIT
 	REBASE
 	CURRENT_REBASE
+	JOURNAL
 )
 
 type reflogAction struct {
 	kind ReflogActionKind
 	from string
 	to   string
+	// only set for the JOURNAL kind
+	journalEntry *models.UndoJournalEntry
 }
 
 func (self *UndoController) GetKeybindings(opts types.KeybindingsOpts) []*types.Binding {
@@ -128,6 +138,24 @@ func (self *UndoController) reflogUndo() error {
 			})
 			return true, nil
 
+		case JOURNAL:
+			self.c.Confirm(types.ConfirmOpts{
+				Title:  self.c.Tr.Actions.Undo,
+				Prompt: self.journalEntryPrompt(action.journalEntry, true),
+				HandleConfirm: func() error {
+					self.c.LogAction(self.c.Tr.Actions.Undo)
+					return self.c.WithWaitingStatus(undoingStatus, func(gocui.Task) error {
+						err := self.c.Helpers().UndoJournal.Restore(action.journalEntry)
+						if err == nil {
+							self.c.Helpers().UndoJournal.RecordUndo()
+						}
+						self.refreshAfterJournalAction(action.journalEntry)
+						return err
+					})
+				},
+			})
+			return true, nil
+
 		case CURRENT_REBASE:
 			// do nothing
 		}
@@ -182,6 +210,24 @@ func (self *UndoController) reflogRedo() error {
 			})
 			return true, nil
 
+		case JOURNAL:
+			self.c.Confirm(types.ConfirmOpts{
+				Title:  self.c.Tr.Actions.Redo,
+				Prompt: self.journalEntryPrompt(action.journalEntry, false),
+				HandleConfirm: func() error {
+					self.c.LogAction(self.c.Tr.Actions.Redo)
+					return self.c.WithWaitingStatus(redoingStatus, func(gocui.Task) error {
+						err := self.c.Helpers().UndoJournal.DeleteAgain(action.journalEntry)
+						if err == nil {
+							self.c.Helpers().UndoJournal.RecordRedo()
+						}
+						self.refreshAfterJournalAction(action.journalEntry)
+						return err
+					})
+				},
+			})
+			return true, nil
+
 		case CURRENT_REBASE:
 			// do nothing
 		}
@@ -200,9 +246,41 @@ func (self *UndoController) reflogRedo() error {
 func (self *UndoController) parseReflogForActions(onUserAction func(counter int, action reflogAction) (bool, error)) error {
 	counter := 0
 	reflogCommits := self.c.Model().ReflogCommits
+	journalEntries, err := self.c.Helpers().UndoJournal.Load()
+	if err != nil {
+		return err
+	}
+	// handles the journal entries that were recorded when the reflog had at
+	// least the given number of entries
+	parseJournalEntries := func(minReflogCount int) (bool, error) {
+		for len(journalEntries) > 0 && journalEntries[0].ReflogCount >= minReflogCount {
+			journalEntry := journalEntries[0]
+			journalEntries = journalEntries[1:]
+
+			switch journalEntry.Kind {
+			case models.UndoJournalUndo:
+				counter++
+			case models.UndoJournalRedo:
+				counter--
+			default:
+				ok, err := onUserAction(counter, reflogAction{kind: JOURNAL, journalEntry: journalEntry})
+				if ok {
+					return true, err
+				}
+				counter--
+			}
+		}
+		return false, nil
+	}
+
 	rebaseFinishCommitHash := ""
 	var action *reflogAction
 	for reflogCommitIdx, reflogCommit := range reflogCommits {
+		// the journal entries that were recorded after this reflog entry come first
+		if ok, err := parseJournalEntries(len(reflogCommits) - reflogCommitIdx); ok {
+			return err
+		}
+
 		action = nil
 
 		prevCommitHash := ""
@@ -242,7 +320,44 @@ func (self *UndoController) parseReflogForActions(onUserAction func(counter int,
 			counter--
 		}
 	}
-	return nil
+
+	_, err = parseJournalEntries(0)
+	return err
+}
+
+func (self *UndoController) journalEntryPrompt(entry *models.UndoJournalEntry, undo bool) string {
+	quotedNames := strings.Join(lo.Map(entry.RefNames(), func(name string, _ int) string { return "'" + name + "'" }), ", ")
+	stashMessages := strings.Join(lo.Map(entry.Refs, func(ref *models.UndoJournalRef, _ int) string { return ref.Message }), "\n")
+
+	switch entry.Kind {
+	case models.UndoJournalDeleteBranches:
+		return utils.ResolvePlaceholderString(
+			lo.Ternary(undo, self.c.Tr.UndoDeleteBranchesPrompt, self.c.Tr.RedoDeleteBranchesPrompt),
+			map[string]string{"branches": quotedNames},
+		)
+	case models.UndoJournalDeleteTag:
+		return utils.ResolvePlaceholderString(
+			lo.Ternary(undo, self.c.Tr.UndoDeleteTagPrompt, self.c.Tr.RedoDeleteTagPrompt),
+			map[string]string{"tag": quotedNames},
+		)
+	default:
+		return utils.ResolvePlaceholderString(
+			lo.Ternary(undo, self.c.Tr.UndoDropStashesPrompt, self.c.Tr.RedoDropStashesPrompt),
+			map[string]string{"stashEntries": stashMessages},
+		)
+	}
+}
+
+func (self *UndoController) refreshAfterJournalAction(entry *models.UndoJournalEntry) {
+	scope := []types.RefreshableView{types.BRANCHES}
+	switch entry.Kind {
+	case models.UndoJournalDeleteTag:
+		scope = []types.RefreshableView{types.COMMITS, types.TAGS}
+	case models.UndoJournalDropStashes:
+		scope = []types.RefreshableView{types.STASH}
+	}
+
+	self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC, Scope: scope})
 }
 
 type hardResetOptions struct {
@@ -9,14 +9,21 @@ import (
 	"time"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
 )
 
 // this is in its own file given that the workspace controller file is already quite long
 
 func (self *FilesController) createResetMenu() error {
+	return self.c.Menu(types.CreateMenuOptions{Title: "", Items: self.resetMenuItems()})
+}
+
+func (self *FilesController) resetMenuItems() []*types.MenuItem {
 	red := style.FgRed
 
 	nukeStr := "git reset --hard HEAD && git clean -fd"
@@ -24,7 +31,7 @@ func (self *FilesController) createResetMenu() error {
 		nukeStr = fmt.Sprintf("%s (%s)", nukeStr, self.c.Tr.AndResetSubmodules)
 	}
 
-	menuItems := []*types.MenuItem{
+	return []*types.MenuItem{
 		{
 			LabelColumns: []string{
 				self.c.Tr.DiscardAllChangesToAllFiles,
@@ -103,6 +110,7 @@ func (self *FilesController) createResetMenu() error {
 				if !self.c.Helpers().WorkingTree.IsWorkingTreeDirtyExceptSubmodules() {
 					return errors.New(self.c.Tr.NoTrackedStagedFilesStash)
 				}
+				self.c.Git().Snapshot.TakeBeforeDiscarding("discard staged changes")
 				if err := self.c.Git().Stash.SaveStagedChanges("[lazygit] tmp stash"); err != nil {
 					return err
 				}
@@ -178,9 +186,83 @@ func (self *FilesController) createResetMenu() error {
 			},
 			Key: 'h',
 		},
+		{
+			LabelColumns: []string{self.c.Tr.RecoverDiscardedChanges},
+			OnPress:      self.createRecoverDiscardedChangesMenu,
+			Key:          'r',
+			Tooltip:      self.c.Tr.RecoverDiscardedChangesTooltip,
+			OpensMenu:    true,
+		},
+	}
+}
+
+func (self *FilesController) createRecoverDiscardedChangesMenu() error {
+	snapshots, err := self.c.Git().Snapshot.List()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		return errors.New(self.c.Tr.NoDiscardSnapshots)
+	}
+
+	menuItems := lo.Map(snapshots, func(snapshot *models.Snapshot, _ int) *types.MenuItem {
+		return &types.MenuItem{
+			LabelColumns: []string{
+				style.FgBlue.Sprint(utils.UnixToTimeAgo(snapshot.UnixTimestamp)),
+				snapshot.Description,
+				style.FgYellow.Sprint(snapshot.ShortHash()),
+			},
+			OnPress: func() error {
+				return self.createRestoreFromSnapshotMenu(snapshot)
+			},
+			OpensMenu: true,
+		}
+	})
+
+	return self.c.Menu(types.CreateMenuOptions{Title: self.c.Tr.RecoverDiscardedChanges, Items: menuItems})
+}
+
+func (self *FilesController) createRestoreFromSnapshotMenu(snapshot *models.Snapshot) error {
+	paths, err := self.c.Git().Snapshot.ChangedFiles(snapshot)
+	if err != nil {
+		return err
+	}
+
+	restore := func(paths []string) error {
+		self.c.LogAction(self.c.Tr.Actions.RestoreDiscardedChanges)
+		if err := self.c.Git().Snapshot.Restore(snapshot, paths); err != nil {
+			return err
+		}
+
+		self.c.Refresh(
+			types.RefreshOptions{Mode: types.ASYNC, Scope: []types.RefreshableView{types.FILES}},
+		)
+		return nil
+	}
+
+	var noFilesDisabledReason *types.DisabledReason
+	if len(paths) == 0 {
+		noFilesDisabledReason = &types.DisabledReason{Text: self.c.Tr.SnapshotHasNoFilesToRestore}
+	}
+
+	menuItems := []*types.MenuItem{
+		{
+			Label:          self.c.Tr.RestoreAllFiles,
+			OnPress:        func() error { return restore(paths) },
+			Key:            'a',
+			Tooltip:        self.c.Tr.RestoreFilesFromSnapshotTooltip,
+			DisabledReason: noFilesDisabledReason,
+		},
+	}
+	for _, path := range paths {
+		menuItems = append(menuItems, &types.MenuItem{
+			Label:   path,
+			OnPress: func() error { return restore([]string{path}) },
+			Tooltip: self.c.Tr.RestoreFilesFromSnapshotTooltip,
+		})
 	}
 
-	return self.c.Menu(types.CreateMenuOptions{Title: "", Items: menuItems})
+	return self.c.Menu(types.CreateMenuOptions{Title: snapshot.Description, Items: menuItems})
 }
 
 func (self *FilesController) animateExplosion() {
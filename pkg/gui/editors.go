@@ -2,6 +2,7 @@ package gui
 
 import (
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
 )
 
 func (gui *Gui) handleEditorKeypress(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier, allowMultiline bool) bool {
@@ -56,3 +57,24 @@ func (gui *Gui) searchEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Mo
 
 	return matched
 }
+
+// Receives the keys that are pressed while the key sequence popup is showing.
+// Any key that doesn't continue one of the sequences cancels the popup.
+func (gui *Gui) keySequenceEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	var pressedKey types.Key = key
+	if ch != 0 {
+		pressedKey = ch
+	}
+
+	binding, canContinue := gui.State.Contexts.KeySequence.Press(pressedKey)
+	if !canContinue {
+		gui.c.Context().Pop()
+	}
+	if binding != nil {
+		gui.c.OnUIThread(func() error {
+			return gui.callKeybindingHandler(binding)
+		})
+	}
+
+	return true
+}
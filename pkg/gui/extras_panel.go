@@ -32,6 +32,11 @@ func (gui *Gui) handleCreateExtrasMenuPanel() error {
 				Key:     'f',
 				OnPress: gui.handleFocusCommandLog,
 			},
+			{
+				Label:   gui.c.Tr.ShowCommandHistory,
+				Key:     'h',
+				OnPress: func() error { return gui.helpers.CommandHistory.Open() },
+			},
 		},
 	})
 }
@@ -1,6 +1,10 @@
 package filetree
 
-import "github.com/jesseduffield/generics/set"
+import (
+	"slices"
+
+	"github.com/jesseduffield/generics/set"
+)
 
 type CollapsedPaths struct {
 	collapsedPaths *set.Set[string]
@@ -37,6 +41,13 @@ func (self *CollapsedPaths) ToggleCollapsed(path string) {
 	}
 }
 
+// Returns the collapsed paths in sorted order
+func (self *CollapsedPaths) ToSlice() []string {
+	paths := self.collapsedPaths.ToSlice()
+	slices.Sort(paths)
+	return paths
+}
+
 func (self *CollapsedPaths) ExpandAll() {
 	// Could be cleaner if Set had a Clear() method...
 	self.collapsedPaths.RemoveSlice(self.collapsedPaths.ToSlice())
@@ -100,6 +100,21 @@ func (self *FileTreeViewModel) GetSelectedPath() string {
 	return node.GetPath()
 }
 
+// Selects the given path, expanding its parent directories if they are
+// collapsed. Returns false if the path isn't in the tree, e.g. because the file
+// has no changes. Like with CommitFileTreeViewModel.SelectPath, the path must be
+// relative to the repo root and contain forward slashes.
+func (self *FileTreeViewModel) SelectPath(filepath string, showRootItem bool) bool {
+	internalPath := InternalTreePathForFilePath(filepath, showRootItem)
+	self.ExpandToPath(internalPath)
+
+	index, found := self.GetIndexForPath(internalPath)
+	if found {
+		self.SetSelection(index)
+	}
+	return found
+}
+
 func (self *FileTreeViewModel) SetTree() {
 	newFiles := self.GetAllFiles()
 	selectedNode := self.GetSelected()
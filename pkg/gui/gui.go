@@ -37,6 +37,8 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/graph"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation/icons"
 	"github.com/jesseduffield/lazygit/pkg/gui/services/custom_commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/plugins"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/remote"
 	"github.com/jesseduffield/lazygit/pkg/gui/status"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
@@ -73,6 +75,8 @@ type Gui struct {
 	pagerConfig *config.PagerConfig
 
 	CustomCommandsClient *custom_commands.Client
+	PluginsClient        *plugins.Client
+	RemoteControlServer  *remote.Server
 
 	// this is a mapping of repos to gui states, so that we can restore the original
 	// gui state when returning from a subrepo.
@@ -256,6 +260,15 @@ type GuiRepoState struct {
 	CurrentPopupOpts *types.CreatePopupPanelOpts
 
 	LastBackgroundFetchTime time.Time
+
+	// The branch and commit that were selected when the repo was last left;
+	// they are selected once the branches and commits have been loaded
+	BranchToSelect     string
+	CommitHashToSelect string
+
+	// The diff context size that the user chose for this repo, overriding
+	// git.diffContextSize; nil if they didn't change it
+	DiffContextSize *uint64
 }
 
 var _ types.IRepoStateAccessor = new(GuiRepoState)
@@ -308,6 +321,22 @@ func (self *GuiRepoState) GetSplitMainPanel() bool {
 	return self.SplitMainPanel
 }
 
+func (self *GuiRepoState) TakeBranchToSelect() string {
+	value := self.BranchToSelect
+	self.BranchToSelect = ""
+	return value
+}
+
+func (self *GuiRepoState) TakeCommitHashToSelect() string {
+	value := self.CommitHashToSelect
+	self.CommitHashToSelect = ""
+	return value
+}
+
+func (self *GuiRepoState) SetDiffContextSize(value uint64) {
+	self.DiffContextSize = &value
+}
+
 func (gui *Gui) onSwitchToNewRepo(startArgs appTypes.StartArgs, contextKey types.ContextKey) error {
 	err := gui.onNewRepo(startArgs, contextKey)
 	if err == nil && gui.UserConfig().Git.AutoFetch && gui.UserConfig().Refresher.FetchInterval > 0 {
@@ -319,6 +348,10 @@ func (gui *Gui) onSwitchToNewRepo(startArgs appTypes.StartArgs, contextKey types
 }
 
 func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.ContextKey) error {
+	if gui.State != nil {
+		gui.saveRepoSession()
+	}
+
 	var err error
 	gui.git, err = commands.NewGitCommand(
 		gui.Common,
@@ -326,6 +359,7 @@ func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.Context
 		gui.os,
 		git_config.NewStdCachedGitConfig(gui.Log),
 		gui.pagerConfig,
+		func() *uint64 { return gui.State.DiffContextSize },
 	)
 	if err != nil {
 		return err
@@ -341,7 +375,8 @@ func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.Context
 		return err
 	}
 
-	contextToPush := gui.resetState(startArgs)
+	session := gui.getRepoSession()
+	contextToPush := gui.resetState(startArgs, session)
 
 	gui.resetHelpersAndControllers()
 
@@ -349,6 +384,10 @@ func (gui *Gui) onNewRepo(startArgs appTypes.StartArgs, contextKey types.Context
 		return err
 	}
 
+	gui.PluginsClient.Start(gui.git.RepoPaths.WorktreePath())
+	gui.RemoteControlServer.Start()
+	gui.BackgroundRoutineMgr.onNewRepo()
+
 	gui.g.SetFocusHandler(func(Focused bool) error {
 		if Focused {
 			gui.git.Config.DropConfigCache()
@@ -487,10 +526,14 @@ func (gui *Gui) checkForChangedConfigsThatDontAutoReload(oldConfig *config.UserC
 	configsThatDontAutoReload := []string{
 		"Git.AutoFetch",
 		"Git.AutoRefresh",
+		"Refresher.WatchFiles",
 		"Refresher.RefreshInterval",
 		"Refresher.FetchInterval",
 		"Update.Method",
 		"Update.Days",
+		"Gui.SideWindowLayout",
+		"CustomPanels",
+		"Plugins",
 	}
 
 	changedConfigs := []string{}
@@ -511,7 +554,7 @@ func (gui *Gui) checkForChangedConfigsThatDontAutoReload(oldConfig *config.UserC
 			new = new.FieldByName(fieldName)
 		}
 		// if the value has changed, ...
-		if !old.Equal(new) {
+		if !reflect.DeepEqual(old.Interface(), new.Interface()) {
 			// ... append it to the list of changed configs
 			changedConfigs = append(changedConfigs, strings.Join(userFacingPath, "."))
 		}
@@ -536,8 +579,9 @@ func (gui *Gui) checkForChangedConfigsThatDontAutoReload(oldConfig *config.UserC
 }
 
 // resetState reuses the repo state from our repo state map, if the repo was
-// open before; otherwise it creates a new one.
-func (gui *Gui) resetState(startArgs appTypes.StartArgs) types.Context {
+// open before; otherwise it creates a new one, restoring the given session if
+// it isn't nil.
+func (gui *Gui) resetState(startArgs appTypes.StartArgs, session *config.RepoSession) types.Context {
 	// Un-highlight the current view if there is one. The reason we do this is
 	// that the repo we are switching to might have a different view focused,
 	// and would then show an inactive highlight for the previous view.
@@ -552,7 +596,7 @@ func (gui *Gui) resetState(startArgs appTypes.StartArgs) types.Context {
 		gui.State.ViewsSetup = false
 
 		contextTree := gui.State.Contexts
-		gui.State.WindowViewNameMap = initialWindowViewNameMap(contextTree)
+		gui.State.WindowViewNameMap = initialWindowViewNameMap(contextTree, gui.viewTabMap())
 
 		// setting this to nil so we don't get stuck based on a popup that was
 		// previously opened
@@ -592,13 +636,19 @@ func (gui *Gui) resetState(startArgs appTypes.StartArgs) types.Context {
 		// TODO: only use contexts from context manager
 		ContextMgr:        NewContextMgr(gui, contextTree),
 		Contexts:          contextTree,
-		WindowViewNameMap: initialWindowViewNameMap(contextTree),
+		WindowViewNameMap: initialWindowViewNameMap(contextTree, gui.viewTabMap()),
 		SearchState:       types.NewSearchState(),
 	}
 
 	gui.RepoStateMap[Repo(worktreePath)] = gui.State
 
-	return initialContext(contextTree, startArgs)
+	if session != nil {
+		if context := gui.restoreRepoSession(session, startArgs); context != nil {
+			return context
+		}
+	}
+
+	return initialContext(contextTree, gui.c.UserConfig().Gui.SideWindowLayout, startArgs)
 }
 
 func (gui *Gui) getViewBufferManagerForView(view *gocui.View) *tasks.ViewBufferManager {
@@ -610,13 +660,19 @@ func (gui *Gui) getViewBufferManagerForView(view *gocui.View) *tasks.ViewBufferM
 	return manager
 }
 
-func initialWindowViewNameMap(contextTree *context.ContextTree) *utils.ThreadSafeMap[string, string] {
+func initialWindowViewNameMap(contextTree *context.ContextTree, viewTabMap map[string][]context.TabView) *utils.ThreadSafeMap[string, string] {
 	result := utils.NewThreadSafeMap[string, string]()
 
 	for _, context := range contextTree.Flatten() {
 		result.Set(context.GetWindowName(), context.GetViewName())
 	}
 
+	// windows with tabs initially show their first tab, even if it's another
+	// window's tabs that come last in the context tree
+	for windowName, tabs := range viewTabMap {
+		result.Set(windowName, tabs[0].ViewName)
+	}
+
 	return result
 }
 
@@ -641,8 +697,20 @@ func parseScreenModeArg(screenModeArg string) types.ScreenMode {
 	}
 }
 
-func initialContext(contextTree *context.ContextTree, startArgs appTypes.StartArgs) types.IListContext {
-	var initialContext types.IListContext = contextTree.Files
+// the inverse of parseScreenModeArg
+func screenModeArg(screenMode types.ScreenMode) string {
+	switch screenMode {
+	case types.SCREEN_HALF:
+		return "half"
+	case types.SCREEN_FULL:
+		return "full"
+	default:
+		return "normal"
+	}
+}
+
+func initialContext(contextTree *context.ContextTree, layout config.SideWindowLayoutConfig, startArgs appTypes.StartArgs) types.Context {
+	var initialContext types.Context = contextTree.Files
 
 	if startArgs.FilterPath != "" {
 		initialContext = contextTree.LocalCommits
@@ -661,6 +729,10 @@ func initialContext(contextTree *context.ContextTree, startArgs appTypes.StartAr
 		}
 	}
 
+	if !isShownInLayout(initialContext, layout) {
+		return defaultSideContextForLayout(contextTree, layout)
+	}
+
 	return initialContext
 }
 
@@ -730,6 +802,7 @@ func NewGui(
 		gui.LogCommand,
 		gui.getCmdWriter,
 		credentialsHelper.PromptUserForCredential,
+		gui.recordCommand,
 	)
 
 	osCommand := oscommands.NewOSCommand(cmn, configurer, oscommands.GetPlatform(), guiIO)
@@ -786,7 +859,13 @@ func (gui *Gui) initGocui(headless bool, test integrationTypes.IntegrationTest)
 }
 
 func (gui *Gui) viewTabMap() map[string][]context.TabView {
-	result := map[string][]context.TabView{
+	windowTabs := map[string][]context.TabView{
+		"status": {
+			{
+				Tab:      gui.c.Tr.StatusTitle,
+				ViewName: "status",
+			},
+		},
 		"branches": {
 			{
 				Tab:      gui.c.Tr.LocalBranchesTitle,
@@ -825,6 +904,36 @@ func (gui *Gui) viewTabMap() map[string][]context.TabView {
 				ViewName: "submodules",
 			},
 		},
+		"stash": {
+			{
+				Tab:      gui.c.Tr.StashTitle,
+				ViewName: "stash",
+			},
+		},
+	}
+
+	for _, panel := range gui.c.UserConfig().CustomPanels {
+		if _, ok := gui.Views.CustomPanels[panel.Key]; !ok {
+			continue
+		}
+
+		windowTabs[panel.Window] = append(windowTabs[panel.Window], context.TabView{
+			Tab:      panel.Title,
+			ViewName: context.CustomPanelViewName(panel.Key),
+		})
+	}
+
+	result := map[string][]context.TabView{}
+	for _, sideWindow := range gui.c.UserConfig().Gui.SideWindowLayout.Windows {
+		tabs := windowTabs[sideWindow.Window]
+		for _, tab := range sideWindow.Tabs {
+			tabs = append(tabs, windowTabs[tab]...)
+		}
+
+		// a window showing a single view has no tabs
+		if len(tabs) > 1 {
+			result[sideWindow.Window] = tabs
+		}
 	}
 
 	return result
@@ -895,7 +1004,9 @@ func (gui *Gui) Run(startArgs appTypes.StartArgs) error {
 	// setting here so we can use it in layout.go
 	gui.integrationTest = startArgs.IntegrationTest
 
-	return gui.g.MainLoop()
+	err = gui.g.MainLoop()
+	gui.saveRepoSession()
+	return err
 }
 
 func (gui *Gui) RunAndHandleError(startArgs appTypes.StartArgs) error {
@@ -906,6 +1017,14 @@ func (gui *Gui) RunAndHandleError(startArgs appTypes.StartArgs) error {
 				manager.Close()
 			}
 
+			if gui.PluginsClient != nil {
+				gui.PluginsClient.Stop()
+			}
+
+			if gui.RemoteControlServer != nil {
+				gui.RemoteControlServer.Stop()
+			}
+
 			close(gui.stopChan)
 
 			if errors.Is(err, gocui.ErrQuit) {
@@ -1119,9 +1238,13 @@ func (gui *Gui) showBreakingChangesMessage() {
 }
 
 // setColorScheme sets the color scheme for the app based on the user config
+// and the theme chosen in the theme menu
 func (gui *Gui) setColorScheme() {
-	userConfig := gui.UserConfig()
-	theme.UpdateTheme(userConfig.Gui.Theme)
+	themeConfig, err := config.GetTheme(gui.UserConfig(), gui.Config.GetUserConfigDir(), gui.c.GetAppState().Theme)
+	if err != nil {
+		gui.c.Log.Error(err)
+	}
+	theme.UpdateTheme(themeConfig)
 
 	gui.g.FgColor = theme.InactiveBorderColor
 	gui.g.SelFgColor = theme.ActiveBorderColor
@@ -1129,6 +1252,12 @@ func (gui *Gui) setColorScheme() {
 	gui.g.SelFrameColor = theme.ActiveBorderColor
 }
 
+func (gui *Gui) applyTheme() {
+	gui.setColorScheme()
+	gui.configureViewProperties()
+	gui.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+}
+
 func (gui *Gui) onUIThread(f func() error) {
 	gui.g.Update(func(*gocui.Gui) error {
 		return f()
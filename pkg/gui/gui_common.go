@@ -157,6 +157,10 @@ func (self *guiCommon) ResetKeybindings() error {
 	return self.gui.resetKeybindings()
 }
 
+func (self *guiCommon) ApplyTheme() {
+	self.gui.applyTheme()
+}
+
 func (self *guiCommon) IsAnyModeActive() bool {
 	return self.gui.helpers.Mode.IsAnyModeActive()
 }
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/remote"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	integrationTypes "github.com/jesseduffield/lazygit/pkg/integration/types"
 )
@@ -64,6 +66,27 @@ func (self *GuiDriver) Click(x, y int) {
 	self.waitTillIdle()
 }
 
+// Sends the request to the remote control socket like any other client would,
+// and returns the response. The request must be valid JSON; otherwise it never
+// reaches the gui, so we'd wait for it to become idle forever.
+func (self *GuiDriver) SendRemoteControlRequest(request string) string {
+	self.CheckAllToastsAcknowledged()
+
+	socketPath, err := remote.SocketPath(self.gui.c.UserConfig().RemoteControl)
+	if err != nil {
+		self.Fail(err.Error())
+	}
+
+	var response bytes.Buffer
+	if _, err := remote.Send(socketPath, strings.NewReader(request), &response); err != nil {
+		self.Fail(err.Error())
+	}
+
+	self.waitTillIdle()
+
+	return strings.TrimSpace(response.String())
+}
+
 // wait until lazygit is idle (i.e. all processing is done) before continuing
 func (self *GuiDriver) waitTillIdle() {
 	<-self.isIdleChan
@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"slices"
+
+	"github.com/jesseduffield/generics/set"
+	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// Two keybindings of the same view (or two global ones) whose keys clash, so
+// that only one of them can be invoked. This is the case when they have the
+// same key, or when one of them is a key sequence that starts with the key (or
+// key sequence) of the other.
+type KeybindingConflict struct {
+	// Empty for global keybindings
+	ViewName string
+	Bindings [2]*types.Binding
+}
+
+// only to be called when checking the user config. This mutates the Gui
+// struct, like GetCheatsheetKeybindings does.
+func (gui *Gui) GetKeybindingConflicts() ([]KeybindingConflict, error) {
+	bindings := gui.GetCheatsheetKeybindings()
+	customBindings, err := gui.CustomCommandsClient.GetCustomCommandKeybindings()
+	if err != nil {
+		return nil, err
+	}
+	isCustom := set.NewFromSlice(customBindings)
+
+	// custom bindings come first, like in GetInitialKeybindingsWithCustomCommands
+	bindings = append(customBindings, bindings...)
+
+	conflicts := []KeybindingConflict{}
+	for i, binding := range bindings {
+		keys := bindingKeySequence(binding)
+		if len(keys) == 0 {
+			continue
+		}
+
+		for _, other := range bindings[:i] {
+			if other.ViewName != binding.ViewName || other.Modifier != binding.Modifier {
+				continue
+			}
+
+			otherKeys := bindingKeySequence(other)
+			if len(otherKeys) == 0 {
+				continue
+			}
+			length := min(len(keys), len(otherKeys))
+			if !slices.Equal(keys[:length], otherKeys[:length]) {
+				continue
+			}
+
+			// the same command is bound for all contexts that share a view
+			if !isCustom.Includes(binding) && !isCustom.Includes(other) && binding.Description == other.Description {
+				continue
+			}
+
+			conflicts = append(conflicts, KeybindingConflict{
+				ViewName: binding.ViewName,
+				Bindings: [2]*types.Binding{other, binding},
+			})
+			break
+		}
+	}
+
+	return conflicts, nil
+}
+
+// Returns nil for disabled keybindings
+func bindingKeySequence(binding *types.Binding) []types.Key {
+	if binding.Key == nil {
+		return nil
+	}
+
+	if keySequence, ok := binding.Key.(types.KeySequence); ok {
+		return keybindings.GetKeySequence(keySequence)
+	}
+
+	return []types.Key{binding.Key}
+}
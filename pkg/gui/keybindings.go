@@ -4,11 +4,13 @@ import (
 	"errors"
 	"log"
 
+	"github.com/jesseduffield/generics/set"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
 )
 
 func (gui *Gui) noPopupPanel(f func() error) func() error {
@@ -435,8 +437,9 @@ func (gui *Gui) GetInitialKeybindingsWithCustomCommands() ([]*types.Binding, []*
 	if err != nil {
 		log.Fatal(err)
 	}
+	pluginBindings := gui.PluginsClient.GetKeybindings()
 	// prepending because we want to give our custom keybindings precedence over default keybindings
-	bindings = append(customBindings, bindings...)
+	bindings = append(append(customBindings, pluginBindings...), bindings...)
 	return bindings, mouseBindings
 }
 
@@ -445,7 +448,26 @@ func (gui *Gui) resetKeybindings() error {
 
 	bindings, mouseBindings := gui.GetInitialKeybindingsWithCustomCommands()
 
+	keySequenceBindings := lo.Filter(bindings, func(binding *types.Binding, _ int) bool {
+		_, ok := binding.Key.(types.KeySequence)
+		return ok
+	})
+	boundKeySequencePrefixes := set.New[keySequencePrefix]()
+
 	for _, binding := range bindings {
+		if keySequence, ok := binding.Key.(types.KeySequence); ok {
+			// we bind the first key of the sequence in place of the sequence,
+			// so that it has the same precedence as the sequence would have
+			prefix := keySequencePrefix{viewName: binding.ViewName, key: keybindings.GetKeySequence(keySequence)[0]}
+			if !boundKeySequencePrefixes.Includes(prefix) {
+				boundKeySequencePrefixes.Add(prefix)
+				if err := gui.setKeySequencePrefixBinding(prefix, keySequenceBindings); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
 		if err := gui.SetKeybinding(binding); err != nil {
 			return err
 		}
@@ -499,6 +521,36 @@ func (gui *Gui) SetKeybinding(binding *types.Binding) error {
 	return gui.g.SetKeybinding(binding.ViewName, binding.Key, binding.Modifier, gui.wrappedHandler(handler))
 }
 
+// The first key of a key sequence, in the view that the sequence is bound to
+type keySequencePrefix struct {
+	viewName string
+	key      types.Key
+}
+
+// Gocui only knows about single keys, so for key sequences we bind the first
+// key to show the key sequence popup. That popup then receives the remaining
+// keys in its editor, see keySequenceEditor.
+func (gui *Gui) setKeySequencePrefixBinding(prefix keySequencePrefix, keySequenceBindings []*types.Binding) error {
+	// if the view has no sequence starting with the pressed key, gocui
+	// dispatches to the global prefix binding, so that one only needs to know
+	// about the global sequences
+	candidates := lo.Filter(keySequenceBindings, func(binding *types.Binding, _ int) bool {
+		return (binding.ViewName == prefix.viewName || binding.ViewName == "") &&
+			keybindings.GetKeySequence(binding.Key.(types.KeySequence))[0] == prefix.key
+	})
+
+	handler := func() error {
+		gui.State.Contexts.KeySequence.Start(prefix.key, candidates)
+		gui.c.Context().Push(gui.State.Contexts.KeySequence, types.OnFocusOpts{})
+		return nil
+	}
+	if prefix.viewName == "" {
+		handler = gui.noPopupPanel(handler)
+	}
+
+	return gui.g.SetKeybinding(prefix.viewName, prefix.key, gocui.ModNone, gui.wrappedHandler(handler))
+}
+
 func (gui *Gui) SetMouseKeybinding(binding *gocui.ViewMouseBinding) error {
 	return gui.g.SetViewClickBinding(binding)
 }
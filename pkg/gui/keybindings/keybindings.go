@@ -10,6 +10,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/constants"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
 )
 
 func Label(name string) string {
@@ -24,6 +25,10 @@ func LabelFromKey(key types.Key) string {
 	keyInt := 0
 
 	switch key := key.(type) {
+	case types.KeySequence:
+		return strings.Join(lo.Map(GetKeySequence(key), func(key types.Key, _ int) string {
+			return LabelFromKey(key)
+		}), " ")
 	case rune:
 		keyInt = int(key)
 	case gocui.Key:
@@ -38,6 +43,14 @@ func LabelFromKey(key types.Key) string {
 }
 
 func GetKey(key string) types.Key {
+	if keys := config.SplitKeySequence(key); len(keys) > 1 {
+		// parse the keys now so that we fail early on unrecognized ones
+		for _, key := range keys {
+			GetKey(key)
+		}
+		return types.KeySequence(strings.Join(keys, " "))
+	}
+
 	runeCount := utf8.RuneCountInString(key)
 	if key == "<disabled>" {
 		return nil
@@ -52,3 +65,11 @@ func GetKey(key string) types.Key {
 	}
 	return nil
 }
+
+// Returns the keys that have to be pressed one after the other for the given
+// key sequence
+func GetKeySequence(keySequence types.KeySequence) []types.Key {
+	return lo.Map(strings.Split(string(keySequence), " "), func(key string, _ int) types.Key {
+		return GetKey(key)
+	})
+}
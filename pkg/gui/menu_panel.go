@@ -16,6 +16,9 @@ func (gui *Gui) createMenu(opts types.CreateMenuOptions) error {
 		opts.Items = append(opts.Items, &types.MenuItem{
 			LabelColumns: []string{gui.c.Tr.Cancel},
 			OnPress: func() error {
+				if opts.OnCancel != nil {
+					opts.OnCancel()
+				}
 				return nil
 			},
 		})
@@ -59,6 +62,8 @@ func (gui *Gui) createMenu(opts types.CreateMenuOptions) error {
 	gui.State.Contexts.Menu.SetPrompt(opts.Prompt)
 	gui.State.Contexts.Menu.SetAllowFilteringKeybindings(opts.AllowFilteringKeybindings)
 	gui.State.Contexts.Menu.SetKeybindingsTakePrecedence(!opts.KeepConflictingKeybindings)
+	gui.State.Contexts.Menu.SetOnCancel(opts.OnCancel)
+	gui.State.Contexts.Menu.SetUseFuzzyFilter(opts.UseFuzzyFilter)
 	gui.State.Contexts.Menu.SetSelection(0)
 
 	gui.Views.Menu.SetOriginY(0)
@@ -79,5 +84,9 @@ func (gui *Gui) createMenu(opts types.CreateMenuOptions) error {
 
 	// TODO: ensure that if we're opened a menu from within a menu that it renders correctly
 	gui.c.Context().Push(gui.State.Contexts.Menu, types.OnFocusOpts{})
+
+	if opts.StartFiltering {
+		return gui.helpers.Search.OpenFilterPrompt(gui.State.Contexts.Menu)
+	}
 	return nil
 }
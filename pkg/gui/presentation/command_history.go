@@ -0,0 +1,52 @@
+package presentation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+func GetCommandHistoryListDisplayStrings(
+	entries []*models.CommandHistoryEntry,
+	now time.Time,
+	userConfig *config.UserConfig,
+) [][]string {
+	return lo.Map(entries, func(entry *models.CommandHistoryEntry, _ int) []string {
+		return getCommandHistoryEntryDisplayStrings(entry, now, userConfig)
+	})
+}
+
+func getCommandHistoryEntryDisplayStrings(
+	entry *models.CommandHistoryEntry,
+	now time.Time,
+	userConfig *config.UserConfig,
+) []string {
+	timeStr := utils.UnixToDateSmart(now, entry.Time.Unix(), userConfig.Gui.TimeFormat, userConfig.Gui.ShortTimeFormat)
+
+	exitCodeStr := style.FgGreen.Sprint("✓")
+	if !entry.Succeeded() {
+		exitCodeStr = style.FgRed.Sprintf("✗ %d", entry.ExitCode)
+	}
+
+	return []string{
+		style.FgBlue.Sprint(timeStr),
+		exitCodeStr,
+		style.FgMagenta.Sprint(FormatCommandDuration(entry.Duration())),
+		theme.DefaultTextColor.Sprint(entry.Command),
+	}
+}
+
+// Shows durations below a second in milliseconds, and longer ones in seconds
+// with one decimal
+func FormatCommandDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return fmt.Sprintf("%dms", duration.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", duration.Seconds())
+}
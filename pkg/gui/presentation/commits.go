@@ -28,7 +28,7 @@ type pipeSetCacheKey struct {
 }
 
 var (
-	pipeSetCache = make(map[pipeSetCacheKey][][]graph.Pipe)
+	pipeSetCache = make(map[pipeSetCacheKey]*graph.LazyPipeSets)
 	mutex        deadlock.Mutex
 )
 
@@ -92,12 +92,11 @@ func GetCommitListDisplayStrings(
 
 			if localSectionStart > 0 {
 				// we have some remote commits
-				pipeSets := loadPipesets(commits[:localSectionStart])
 				if startIdx < localSectionStart {
 					// some of the remote commits are visible
 					start := startIdx
 					end := min(endIdx, localSectionStart)
-					graphPipeSets := pipeSets[start:end]
+					graphPipeSets := loadPipesets(commits[:localSectionStart], end)[start:end]
 					graphCommits := commits[start:end]
 					graphLines := graph.RenderAux(
 						graphPipeSets,
@@ -109,12 +108,12 @@ func GetCommitListDisplayStrings(
 			}
 			if localSectionStart < len(commits) {
 				// we have some local commits
-				pipeSets := loadPipesets(commits[localSectionStart:])
 				if localSectionStart < endIdx {
 					// some of the local commits are visible
 					graphOffset := max(startIdx, localSectionStart)
 					pipeSetOffset := max(startIdx-localSectionStart, 0)
-					graphPipeSets := pipeSets[pipeSetOffset : endIdx-localSectionStart]
+					pipeSetEnd := endIdx - localSectionStart
+					graphPipeSets := loadPipesets(commits[localSectionStart:], pipeSetEnd)[pipeSetOffset:pipeSetEnd]
 					graphCommits := commits[graphOffset:endIdx]
 					graphLines := graph.RenderAux(
 						graphPipeSets,
@@ -133,9 +132,9 @@ func GetCommitListDisplayStrings(
 			// but we'll never include TODO commits as part of the graph because it'll be messy)
 			graphOffset := max(startIdx, rebaseOffset)
 
-			pipeSets := loadPipesets(commits[rebaseOffset:])
 			pipeSetOffset := max(startIdx-rebaseOffset, 0)
-			graphPipeSets := pipeSets[pipeSetOffset:max(endIdx-rebaseOffset, 0)]
+			pipeSetEnd := max(endIdx-rebaseOffset, 0)
+			graphPipeSets := loadPipesets(commits[rebaseOffset:], pipeSetEnd)[pipeSetOffset:pipeSetEnd]
 			graphCommits := commits[graphOffset:endIdx]
 			graphLines := graph.RenderAux(
 				graphPipeSets,
@@ -244,7 +243,9 @@ func indexOfFirstNonTODOCommit(commits []*models.Commit) int {
 	return 0
 }
 
-func loadPipesets(commits []*models.Commit) [][]graph.Pipe {
+// Returns the pipe sets of the first n of the given commits. The rest are only
+// computed once they are scrolled into view.
+func loadPipesets(commits []*models.Commit, n int) [][]graph.Pipe {
 	// given that our cache key is a commit hash and a commit count, it's very important that we don't actually try to render pipes
 	// when dealing with things like filtered commits.
 	cacheKey := pipeSetCacheKey{
@@ -260,11 +261,11 @@ func loadPipesets(commits []*models.Commit) [][]graph.Pipe {
 		getStyle := func(commit *models.Commit) *style.TextStyle {
 			return authors.AuthorStyle(commit.AuthorName)
 		}
-		pipeSets = graph.GetPipeSets(commits, getStyle)
+		pipeSets = graph.NewLazyPipeSets(commits, getStyle)
 		pipeSetCache[cacheKey] = pipeSets
 	}
 
-	return pipeSets
+	return pipeSets.Get(n)
 }
 
 // similar to the git_commands.BisectStatus but more gui-focused
@@ -49,6 +49,27 @@ func RenderCommitFileTree(
 	})
 }
 
+// Renders the tree of a file picker. Selected files are marked the same way as
+// files that are included in the custom patch.
+func RenderFilePickerTree(
+	tree *filetree.CommitFileTreeViewModel,
+	isSelected func(path string) bool,
+	showFileIcons bool,
+	customIconsConfig *config.CustomIconsConfig,
+) []string {
+	collapsedPaths := tree.CollapsedPaths()
+	return renderAux(tree.GetRoot().Raw(), collapsedPaths, -1, -1, func(node *filetree.Node[models.CommitFile], treeDepth int, visualDepth int, isCollapsed bool) string {
+		status := patch.PART
+		if node.EveryFile(func(file *models.CommitFile) bool { return isSelected(file.Path) }) {
+			status = patch.WHOLE
+		} else if node.EveryFile(func(file *models.CommitFile) bool { return !isSelected(file.Path) }) {
+			status = patch.UNSELECTED
+		}
+
+		return getCommitFileLine(isCollapsed, treeDepth, visualDepth, node, status, showFileIcons, customIconsConfig)
+	})
+}
+
 // Returns the status of a commit file in terms of its inclusion in the custom patch
 func commitFilePatchStatus(node *filetree.Node[models.CommitFile], tree *filetree.CommitFileTreeViewModel, patchBuilder *patch.PatchBuilder) patch.PatchStatus {
 	// This is a little convoluted because we're dealing with either a leaf or a non-leaf.
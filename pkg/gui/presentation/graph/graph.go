@@ -57,16 +57,44 @@ func RenderCommitGraph(commits []*models.Commit, selectedCommitHashPtr *string,
 }
 
 func GetPipeSets(commits []*models.Commit, getStyle func(c *models.Commit) *style.TextStyle) [][]Pipe {
-	if len(commits) == 0 {
+	return NewLazyPipeSets(commits, getStyle).Get(len(commits))
+}
+
+// LazyPipeSets computes the pipe sets of a list of commits only as far down as
+// they are needed. Each pipe set depends on the one before it, so rendering the
+// first screen of a log with millions of commits only needs the first few.
+type LazyPipeSets struct {
+	commits  []*models.Commit
+	getStyle func(c *models.Commit) *style.TextStyle
+	pipeSets [][]Pipe
+}
+
+func NewLazyPipeSets(commits []*models.Commit, getStyle func(c *models.Commit) *style.TextStyle) *LazyPipeSets {
+	return &LazyPipeSets{
+		commits:  commits,
+		getStyle: getStyle,
+	}
+}
+
+// Returns the pipe sets of the first n commits, computing the ones that
+// haven't been computed yet. Not safe for concurrent use.
+func (self *LazyPipeSets) Get(n int) [][]Pipe {
+	n = min(n, len(self.commits))
+	if n <= 0 {
 		return nil
 	}
 
-	pipes := []Pipe{{fromPos: 0, toPos: 0, fromHash: &StartCommitHash, toHash: commits[0].HashPtr(), kind: STARTS, style: &style.FgDefault}}
+	for i := len(self.pipeSets); i < n; i++ {
+		var pipes []Pipe
+		if i == 0 {
+			pipes = []Pipe{{fromPos: 0, toPos: 0, fromHash: &StartCommitHash, toHash: self.commits[0].HashPtr(), kind: STARTS, style: &style.FgDefault}}
+		} else {
+			pipes = self.pipeSets[i-1]
+		}
+		self.pipeSets = append(self.pipeSets, getNextPipes(pipes, self.commits[i], self.getStyle))
+	}
 
-	return lo.Map(commits, func(commit *models.Commit, _ int) []Pipe {
-		pipes = getNextPipes(pipes, commit, getStyle)
-		return pipes
-	})
+	return self.pipeSets[:n:n]
 }
 
 func RenderAux(pipeSets [][]Pipe, commits []*models.Commit, selectedCommitHashPtr *string) []string {
@@ -550,6 +550,21 @@ func TestGetNextPipes(t *testing.T) {
 	}
 }
 
+func TestLazyPipeSets(t *testing.T) {
+	hashPool := &utils.StringPool{}
+	commits := generateCommits(hashPool, 50)
+	getStyle := func(commit *models.Commit) *style.TextStyle {
+		return &style.FgDefault
+	}
+	expected := GetPipeSets(commits, getStyle)
+
+	lazyPipeSets := NewLazyPipeSets(commits, getStyle)
+	assert.Nil(t, lazyPipeSets.Get(0))
+	for _, n := range []int{10, 5, 30, 50, 60} {
+		assert.Equal(t, expected[:min(n, len(commits))], lazyPipeSets.Get(n), "n = %d", n)
+	}
+}
+
 func BenchmarkRenderCommitGraph(b *testing.B) {
 	oldColorLevel := color.ForceSetColorLevel(terminfo.ColorLevelMillions)
 	defer color.ForceSetColorLevel(oldColorLevel)
@@ -0,0 +1,24 @@
+package presentation
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/samber/lo"
+)
+
+func GetHookListDisplayStrings(hooks []*models.Hook, tr *i18n.TranslationSet) [][]string {
+	return lo.Map(hooks, func(hook *models.Hook, _ int) []string {
+		return getHookDisplayStrings(hook, tr)
+	})
+}
+
+func getHookDisplayStrings(hook *models.Hook, tr *i18n.TranslationSet) []string {
+	status := style.FgGreen.Sprint("✓ " + tr.HookEnabled)
+	if !hook.Enabled {
+		status = style.FgRed.Sprint("✗ " + tr.HookDisabled)
+	}
+
+	return []string{status, theme.DefaultTextColor.Sprint(hook.Name)}
+}
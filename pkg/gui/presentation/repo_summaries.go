@@ -0,0 +1,104 @@
+package presentation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/style"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+func GetRepoSummaryListDisplayStrings(
+	summaries []*models.RepoSummary,
+	currentRepoPath string,
+	getItemOperation func(item types.HasUrn) types.ItemOperation,
+	tr *i18n.TranslationSet,
+	now time.Time,
+	userConfig *config.UserConfig,
+) [][]string {
+	return lo.Map(summaries, func(summary *models.RepoSummary, _ int) []string {
+		return getRepoSummaryDisplayStrings(summary, summary.Path == currentRepoPath, getItemOperation(summary), tr, now, userConfig)
+	})
+}
+
+func getRepoSummaryDisplayStrings(
+	summary *models.RepoSummary,
+	isCurrent bool,
+	itemOperation types.ItemOperation,
+	tr *i18n.TranslationSet,
+	now time.Time,
+	userConfig *config.UserConfig,
+) []string {
+	nameStyle := theme.DefaultTextColor
+	if isCurrent {
+		nameStyle = style.FgGreen
+	}
+
+	// all rows need the same number of columns
+	var head, status, lastCommit string
+	switch {
+	case !summary.Loaded:
+		head = style.FgCyan.Sprint(tr.LoadingRepoSummary)
+	case summary.Err != nil:
+		head = style.FgRed.Sprint(firstLine(summary.Err.Error()))
+	default:
+		head, status, lastCommit = repoHeadStr(summary), repoStatusStr(summary, tr), repoLastCommitStr(summary)
+	}
+
+	itemOperationStr := ItemOperationToString(itemOperation, tr)
+	if itemOperationStr != "" {
+		itemOperationStr = style.FgCyan.Sprintf("%s %s", itemOperationStr, Loader(now, userConfig.Gui.Spinner))
+	}
+
+	return []string{nameStyle.Sprint(summary.Name()), head, status, lastCommit, itemOperationStr}
+}
+
+func repoHeadStr(summary *models.RepoSummary) string {
+	if summary.Branch != "" {
+		return style.FgCyan.Sprint(summary.Branch)
+	}
+	return style.FgYellow.Sprint(summary.Head)
+}
+
+// Combines the divergence from the upstream, changed files and stashes into a
+// single column, so that the ones that need attention stand out
+func repoStatusStr(summary *models.RepoSummary, tr *i18n.TranslationSet) string {
+	parts := []string{}
+	if summary.HasUpstream {
+		switch {
+		case summary.Ahead == 0 && summary.Behind == 0:
+			parts = append(parts, style.FgGreen.Sprint("✓"))
+		case summary.Ahead > 0 && summary.Behind > 0:
+			parts = append(parts, style.FgYellow.Sprintf("↓%d↑%d", summary.Behind, summary.Ahead))
+		case summary.Behind > 0:
+			parts = append(parts, style.FgYellow.Sprintf("↓%d", summary.Behind))
+		default:
+			parts = append(parts, style.FgYellow.Sprintf("↑%d", summary.Ahead))
+		}
+	}
+	if summary.IsDirty() {
+		parts = append(parts, style.FgRed.Sprintf(tr.RepoSummaryChangedFiles, summary.ChangedFiles))
+	}
+	if summary.StashCount > 0 {
+		parts = append(parts, style.FgMagenta.Sprintf(tr.RepoSummaryStashEntries, summary.StashCount))
+	}
+	return strings.Join(parts, " ")
+}
+
+func repoLastCommitStr(summary *models.RepoSummary) string {
+	if summary.LastCommitUnixTimestamp == 0 {
+		return ""
+	}
+	return style.FgBlue.Sprint(utils.UnixToTimeAgo(summary.LastCommitUnixTimestamp))
+}
+
+func firstLine(str string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(str), "\n")
+	return line
+}
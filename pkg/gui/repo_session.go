@@ -0,0 +1,92 @@
+package gui
+
+import (
+	appTypes "github.com/jesseduffield/lazygit/pkg/app/types"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Returns the session that was saved when the current repo was last left, or
+// nil if there is none
+func (gui *Gui) getRepoSession() *config.RepoSession {
+	return gui.c.GetAppState().RepoSessions[gui.git.RepoPaths.WorktreePath()]
+}
+
+// Remembers the state of the gui for the current repo so that it can be
+// restored when the repo is opened again, possibly in a later run of the app
+func (gui *Gui) saveRepoSession() {
+	if gui.git.Status.IsBareRepo() {
+		// bare repos don't make it into the recent repos list either
+		return
+	}
+
+	session := &config.RepoSession{
+		CollapsedPaths: gui.State.Contexts.Files.CollapsedPaths().ToSlice(),
+		FilterPath:     gui.State.Modes.Filtering.GetPath(),
+		FilterAuthor:   gui.State.Modes.Filtering.GetAuthor(),
+		ScreenMode:     screenModeArg(gui.State.ScreenMode),
+	}
+
+	if context := gui.State.ContextMgr.CurrentSide(); context != nil && !context.IsTransient() {
+		session.Context = string(context.GetKey())
+	}
+	if branch := gui.State.Contexts.Branches.GetSelected(); branch != nil {
+		session.SelectedBranch = branch.Name
+	}
+	if commit := gui.State.Contexts.LocalCommits.GetSelected(); commit != nil {
+		session.SelectedCommitHash = commit.Hash()
+	}
+	session.DiffContextSize = gui.State.DiffContextSize
+
+	appState := gui.c.GetAppState()
+	worktreePath := gui.git.RepoPaths.WorktreePath()
+	// only keep the sessions of repos that we still know about
+	sessions := map[string]*config.RepoSession{worktreePath: session}
+	for path, otherSession := range appState.RepoSessions {
+		if lo.Contains(appState.RecentRepos, path) && path != worktreePath {
+			sessions[path] = otherSession
+		}
+	}
+	appState.RepoSessions = sessions
+
+	if err := gui.c.SaveAppState(); err != nil {
+		gui.c.Log.Error(err)
+	}
+}
+
+// Applies the given session to the freshly created repo state. Start arguments
+// take precedence over the session. Returns the context to focus, or nil if
+// the default should be used.
+func (gui *Gui) restoreRepoSession(session *config.RepoSession, startArgs appTypes.StartArgs) types.Context {
+	contextTree := gui.State.Contexts
+
+	for _, path := range session.CollapsedPaths {
+		contextTree.Files.CollapsedPaths().Collapse(path)
+	}
+
+	gui.State.BranchToSelect = session.SelectedBranch
+	gui.State.CommitHashToSelect = session.SelectedCommitHash
+	gui.State.DiffContextSize = session.DiffContextSize
+
+	if startArgs.FilterPath != "" || startArgs.GitArg != appTypes.GitArgNone {
+		return nil
+	}
+
+	gui.State.Modes.Filtering = filtering.New(session.FilterPath, session.FilterAuthor)
+	if startArgs.ScreenMode == "" && session.ScreenMode != "" {
+		gui.State.ScreenMode = parseScreenModeArg(session.ScreenMode)
+	}
+
+	sideWindows := gui.UserConfig().Gui.SideWindowLayout.WindowNames()
+	context, ok := lo.Find(contextTree.Flatten(), func(context types.Context) bool {
+		return string(context.GetKey()) == session.Context
+	})
+	if !ok || context.GetKind() != types.SIDE_CONTEXT || context.IsTransient() ||
+		!lo.Contains(sideWindows, context.GetWindowName()) {
+		return nil
+	}
+
+	return context
+}
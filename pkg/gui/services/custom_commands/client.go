@@ -1,6 +1,9 @@
 package custom_commands
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
@@ -55,7 +58,8 @@ func (self *Client) GetCustomCommandKeybindings() ([]*types.Binding, error) {
 			})
 		} else {
 			handler := self.handlerCreator.call(customCommand)
-			compoundBindings, err := self.keybindingCreator.call(customCommand, handler)
+			getDisabledReason := self.handlerCreator.getDisabledReasonFn(customCommand)
+			compoundBindings, err := self.keybindingCreator.call(customCommand, handler, getDisabledReason)
 			if err != nil {
 				return nil, err
 			}
@@ -93,10 +97,16 @@ func (self *Client) showCustomCommandsMenu(customCommand config.CustomCommand) e
 				}
 			}
 
+			var disabledReason *types.DisabledReason
+			if getDisabledReason := self.handlerCreator.getDisabledReasonFn(subCommand); getDisabledReason != nil {
+				disabledReason = getDisabledReason()
+			}
+
 			menuItems = append(menuItems, &types.MenuItem{
-				Label:   subCommand.GetDescription(),
-				Key:     keybindings.GetKey(subCommand.Key),
-				OnPress: self.handlerCreator.call(subCommand),
+				Label:          subCommand.GetDescription(),
+				Key:            keybindings.GetKey(subCommand.Key),
+				OnPress:        self.handlerCreator.call(subCommand),
+				DisabledReason: disabledReason,
 			})
 		}
 	}
@@ -119,3 +129,37 @@ func getCustomCommandsMenuDescription(customCommand config.CustomCommand, tr *i1
 
 	return tr.CustomCommands
 }
+
+// Runs the custom command whose description is the given name, looking inside
+// command menus too. Unlike with the keybinding, the command's context is not
+// checked, so it runs against whatever is selected in that context. Its
+// condition is checked though, and if it isn't met, the disabled reason is
+// returned as an error.
+func (self *Client) RunByName(name string) error {
+	customCommand, ok := findCustomCommandByName(self.c.UserConfig().CustomCommands, name)
+	if !ok {
+		return fmt.Errorf("no custom command named '%s'", name)
+	}
+
+	if getDisabledReason := self.handlerCreator.getDisabledReasonFn(customCommand); getDisabledReason != nil {
+		if disabledReason := getDisabledReason(); disabledReason != nil {
+			return errors.New(disabledReason.Text)
+		}
+	}
+
+	return self.handlerCreator.call(customCommand)()
+}
+
+func findCustomCommandByName(customCommands []config.CustomCommand, name string) (config.CustomCommand, bool) {
+	for _, customCommand := range customCommands {
+		if len(customCommand.CommandMenu) > 0 {
+			if found, ok := findCustomCommandByName(customCommand.CommandMenu, name); ok {
+				return found, true
+			}
+		} else if customCommand.GetDescription() == name {
+			return customCommand, true
+		}
+	}
+
+	return config.CustomCommand{}, false
+}
@@ -3,11 +3,14 @@ package custom_commands
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"text/template"
 
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
 	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
 	"github.com/jesseduffield/lazygit/pkg/gui/style"
@@ -47,11 +50,18 @@ func NewHandlerCreator(
 
 func (self *HandlerCreator) call(customCommand config.CustomCommand) func() error {
 	return func() error {
-		sessionState := self.sessionStateLoader.call()
-		promptResponses := make([]string, len(customCommand.Prompts))
-		form := make(map[string]string)
-
-		f := func() error { return self.finalHandler(customCommand, sessionState, promptResponses, form) }
+		sessionState := self.sessionStateLoader.Load()
+		promptResponses := make([]any, len(customCommand.Prompts))
+		form := make(map[string]any)
+		steps := make(map[string]*StepResult)
+		resolveTemplate := self.getResolveTemplateFn(form, promptResponses, steps, sessionState)
+
+		f := func() error {
+			if len(customCommand.Steps) > 0 {
+				return self.runSteps(customCommand, resolveTemplate, form, steps)
+			}
+			return self.finalHandler(customCommand, resolveTemplate)
+		}
 
 		// if we have prompts we'll recursively wrap our confirm handlers with more prompts
 		// until we reach the actual command
@@ -63,49 +73,18 @@ func (self *HandlerCreator) call(customCommand config.CustomCommand) func() erro
 			// going backwards so the outermost prompt is the first one
 			prompt := customCommand.Prompts[idx]
 
-			wrappedF := func(response string) error {
-				promptResponses[idx] = response
-				form[prompt.Key] = response
-				return g()
+			if !lo.Contains(promptTypes, prompt.Type) {
+				return errors.New("custom command prompt must have a type of 'input', 'menu', 'menuFromCommand', 'multiSelect', 'multiSelectFromCommand', 'filePicker', or 'confirm'")
 			}
 
-			resolveTemplate := self.getResolveTemplateFn(form, promptResponses, sessionState)
-
-			switch prompt.Type {
-			case "input":
-				f = func() error {
-					resolvedPrompt, err := self.resolver.resolvePrompt(&prompt, resolveTemplate)
-					if err != nil {
-						return err
-					}
-					return self.inputPrompt(resolvedPrompt, wrappedF)
-				}
-			case "menu":
-				f = func() error {
-					resolvedPrompt, err := self.resolver.resolvePrompt(&prompt, resolveTemplate)
-					if err != nil {
-						return err
+			f = func() error {
+				return self.showPrompt(&prompt, resolveTemplate, func(response any) error {
+					if prompt.Type != "confirm" {
+						promptResponses[idx] = response
+						form[prompt.Key] = response
 					}
-					return self.menuPrompt(resolvedPrompt, wrappedF)
-				}
-			case "menuFromCommand":
-				f = func() error {
-					resolvedPrompt, err := self.resolver.resolvePrompt(&prompt, resolveTemplate)
-					if err != nil {
-						return err
-					}
-					return self.menuPromptFromCommand(resolvedPrompt, wrappedF)
-				}
-			case "confirm":
-				f = func() error {
-					resolvedPrompt, err := self.resolver.resolvePrompt(&prompt, resolveTemplate)
-					if err != nil {
-						return err
-					}
-					return self.confirmPrompt(resolvedPrompt, g)
-				}
-			default:
-				return errors.New("custom command prompt must have a type of 'input', 'menu', 'menuFromCommand', or 'confirm'")
+					return g()
+				})
 			}
 		}
 
@@ -113,6 +92,43 @@ func (self *HandlerCreator) call(customCommand config.CustomCommand) func() erro
 	}
 }
 
+var promptTypes = []string{"input", "menu", "menuFromCommand", "multiSelect", "multiSelectFromCommand", "filePicker", "confirm"}
+
+// Shows a prompt and calls handleResponse with the user's response: a string,
+// a list of strings for multi-select prompts (and file pickers that allow
+// picking several files), or nil for confirm prompts
+func (self *HandlerCreator) showPrompt(prompt *config.CustomCommandPrompt, resolveTemplate func(string) (string, error), handleResponse func(any) error) error {
+	resolvedPrompt, err := self.resolver.resolvePrompt(prompt, resolveTemplate)
+	if err != nil {
+		return err
+	}
+
+	handleSingle := func(response string) error { return handleResponse(response) }
+	handleMulti := func(responses []string) error { return handleResponse(responses) }
+
+	switch prompt.Type {
+	case "input":
+		return self.inputPrompt(resolvedPrompt, handleSingle)
+	case "menu":
+		return self.menuPrompt(resolvedPrompt, handleSingle)
+	case "menuFromCommand":
+		return self.menuPromptFromCommand(resolvedPrompt, handleSingle)
+	case "multiSelect":
+		return self.multiSelectPrompt(resolvedPrompt, handleMulti)
+	case "multiSelectFromCommand":
+		return self.multiSelectPromptFromCommand(resolvedPrompt, handleMulti)
+	case "filePicker":
+		if resolvedPrompt.AllowMultiple {
+			return self.filePickerPrompt(resolvedPrompt, handleMulti)
+		}
+		return self.filePickerPrompt(resolvedPrompt, func(paths []string) error { return handleSingle(paths[0]) })
+	case "confirm":
+		return self.confirmPrompt(resolvedPrompt, func() error { return handleResponse(nil) })
+	default:
+		return errors.New("custom command prompt must have a type of 'input', 'menu', 'menuFromCommand', 'multiSelect', 'multiSelectFromCommand', 'filePicker', or 'confirm'")
+	}
+}
+
 func (self *HandlerCreator) inputPrompt(prompt *config.CustomCommandPrompt, wrappedF func(string) error) error {
 	findSuggestionsFn, err := self.generateFindSuggestionsFunc(prompt)
 	if err != nil {
@@ -236,29 +252,208 @@ func (self *HandlerCreator) menuPromptFromCommand(prompt *config.CustomCommandPr
 	return self.c.Menu(types.CreateMenuOptions{Title: prompt.Title, Items: menuItems})
 }
 
+// An item of a multi-select prompt; a common representation for the options of
+// multiSelect and multiSelectFromCommand prompts
+type multiSelectItem struct {
+	labelColumns []string
+	value        string
+	key          types.Key
+}
+
+func (self *HandlerCreator) multiSelectPrompt(prompt *config.CustomCommandPrompt, wrappedF func([]string) error) error {
+	items := lo.Map(prompt.Options, func(option config.CustomCommandMenuOption, _ int) multiSelectItem {
+		return multiSelectItem{
+			labelColumns: []string{option.Name, style.FgYellow.Sprint(option.Description)},
+			value:        option.Value,
+			key:          keybindings.GetKey(option.Key),
+		}
+	})
+
+	return self.showMultiSelectMenu(prompt.Title, items, wrappedF)
+}
+
+func (self *HandlerCreator) multiSelectPromptFromCommand(prompt *config.CustomCommandPrompt, wrappedF func([]string) error) error {
+	message, err := self.c.Git().Custom.RunWithOutput(prompt.Command)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := self.menuGenerator.call(message, prompt.Filter, prompt.ValueFormat, prompt.LabelFormat)
+	if err != nil {
+		return err
+	}
+
+	items := lo.Map(candidates, func(candidate *commandMenuItem, _ int) multiSelectItem {
+		return multiSelectItem{
+			labelColumns: []string{candidate.label},
+			value:        candidate.value,
+		}
+	})
+
+	return self.showMultiSelectMenu(prompt.Title, items, wrappedF)
+}
+
+// Shows a menu in which each item can be toggled on and off, plus an item at
+// the top for confirming the selection. The selected values are passed to
+// wrappedF in the order in which they appear in the menu.
+func (self *HandlerCreator) showMultiSelectMenu(title string, items []multiSelectItem, wrappedF func([]string) error) error {
+	selected := make([]bool, len(items))
+
+	menuItems := []*types.MenuItem{
+		{
+			LabelColumns: []string{self.c.Tr.ConfirmSelection},
+			OnPress: func() error {
+				values := lo.FilterMap(items, func(item multiSelectItem, i int) (string, bool) {
+					return item.value, selected[i]
+				})
+				return wrappedF(values)
+			},
+		},
+	}
+
+	for i, item := range items {
+		menuItem := &types.MenuItem{
+			LabelColumns: item.labelColumns,
+			Widget:       types.MakeMenuCheckBox(false),
+			Key:          item.key,
+			KeepOpen:     true,
+		}
+		menuItem.OnPress = func() error {
+			selected[i] = !selected[i]
+			menuItem.Widget = types.MakeMenuCheckBox(selected[i])
+			return nil
+		}
+		menuItems = append(menuItems, menuItem)
+	}
+
+	return self.c.Menu(types.CreateMenuOptions{Title: title, Items: menuItems})
+}
+
+func (self *HandlerCreator) filePickerPrompt(prompt *config.CustomCommandPrompt, wrappedF func([]string) error) error {
+	var paths []string
+	var err error
+	if prompt.Ref != "" {
+		paths, err = self.c.Git().WorkingTree.AllFilesAtRef(prompt.Ref)
+	} else {
+		paths, err = self.c.Git().WorkingTree.AllRepoFiles()
+	}
+	if err != nil {
+		return err
+	}
+
+	filePicker := self.c.Contexts().FilePicker
+	filePicker.ReInit(context.FilePickerOpts{
+		Title:         prompt.Title,
+		Paths:         paths,
+		MultiSelect:   prompt.AllowMultiple,
+		HandleConfirm: wrappedF,
+	})
+	self.c.PostRefreshUpdate(filePicker)
+	self.c.Context().Push(filePicker, types.OnFocusOpts{})
+	return nil
+}
+
 type CustomCommandObjects struct {
 	*SessionState
-	PromptResponses []string
-	Form            map[string]string
+	PromptResponses []any
+	Form            map[string]any
+	Steps           map[string]*StepResult
+}
+
+// The result of a step of a custom command with steps, available to later
+// steps by the step's key
+type StepResult struct {
+	Output string
 }
 
-func (self *HandlerCreator) getResolveTemplateFn(form map[string]string, promptResponses []string, sessionState *SessionState) func(string) (string, error) {
+func (self *HandlerCreator) getResolveTemplateFn(form map[string]any, promptResponses []any, steps map[string]*StepResult, sessionState *SessionState) func(string) (string, error) {
 	objects := CustomCommandObjects{
 		SessionState:    sessionState,
 		PromptResponses: promptResponses,
 		Form:            form,
+		Steps:           steps,
 	}
 
 	funcs := template.FuncMap{
 		"quote":      self.c.OS().Quote,
 		"runCommand": self.c.Git().Custom.TemplateFunctionRunCommand,
+		"join":       joinTemplateFunction,
+		"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+		"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+		"regexMatch": regexp.MatchString,
+		"first":      firstTemplateFunction,
+		"last":       lastTemplateFunction,
 	}
 
 	return func(templateStr string) (string, error) { return utils.ResolveTemplate(templateStr, objects, funcs) }
 }
 
-func (self *HandlerCreator) finalHandler(customCommand config.CustomCommand, sessionState *SessionState, promptResponses []string, form map[string]string) error {
-	resolveTemplate := self.getResolveTemplateFn(form, promptResponses, sessionState)
+// Joins the values of a multi-select prompt. The separator comes first so that
+// it can be used in a pipeline, e.g. `{{.Form.Files | join " "}}`
+func joinTemplateFunction(separator string, values []string) string {
+	return strings.Join(values, separator)
+}
+
+// Returns the first element of a list such as .SelectedCommits, or nil if the
+// list is empty
+func firstTemplateFunction(list any) (any, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("first: expected a list, got %T", list)
+	}
+	if value.Len() == 0 {
+		return nil, nil
+	}
+	return value.Index(0).Interface(), nil
+}
+
+// Returns the last element of a list such as .SelectedCommits, or nil if the
+// list is empty
+func lastTemplateFunction(list any) (any, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("last: expected a list, got %T", list)
+	}
+	if value.Len() == 0 {
+		return nil, nil
+	}
+	return value.Index(value.Len() - 1).Interface(), nil
+}
+
+// Returns a function that evaluates the command's condition against the current
+// session state, or nil if the command doesn't have a condition. The command is
+// disabled when the condition renders to an empty string or to "false".
+func (self *HandlerCreator) getDisabledReasonFn(customCommand config.CustomCommand) func() *types.DisabledReason {
+	if customCommand.Condition == "" {
+		return nil
+	}
+
+	return func() *types.DisabledReason {
+		resolveTemplate := self.getResolveTemplateFn(map[string]any{}, []any{}, map[string]*StepResult{}, self.sessionStateLoader.Load())
+		result, err := resolveTemplate(customCommand.Condition)
+		if err != nil {
+			return &types.DisabledReason{Text: err.Error(), ShowErrorInPanel: true}
+		}
+
+		result = strings.TrimSpace(result)
+		if result != "" && result != "false" {
+			return nil
+		}
+
+		if customCommand.DisabledReason == "" {
+			return &types.DisabledReason{Text: self.c.Tr.CustomCommandConditionNotMet}
+		}
+
+		text, err := resolveTemplate(customCommand.DisabledReason)
+		if err != nil {
+			return &types.DisabledReason{Text: err.Error(), ShowErrorInPanel: true}
+		}
+		return &types.DisabledReason{Text: text}
+	}
+}
+
+func (self *HandlerCreator) finalHandler(customCommand config.CustomCommand, resolveTemplate func(string) (string, error)) error {
 	cmdStr, err := resolveTemplate(customCommand.Command)
 	if err != nil {
 		return err
@@ -24,7 +24,7 @@ func NewKeybindingCreator(c *helpers.HelperCommon) *KeybindingCreator {
 	}
 }
 
-func (self *KeybindingCreator) call(customCommand config.CustomCommand, handler func() error) ([]*types.Binding, error) {
+func (self *KeybindingCreator) call(customCommand config.CustomCommand, handler func() error, getDisabledReason func() *types.DisabledReason) ([]*types.Binding, error) {
 	if customCommand.Context == "" {
 		return nil, formatContextNotProvidedError(customCommand)
 	}
@@ -36,11 +36,12 @@ func (self *KeybindingCreator) call(customCommand config.CustomCommand, handler
 
 	return lo.Map(viewNames, func(viewName string, _ int) *types.Binding {
 		return &types.Binding{
-			ViewName:    viewName,
-			Key:         keybindings.GetKey(customCommand.Key),
-			Modifier:    gocui.ModNone,
-			Handler:     handler,
-			Description: customCommand.GetDescription(),
+			ViewName:          viewName,
+			Key:               keybindings.GetKey(customCommand.Key),
+			Modifier:          gocui.ModNone,
+			Handler:           handler,
+			Description:       customCommand.GetDescription(),
+			GetDisabledReason: getDisabledReason,
 		}
 	}), nil
 }
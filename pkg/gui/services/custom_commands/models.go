@@ -104,3 +104,16 @@ type Worktree struct {
 	Branch        string
 	Name          string
 }
+
+type WorkingTreeState struct {
+	Rebasing      bool
+	Merging       bool
+	CherryPicking bool
+	Reverting     bool
+}
+
+type CustomPanelItem struct {
+	Label  string
+	Value  string
+	Fields map[string]string
+}
@@ -20,8 +20,9 @@ func (self *Resolver) resolvePrompt(
 ) (*config.CustomCommandPrompt, error) {
 	var err error
 	result := &config.CustomCommandPrompt{
-		ValueFormat: prompt.ValueFormat,
-		LabelFormat: prompt.LabelFormat,
+		ValueFormat:   prompt.ValueFormat,
+		LabelFormat:   prompt.LabelFormat,
+		AllowMultiple: prompt.AllowMultiple,
 	}
 
 	result.Title, err = resolveTemplate(prompt.Title)
@@ -59,7 +60,12 @@ func (self *Resolver) resolvePrompt(
 		return nil, err
 	}
 
-	if prompt.Type == "menu" {
+	result.Ref, err = resolveTemplate(prompt.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if prompt.Type == "menu" || prompt.Type == "multiSelect" {
 		result.Options, err = self.resolveMenuOptions(prompt, resolveTemplate)
 		if err != nil {
 			return nil, err
@@ -2,7 +2,9 @@ package custom_commands
 
 import (
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/samber/lo"
 )
 
@@ -174,6 +176,27 @@ func worktreeShimFromModelRemote(worktree *models.Worktree) *Worktree {
 	}
 }
 
+func workingTreeStateShimFromModel(state models.WorkingTreeState) *WorkingTreeState {
+	return &WorkingTreeState{
+		Rebasing:      state.Rebasing,
+		Merging:       state.Merging,
+		CherryPicking: state.CherryPicking,
+		Reverting:     state.Reverting,
+	}
+}
+
+func customPanelItemShimFromModel(item *models.CustomPanelItem) *CustomPanelItem {
+	if item == nil {
+		return nil
+	}
+
+	return &CustomPanelItem{
+		Label:  item.Label,
+		Value:  item.Value,
+		Fields: item.Fields,
+	}
+}
+
 type CommitRange struct {
 	From string
 	To   string
@@ -190,6 +213,13 @@ func makeCommitRange(commits []*models.Commit, _ int, _ int) *CommitRange {
 	}
 }
 
+// Converts the selected items of a list context (a single item, or all items of
+// a range selection) to their shims, in the order in which they are displayed
+func selectedItemShims[T any, S any](list interface{ GetSelectedItems() ([]T, int, int) }, shim func(T) *S) []*S {
+	items, _, _ := list.GetSelectedItems()
+	return lo.Map(items, func(item T, _ int) *S { return shim(item) })
+}
+
 // SessionState captures the current state of the application for use in custom commands
 type SessionState struct {
 	SelectedLocalCommit    *Commit // deprecated, use SelectedCommit
@@ -209,9 +239,31 @@ type SessionState struct {
 	SelectedCommitFilePath string
 	SelectedWorktree       *Worktree
 	CheckedOutBranch       *Branch
+	WorkingTreeState       *WorkingTreeState
+
+	// Only set when a custom panel is focused
+	SelectedCustomItem *CustomPanelItem
+
+	// All selected items when there's a range selection, or just the selected
+	// item otherwise
+	SelectedCommits         []*Commit
+	SelectedFiles           []*File
+	SelectedPaths           []string
+	SelectedSubmodules      []*Submodule
+	SelectedLocalBranches   []*Branch
+	SelectedRemoteBranches  []*RemoteBranch
+	SelectedRemotes         []*Remote
+	SelectedTags            []*Tag
+	SelectedStashEntries    []*StashEntry
+	SelectedCommitFiles     []*CommitFile
+	SelectedCommitFilePaths []string
+	SelectedWorktrees       []*Worktree
+	SelectedCustomItems     []*CustomPanelItem
 }
 
-func (self *SessionStateLoader) call() *SessionState {
+// Load returns the session state as it is right now. Besides custom commands,
+// plugins use this to query lazygit's state.
+func (self *SessionStateLoader) Load() *SessionState {
 	selectedLocalCommit := commitShimFromModelCommit(self.c.Contexts().LocalCommits.GetSelected())
 	selectedLocalCommitRange := makeCommitRange(self.c.Contexts().LocalCommits.GetSelectedItems())
 	selectedReflogCommit := commitShimFromModelCommit(self.c.Contexts().ReflogCommits.GetSelected())
@@ -221,19 +273,54 @@ func (self *SessionStateLoader) call() *SessionState {
 
 	selectedCommit := selectedLocalCommit
 	selectedCommitRange := selectedLocalCommitRange
+	selectedCommits := selectedItemShims(self.c.Contexts().LocalCommits, commitShimFromModelCommit)
 	if self.c.Context().IsCurrentOrParent(self.c.Contexts().ReflogCommits) {
 		selectedCommit = selectedReflogCommit
 		selectedCommitRange = selectedReflogCommitRange
+		selectedCommits = selectedItemShims(self.c.Contexts().ReflogCommits, commitShimFromModelCommit)
 	} else if self.c.Context().IsCurrentOrParent(self.c.Contexts().SubCommits) {
 		selectedCommit = selectedSubCommit
 		selectedCommitRange = selectedSubCommitRange
+		selectedCommits = selectedItemShims(self.c.Contexts().SubCommits, commitShimFromModelCommit)
 	}
 
 	selectedPath := self.c.Contexts().Files.GetSelectedPath()
 	selectedCommitFilePath := self.c.Contexts().CommitFiles.GetSelectedPath()
 
+	// the file trees return nodes wrapping nil when they haven't been loaded yet
+	selectedFileNodes, _, _ := self.c.Contexts().Files.GetSelectedItems()
+	selectedFileNodes = lo.Filter(selectedFileNodes, func(node *filetree.FileNode, _ int) bool { return node.Raw() != nil })
+	selectedPaths := lo.Map(selectedFileNodes, func(node *filetree.FileNode, _ int) string { return node.GetPath() })
+	// for directories, we include all the files inside them
+	selectedFiles := lo.UniqBy(
+		lo.FlatMap(selectedFileNodes, func(node *filetree.FileNode, _ int) []*File {
+			return lo.Map(node.GetLeaves(), func(leaf *filetree.Node[models.File], _ int) *File { return fileShimFromModelFile(leaf.File) })
+		}),
+		func(file *File) string { return file.Name },
+	)
+
+	selectedCommitFileNodes, _, _ := self.c.Contexts().CommitFiles.GetSelectedItems()
+	selectedCommitFileNodes = lo.Filter(selectedCommitFileNodes, func(node *filetree.CommitFileNode, _ int) bool { return node.Raw() != nil })
+	selectedCommitFilePaths := lo.Map(selectedCommitFileNodes, func(node *filetree.CommitFileNode, _ int) string { return node.GetPath() })
+	selectedCommitFiles := lo.UniqBy(
+		lo.FlatMap(selectedCommitFileNodes, func(node *filetree.CommitFileNode, _ int) []*CommitFile {
+			return lo.Map(node.GetLeaves(), func(leaf *filetree.Node[models.CommitFile], _ int) *CommitFile {
+				return commitFileShimFromModelRemote(leaf.File)
+			})
+		}),
+		func(file *CommitFile) string { return file.Name },
+	)
+
 	if self.c.Context().IsCurrent(self.c.Contexts().CommitFiles) {
 		selectedPath = selectedCommitFilePath
+		selectedPaths = selectedCommitFilePaths
+	}
+
+	var selectedCustomItem *CustomPanelItem
+	var selectedCustomItems []*CustomPanelItem
+	if customPanelContext, ok := self.c.Context().CurrentSide().(*context.CustomPanelContext); ok {
+		selectedCustomItem = customPanelItemShimFromModel(customPanelContext.GetSelected())
+		selectedCustomItems = selectedItemShims(customPanelContext, customPanelItemShimFromModel)
 	}
 
 	return &SessionState{
@@ -254,5 +341,42 @@ func (self *SessionStateLoader) call() *SessionState {
 		SelectedCommitFilePath: selectedCommitFilePath,
 		SelectedWorktree:       worktreeShimFromModelRemote(self.c.Contexts().Worktrees.GetSelected()),
 		CheckedOutBranch:       branchShimFromModelBranch(self.refsHelper.GetCheckedOutRef()),
+		WorkingTreeState:       workingTreeStateShimFromModel(self.c.Model().WorkingTreeStateAtLastCommitRefresh),
+		SelectedCustomItem:     selectedCustomItem,
+
+		SelectedCommits:         selectedCommits,
+		SelectedFiles:           selectedFiles,
+		SelectedPaths:           selectedPaths,
+		SelectedSubmodules:      selectedItemShims(self.c.Contexts().Submodules, submoduleShimFromModelSubmodule),
+		SelectedLocalBranches:   selectedItemShims(self.c.Contexts().Branches, branchShimFromModelBranch),
+		SelectedRemoteBranches:  selectedItemShims(self.c.Contexts().RemoteBranches, remoteBranchShimFromModelRemoteBranch),
+		SelectedRemotes:         selectedItemShims(self.c.Contexts().Remotes, remoteShimFromModelRemote),
+		SelectedTags:            selectedItemShims(self.c.Contexts().Tags, tagShimFromModelRemote),
+		SelectedStashEntries:    selectedItemShims(self.c.Contexts().Stash, stashEntryShimFromModelRemote),
+		SelectedCommitFiles:     selectedCommitFiles,
+		SelectedCommitFilePaths: selectedCommitFilePaths,
+		SelectedWorktrees:       selectedItemShims(self.c.Contexts().Worktrees, worktreeShimFromModelRemote),
+		SelectedCustomItems:     selectedCustomItems,
 	}
 }
+
+// Commits returns all commits of the current branch
+func (self *SessionStateLoader) Commits() []*Commit {
+	return lo.Map(self.c.Model().Commits, func(commit *models.Commit, _ int) *Commit {
+		return commitShimFromModelCommit(commit)
+	})
+}
+
+// Branches returns all local branches
+func (self *SessionStateLoader) Branches() []*Branch {
+	return lo.Map(self.c.Model().Branches, func(branch *models.Branch, _ int) *Branch {
+		return branchShimFromModelBranch(branch)
+	})
+}
+
+// Files returns all files with changes in the working tree
+func (self *SessionStateLoader) Files() []*File {
+	return lo.Map(self.c.Model().Files, func(file *models.File, _ int) *File {
+		return fileShimFromModelFile(file)
+	})
+}
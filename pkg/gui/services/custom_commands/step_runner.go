@@ -0,0 +1,138 @@
+package custom_commands
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// Runs the steps of a custom command one after the other. Commands run in the
+// background and prompts wait for the user, so each step calls the next one
+// once it's done rather than us looping over them.
+type stepRunner struct {
+	handlerCreator  *HandlerCreator
+	customCommand   config.CustomCommand
+	resolveTemplate func(string) (string, error)
+	form            map[string]any
+	results         map[string]*StepResult
+}
+
+func (self *HandlerCreator) runSteps(
+	customCommand config.CustomCommand,
+	resolveTemplate func(string) (string, error),
+	form map[string]any,
+	results map[string]*StepResult,
+) error {
+	runner := &stepRunner{
+		handlerCreator:  self,
+		customCommand:   customCommand,
+		resolveTemplate: resolveTemplate,
+		form:            form,
+		results:         results,
+	}
+
+	self.c.LogAction(self.c.Tr.Actions.CustomCommand)
+	return runner.run(0)
+}
+
+func (self *stepRunner) run(index int) error {
+	if index == len(self.customCommand.Steps) {
+		self.handlerCreator.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return nil
+	}
+
+	return self.runStep(self.customCommand.Steps[index], func() error {
+		return self.run(index + 1)
+	})
+}
+
+func (self *stepRunner) runStep(step config.CustomCommandStep, next func() error) error {
+	switch {
+	case step.Prompt != nil:
+		return self.handlerCreator.showPrompt(step.Prompt, self.resolveTemplate, func(response any) error {
+			if step.Prompt.Type != "confirm" {
+				self.form[step.Prompt.Key] = response
+			}
+			if responseStr, ok := response.(string); ok && step.Key != "" {
+				self.results[step.Key] = &StepResult{Output: responseStr}
+			}
+			return next()
+		})
+	case step.Refresh:
+		self.handlerCreator.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+		return next()
+	default:
+		return self.runCommandStep(step, next)
+	}
+}
+
+func (self *stepRunner) runCommandStep(step config.CustomCommandStep, next func() error) error {
+	cmdStr, err := self.resolveTemplate(step.Command)
+	if err != nil {
+		return err
+	}
+
+	loadingText, err := self.loadingText(step)
+	if err != nil {
+		return err
+	}
+
+	c := self.handlerCreator.c
+	return c.WithWaitingStatus(loadingText, func(gocui.Task) error {
+		output, err := c.OS().Cmd.NewShell(cmdStr, c.UserConfig().OS.ShellFunctionsFile).RunWithOutput()
+		if step.Key != "" {
+			self.results[step.Key] = &StepResult{Output: strings.TrimSpace(output)}
+		}
+
+		// the next step may be a prompt, so we continue on the UI thread
+		if err != nil {
+			c.OnUIThread(func() error { return self.handleError(step, err) })
+		} else {
+			c.OnUIThread(next)
+		}
+		return nil
+	})
+}
+
+func (self *stepRunner) loadingText(step config.CustomCommandStep) (string, error) {
+	if step.LoadingText != "" {
+		return self.resolveTemplate(step.LoadingText)
+	}
+
+	if self.customCommand.LoadingText != "" {
+		return self.customCommand.LoadingText, nil
+	}
+
+	return self.handlerCreator.c.Tr.RunningCustomCommandStatus, nil
+}
+
+// Runs the step's onError step if it has one, and then reports the error. The
+// remaining steps are skipped.
+func (self *stepRunner) handleError(step config.CustomCommandStep, err error) error {
+	self.handlerCreator.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+
+	reportError := func() error {
+		if step.ErrorMessage != "" {
+			message, resolveErr := self.resolveTemplate(step.ErrorMessage)
+			if resolveErr != nil {
+				return resolveErr
+			}
+			return errors.New(message)
+		}
+
+		if self.customCommand.After != nil && self.customCommand.After.CheckForConflicts {
+			return self.handlerCreator.mergeAndRebaseHelper.CheckForConflicts(err)
+		}
+
+		return err
+	}
+
+	if step.OnError != nil {
+		return self.runStep(*step.OnError, reportError)
+	}
+
+	return reportError()
+}
@@ -0,0 +1,139 @@
+package file_watcher
+
+import (
+	"github.com/sasha-s/go-deadlock"
+	"github.com/sirupsen/logrus"
+)
+
+// What the Refresher needs of a Watcher
+type IWatcher interface {
+	Start() error
+	Stop()
+}
+
+// Refresher keeps the current repo refreshed by watching it, falling back to
+// polling when watching fails (e.g. because the repo has more directories than
+// the OS lets us watch). There is at most one watcher and one polling routine
+// at a time; polling stops as soon as watching works again, e.g. after
+// switching to a smaller repo.
+type Refresher struct {
+	// Creates a watcher for the current repo, which calls onError if watching
+	// fails after it was started
+	newWatcher func(onError func(error)) IWatcher
+	// Starts polling the current repo until the given channel is closed
+	startPolling func(stop chan struct{})
+	// Runs f in the background. Starting a watcher can take a while in big
+	// repos, so we don't want to do it on the UI thread.
+	onWorker func(f func())
+	log      *logrus.Entry
+
+	mutex   deadlock.Mutex
+	started bool
+	// incremented whenever we switch repos, so that a watcher that was started
+	// for a previous repo isn't installed
+	repoGeneration int
+	watcher        IWatcher
+	// closed to stop polling; nil if we're not polling
+	stopPolling chan struct{}
+}
+
+func NewRefresher(
+	newWatcher func(onError func(error)) IWatcher,
+	startPolling func(stop chan struct{}),
+	onWorker func(f func()),
+	log *logrus.Entry,
+) *Refresher {
+	return &Refresher{
+		newWatcher:   newWatcher,
+		startPolling: startPolling,
+		onWorker:     onWorker,
+		log:          log,
+	}
+}
+
+// Starts watching (or polling) the current repo
+func (self *Refresher) Start() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.started = true
+	self.watchInBackground()
+}
+
+// Moves the watcher to the repo we just switched to. Polling goes on until the
+// new watcher has started, since it always refreshes the current repo.
+func (self *Refresher) OnNewRepo() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if !self.started {
+		return
+	}
+
+	self.repoGeneration++
+	if self.watcher != nil {
+		self.watcher.Stop()
+		self.watcher = nil
+	}
+	self.watchInBackground()
+}
+
+// Must be called with the mutex held
+func (self *Refresher) watchInBackground() {
+	repoGeneration := self.repoGeneration
+	self.onWorker(func() { self.watch(repoGeneration) })
+}
+
+func (self *Refresher) watch(repoGeneration int) {
+	var newWatcher IWatcher
+	newWatcher = self.newWatcher(func(err error) { self.onWatcherError(newWatcher, err) })
+	err := newWatcher.Start()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if repoGeneration != self.repoGeneration {
+		// we've switched repos in the meantime, and another watcher is on its way
+		if err == nil {
+			newWatcher.Stop()
+		}
+		return
+	}
+
+	if err != nil {
+		self.log.Warnf("Can't watch files (%v), falling back to polling", err)
+		self.poll()
+		return
+	}
+
+	self.watcher = newWatcher
+	if self.stopPolling != nil {
+		close(self.stopPolling)
+		self.stopPolling = nil
+	}
+}
+
+func (self *Refresher) onWatcherError(failedWatcher IWatcher, err error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	// we may have switched repos in the meantime
+	if self.watcher != failedWatcher {
+		return
+	}
+
+	self.log.Warnf("Watching files failed (%v), falling back to polling", err)
+	self.watcher.Stop()
+	self.watcher = nil
+	self.poll()
+}
+
+// Starts polling unless we already are. Must be called with the mutex held.
+func (self *Refresher) poll() {
+	if self.stopPolling != nil {
+		return
+	}
+
+	self.stopPolling = make(chan struct{})
+	self.startPolling(self.stopPolling)
+}
@@ -0,0 +1,139 @@
+package file_watcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeWatcher struct {
+	startErr error
+	onError  func(error)
+	started  bool
+	stopped  bool
+}
+
+func (self *fakeWatcher) Start() error {
+	self.started = self.startErr == nil
+	return self.startErr
+}
+
+func (self *fakeWatcher) Stop() {
+	self.stopped = true
+}
+
+type refresherTest struct {
+	refresher *Refresher
+	// the errors that the next watchers will fail to start with
+	startErrs []error
+	watchers  []*fakeWatcher
+	// the stop channels of all polling routines that were started
+	pollers []chan struct{}
+	// the functions passed to onWorker that haven't run yet
+	pendingWork []func()
+}
+
+func newRefresherTest() *refresherTest {
+	test := &refresherTest{}
+	test.refresher = NewRefresher(
+		func(onError func(error)) IWatcher {
+			watcher := &fakeWatcher{startErr: test.startErrs[0], onError: onError}
+			test.startErrs = test.startErrs[1:]
+			test.watchers = append(test.watchers, watcher)
+			return watcher
+		},
+		func(stop chan struct{}) { test.pollers = append(test.pollers, stop) },
+		func(f func()) { test.pendingWork = append(test.pendingWork, f) },
+		utils.NewDummyLog(),
+	)
+	return test
+}
+
+func (self *refresherTest) runPendingWork() {
+	for len(self.pendingWork) > 0 {
+		f := self.pendingWork[0]
+		self.pendingWork = self.pendingWork[1:]
+		f()
+	}
+}
+
+func (self *refresherTest) activePollers() int {
+	return lo.CountBy(self.pollers, func(stop chan struct{}) bool {
+		select {
+		case <-stop:
+			return false
+		default:
+			return true
+		}
+	})
+}
+
+func TestRefresherFallsBackToASinglePoller(t *testing.T) {
+	test := newRefresherTest()
+	test.startErrs = []error{ErrWatchLimitReached, ErrWatchLimitReached, nil}
+
+	test.refresher.Start()
+	assert.Len(t, test.watchers, 0, "the watcher should be started in the background")
+	test.runPendingWork()
+	assert.Equal(t, 1, test.activePollers())
+
+	// switching to another repo that is too big to watch keeps the poller
+	test.refresher.OnNewRepo()
+	test.runPendingWork()
+	assert.Len(t, test.pollers, 1)
+	assert.Equal(t, 1, test.activePollers())
+
+	// switching to a repo that can be watched stops polling
+	test.refresher.OnNewRepo()
+	test.runPendingWork()
+	assert.True(t, test.watchers[2].started)
+	assert.Equal(t, 0, test.activePollers())
+}
+
+func TestRefresherPollsWhenWatchingFails(t *testing.T) {
+	test := newRefresherTest()
+	test.startErrs = []error{nil, nil}
+
+	test.refresher.Start()
+	test.runPendingWork()
+	assert.Equal(t, 0, test.activePollers())
+
+	test.watchers[0].onError(ErrWatchLimitReached)
+	assert.True(t, test.watchers[0].stopped)
+	assert.Equal(t, 1, test.activePollers())
+
+	// errors of a watcher that was already replaced are ignored
+	test.refresher.OnNewRepo()
+	test.runPendingWork()
+	test.watchers[0].onError(errors.New("stale"))
+	assert.False(t, test.watchers[1].stopped)
+	assert.Equal(t, 0, test.activePollers())
+}
+
+func TestRefresherDiscardsWatchersOfPreviousRepos(t *testing.T) {
+	test := newRefresherTest()
+	test.startErrs = []error{nil, nil, nil}
+
+	test.refresher.Start()
+	test.runPendingWork()
+
+	// switch repos twice before the first new watcher has been started
+	test.refresher.OnNewRepo()
+	test.refresher.OnNewRepo()
+	assert.True(t, test.watchers[0].stopped)
+
+	test.runPendingWork()
+	assert.True(t, test.watchers[1].stopped, "the watcher of the repo we switched away from should be stopped")
+	assert.False(t, test.watchers[2].stopped)
+	assert.Equal(t, 0, test.activePollers())
+}
+
+func TestRefresherOnNewRepoBeforeStart(t *testing.T) {
+	test := newRefresherTest()
+
+	test.refresher.OnNewRepo()
+	assert.Empty(t, test.pendingWork)
+}
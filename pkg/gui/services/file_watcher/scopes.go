@@ -0,0 +1,51 @@
+package file_watcher
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+)
+
+// Returns what needs refreshing when the file at the given path in the git dir
+// changes, or nil if the file doesn't affect anything we show. The path is
+// relative to the git dir and uses forward slashes.
+func scopesForGitDirPath(path string) []types.RefreshableView {
+	if strings.HasSuffix(path, ".lock") {
+		// git writes to a lock file and then renames it, so we'll get an
+		// event for the real file too
+		return nil
+	}
+
+	switch path {
+	case "index":
+		return []types.RefreshableView{types.FILES}
+	case "HEAD":
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS}
+	case "logs/HEAD":
+		return []types.RefreshableView{types.REFLOG}
+	case "packed-refs":
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS, types.REMOTES, types.TAGS}
+	case "config":
+		// e.g. a branch's upstream was set
+		return []types.RefreshableView{types.BRANCHES, types.REMOTES}
+	case "refs/stash", "logs/refs/stash":
+		return []types.RefreshableView{types.STASH}
+	case "MERGE_HEAD", "CHERRY_PICK_HEAD", "REVERT_HEAD", "rebase-merge", "rebase-apply":
+		// the working tree state is loaded together with the commits
+		return []types.RefreshableView{types.COMMITS, types.FILES}
+	}
+
+	switch {
+	case strings.HasPrefix(path, "refs/heads/"):
+		return []types.RefreshableView{types.BRANCHES, types.COMMITS}
+	case strings.HasPrefix(path, "refs/remotes/"):
+		// the ahead/behind counts of branches depend on their upstreams
+		return []types.RefreshableView{types.REMOTES, types.BRANCHES, types.COMMITS}
+	case strings.HasPrefix(path, "refs/tags/"):
+		return []types.RefreshableView{types.TAGS}
+	case strings.HasPrefix(path, "BISECT_"):
+		return []types.RefreshableView{types.BISECT_INFO, types.COMMITS}
+	}
+
+	return nil
+}
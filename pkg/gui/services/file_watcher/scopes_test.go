@@ -0,0 +1,37 @@
+package file_watcher
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopesForGitDirPath(t *testing.T) {
+	scenarios := []struct {
+		path     string
+		expected []types.RefreshableView
+	}{
+		{path: "index", expected: []types.RefreshableView{types.FILES}},
+		{path: "index.lock", expected: nil},
+		{path: "HEAD", expected: []types.RefreshableView{types.BRANCHES, types.COMMITS}},
+		{path: "logs/HEAD", expected: []types.RefreshableView{types.REFLOG}},
+		{path: "refs/heads/feature/login", expected: []types.RefreshableView{types.BRANCHES, types.COMMITS}},
+		{path: "refs/heads/master.lock", expected: nil},
+		{path: "refs/remotes/origin/master", expected: []types.RefreshableView{types.REMOTES, types.BRANCHES, types.COMMITS}},
+		{path: "refs/tags/v1.0", expected: []types.RefreshableView{types.TAGS}},
+		{path: "refs/stash", expected: []types.RefreshableView{types.STASH}},
+		{path: "logs/refs/stash", expected: []types.RefreshableView{types.STASH}},
+		{path: "rebase-merge", expected: []types.RefreshableView{types.COMMITS, types.FILES}},
+		{path: "BISECT_LOG", expected: []types.RefreshableView{types.BISECT_INFO, types.COMMITS}},
+		{path: "ORIG_HEAD", expected: nil},
+		{path: "FETCH_HEAD", expected: nil},
+		{path: "logs/refs/heads/master", expected: nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.path, func(t *testing.T) {
+			assert.Equal(t, s.expected, scopesForGitDirPath(s.path))
+		})
+	}
+}
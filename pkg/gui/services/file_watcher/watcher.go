@@ -0,0 +1,307 @@
+package file_watcher
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/samber/lo"
+)
+
+// How long to wait for more changes before refreshing. Most operations (e.g.
+// a checkout, or a build) change many files in quick succession, and we only
+// want to refresh once.
+const debounceDuration = 100 * time.Millisecond
+
+// How long to wait at most before refreshing while changes keep coming in
+const maxRefreshDelay = time.Second
+
+// Returned by Start when the OS doesn't let us watch any more files. The
+// caller should fall back to polling.
+var ErrWatchLimitReached = errors.New("file watch limit reached")
+
+// Watcher refreshes the parts of the gui whose files change, by watching the
+// work tree (except for ignored directories) and the files in the git dir that
+// refs, HEAD and the index are stored in. Watches the repo that is current when
+// Start is called; a new Watcher needs to be started after switching repos.
+type Watcher struct {
+	c *helpers.HelperCommon
+	// Whether to ignore changes for now, e.g. while a subprocess is running
+	isPaused func() bool
+	// Called if watching fails after Start succeeded, e.g. because a directory
+	// was created after the watch limit was reached
+	onError func(error)
+
+	fsWatcher      *fsnotify.Watcher
+	worktreePath   string
+	worktreeGitDir string
+	repoGitDir     string
+	stop           chan struct{}
+}
+
+func NewWatcher(c *helpers.HelperCommon, isPaused func() bool, onError func(error)) *Watcher {
+	return &Watcher{
+		c:        c,
+		isPaused: isPaused,
+		onError:  onError,
+	}
+}
+
+func (self *Watcher) Start() error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return watchError(err)
+	}
+	self.fsWatcher = fsWatcher
+	self.stop = make(chan struct{})
+
+	repoPaths := self.c.Git().RepoPaths
+	self.worktreeGitDir = repoPaths.WorktreeGitDirPath()
+	self.repoGitDir = repoPaths.RepoGitDirPath()
+	if !repoPaths.IsBareRepo() {
+		self.worktreePath = repoPaths.WorktreePath()
+	}
+
+	if err := self.addWatches(); err != nil {
+		fsWatcher.Close()
+		return watchError(err)
+	}
+
+	go utils.Safe(self.run)
+	return nil
+}
+
+// Stops watching. Must be called at most once, and the watcher can't be
+// started again afterwards.
+func (self *Watcher) Stop() {
+	if self.fsWatcher != nil {
+		close(self.stop)
+		self.fsWatcher.Close()
+	}
+}
+
+func (self *Watcher) addWatches() error {
+	// we don't watch the git dirs recursively, because objects/ is huge and
+	// changes whenever refs do anyway
+	gitDirs := lo.Uniq([]string{self.worktreeGitDir, self.repoGitDir})
+	for _, dir := range gitDirs {
+		if err := self.fsWatcher.Add(dir); err != nil {
+			return err
+		}
+	}
+	for _, dir := range []string{filepath.Join(self.worktreeGitDir, "logs"), filepath.Join(self.repoGitDir, "logs", "refs")} {
+		if err := self.addIfExists(dir); err != nil {
+			return err
+		}
+	}
+	if err := self.addRecursively(filepath.Join(self.repoGitDir, "refs"), nil); err != nil {
+		return err
+	}
+
+	if self.worktreePath == "" {
+		return nil
+	}
+
+	ignoredDirs, err := self.c.Git().WorkingTree.IgnoredDirectories()
+	if err != nil {
+		return err
+	}
+	ignoredDirSet := lo.SliceToMap(ignoredDirs, func(dir string) (string, bool) {
+		return filepath.Join(self.worktreePath, filepath.FromSlash(dir)), true
+	})
+	return self.addRecursively(self.worktreePath, ignoredDirSet)
+}
+
+func (self *Watcher) addIfExists(dir string) error {
+	if err := self.fsWatcher.Add(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Watches the given directory and its subdirectories, except for .git
+// directories (of the repo and of nested repos) and the given ignored ones
+func (self *Watcher) addRecursively(root string, ignoredDirs map[string]bool) error {
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// the directory may have been deleted in the meantime
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if entry.Name() == ".git" || ignoredDirs[path] {
+			return filepath.SkipDir
+		}
+		return self.addIfExists(path)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (self *Watcher) run() {
+	fsWatcher := self.fsWatcher
+
+	// the changed paths, and what happened to them
+	pendingPaths := map[string]fsnotify.Op{}
+	overflowed := false
+	var debounceTimer, maxDelayTimer <-chan time.Time
+
+	for {
+		select {
+		case <-self.stop:
+			return
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				// e.g. touching a file without changing it
+				continue
+			}
+			pendingPaths[event.Name] |= event.Op
+			debounceTimer = time.After(debounceDuration)
+			if maxDelayTimer == nil {
+				maxDelayTimer = time.After(maxRefreshDelay)
+			}
+			continue
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			if !errors.Is(err, fsnotify.ErrEventOverflow) {
+				self.c.Log.Error(err)
+				continue
+			}
+			// we've missed events, so we don't know what changed
+			overflowed = true
+			debounceTimer = time.After(debounceDuration)
+			continue
+		case <-debounceTimer:
+		case <-maxDelayTimer:
+		}
+
+		debounceTimer, maxDelayTimer = nil, nil
+		changes := pendingPaths
+		pendingPaths = map[string]fsnotify.Op{}
+		if self.isPaused() {
+			// we'll refresh everything when resuming anyway
+			overflowed = false
+			continue
+		}
+
+		if overflowed {
+			overflowed = false
+			self.refresh(nil)
+			continue
+		}
+
+		if scope := self.processChanges(changes); len(scope) > 0 {
+			self.refresh(scope)
+		}
+	}
+}
+
+// Returns what needs to be refreshed for the given changed paths, and starts
+// watching new directories
+func (self *Watcher) processChanges(changes map[string]fsnotify.Op) []types.RefreshableView {
+	scope := []types.RefreshableView{}
+	worktreePaths := []string{}
+	for path, op := range changes {
+		if gitDirPath, ok := self.relativeGitDirPath(path); ok {
+			scope = append(scope, scopesForGitDirPath(gitDirPath)...)
+			if op.Has(fsnotify.Create) && strings.HasPrefix(gitDirPath, "refs/") {
+				// e.g. the first branch called feature/something was created
+				self.watchIfNewDir(path)
+			}
+		} else if relativePath, ok := relativePathIn(self.worktreePath, path); ok {
+			worktreePaths = append(worktreePaths, relativePath)
+		}
+	}
+
+	if len(worktreePaths) > 0 {
+		ignoredPaths, err := self.c.Git().WorkingTree.IgnoredPaths(worktreePaths)
+		if err != nil {
+			self.c.Log.Error(err)
+		}
+		ignoredPathSet := lo.SliceToMap(ignoredPaths, func(path string) (string, bool) { return path, true })
+		for _, relativePath := range worktreePaths {
+			if ignoredPathSet[relativePath] {
+				continue
+			}
+			scope = append(scope, types.FILES)
+			path := filepath.Join(self.worktreePath, filepath.FromSlash(relativePath))
+			if changes[path].Has(fsnotify.Create) {
+				self.watchIfNewDir(path)
+			}
+		}
+	}
+
+	return lo.Uniq(scope)
+}
+
+func (self *Watcher) watchIfNewDir(path string) {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return
+	}
+
+	// a directory that was moved in may contain ignored directories, which
+	// we'd only know about by asking git again. That's rare enough that we
+	// just watch them too.
+	if err := self.addRecursively(path, nil); err != nil && !errors.Is(err, fsnotify.ErrClosed) {
+		self.onError(watchError(err))
+	}
+}
+
+// Returns the path relative to the git dir that contains it, if any. The
+// worktree's git dir comes first because for linked worktrees, it's inside
+// the repo's git dir.
+func (self *Watcher) relativeGitDirPath(path string) (string, bool) {
+	if relativePath, ok := relativePathIn(self.worktreeGitDir, path); ok {
+		return relativePath, true
+	}
+	return relativePathIn(self.repoGitDir, path)
+}
+
+func (self *Watcher) refresh(scope []types.RefreshableView) {
+	self.c.OnWorker(func(gocui.Task) error {
+		self.c.Refresh(types.RefreshOptions{Scope: scope})
+		return nil
+	})
+}
+
+// Returns the path relative to dir with forward slashes, if it's inside dir
+func relativePathIn(dir string, path string) (string, bool) {
+	if dir == "" {
+		return "", false
+	}
+	relativePath, err := filepath.Rel(dir, path)
+	if err != nil || relativePath == "." || strings.HasPrefix(relativePath, "..") {
+		return "", false
+	}
+	return filepath.ToSlash(relativePath), true
+}
+
+// Running out of inotify watches shows up as ENOSPC, and running out of file
+// descriptors (e.g. with kqueue, which needs one per watched file) as EMFILE
+func watchError(err error) error {
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) {
+		return ErrWatchLimitReached
+	}
+	return err
+}
@@ -0,0 +1,203 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// The methods that plugins can call. See docs/Plugins.md for a description of
+// each of them.
+
+type toastParams struct {
+	Message string `json:"message"`
+	// "error" for an error toast; anything else for a normal one
+	Kind string `json:"kind"`
+}
+
+type alertParams struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+type confirmParams struct {
+	Title  string `json:"title"`
+	Prompt string `json:"prompt"`
+}
+
+type confirmResult struct {
+	Confirmed bool `json:"confirmed"`
+}
+
+type promptParams struct {
+	Title        string `json:"title"`
+	InitialValue string `json:"initialValue"`
+}
+
+type promptResult struct {
+	Value     string `json:"value"`
+	Cancelled bool   `json:"cancelled"`
+}
+
+type menuParams struct {
+	Title string     `json:"title"`
+	Items []menuItem `json:"items"`
+}
+
+type menuItem struct {
+	Label       string `json:"label"`
+	Description string `json:"description"`
+}
+
+type menuResult struct {
+	// The index of the chosen item, or -1 if the menu was cancelled
+	Index int `json:"index"`
+}
+
+type refreshParams struct {
+	// The names of the things to refresh, e.g. "files" or "branches". Empty
+	// means everything.
+	Scope []string `json:"scope"`
+}
+
+// Requests arrive on the goroutine that reads the plugin's output; we handle
+// them on the UI thread because that's where the models and popups live.
+func (self *Client) handleRequest(p *plugin, method string, rawParams json.RawMessage, reply replyFunc) {
+	self.c.OnUIThread(func() error {
+		if err := self.dispatch(p, method, rawParams, reply); err != nil {
+			reply(nil, err)
+		}
+		return nil
+	})
+}
+
+func (self *Client) dispatch(p *plugin, method string, rawParams json.RawMessage, reply replyFunc) error {
+	switch method {
+	case "getSessionState":
+		reply(self.sessionStateLoader.Load(), nil)
+	case "getCommits":
+		reply(self.sessionStateLoader.Commits(), nil)
+	case "getBranches":
+		reply(self.sessionStateLoader.Branches(), nil)
+	case "getFiles":
+		reply(self.sessionStateLoader.Files(), nil)
+	case "toast":
+		var params toastParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		if params.Kind == "error" {
+			self.c.ErrorToast(params.Message)
+		} else {
+			self.c.Toast(params.Message)
+		}
+		reply(nil, nil)
+	case "alert":
+		var params alertParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		resume := p.pauseTask()
+		done := func() error {
+			resume()
+			reply(nil, nil)
+			return nil
+		}
+		self.c.Confirm(types.ConfirmOpts{
+			Title:         params.Title,
+			Prompt:        params.Message,
+			HandleConfirm: done,
+			HandleClose:   done,
+		})
+	case "confirm":
+		var params confirmParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		resume := p.pauseTask()
+		answer := func(confirmed bool) func() error {
+			return func() error {
+				resume()
+				reply(confirmResult{Confirmed: confirmed}, nil)
+				return nil
+			}
+		}
+		self.c.Confirm(types.ConfirmOpts{
+			Title:         params.Title,
+			Prompt:        params.Prompt,
+			HandleConfirm: answer(true),
+			HandleClose:   answer(false),
+		})
+	case "prompt":
+		var params promptParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		resume := p.pauseTask()
+		self.c.Prompt(types.PromptOpts{
+			Title:           params.Title,
+			InitialContent:  params.InitialValue,
+			AllowEmptyInput: true,
+			HandleConfirm: func(value string) error {
+				resume()
+				reply(promptResult{Value: value}, nil)
+				return nil
+			},
+			HandleClose: func() error {
+				resume()
+				reply(promptResult{Cancelled: true}, nil)
+				return nil
+			},
+		})
+	case "menu":
+		var params menuParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		resume := p.pauseTask()
+		choose := func(index int) {
+			resume()
+			reply(menuResult{Index: index}, nil)
+		}
+		return self.c.Menu(types.CreateMenuOptions{
+			Title: params.Title,
+			Items: lo.Map(params.Items, func(item menuItem, index int) *types.MenuItem {
+				return &types.MenuItem{
+					LabelColumns: []string{item.Label, item.Description},
+					OnPress: func() error {
+						choose(index)
+						return nil
+					},
+				}
+			}),
+			OnCancel: func() { choose(-1) },
+		})
+	case "refresh":
+		var params refreshParams
+		if err := decodeParams(rawParams, &params); err != nil {
+			return err
+		}
+		// nil means everything
+		var scope []types.RefreshableView
+		for _, name := range params.Scope {
+			refreshable, ok := helpers.ScopeFromName(name)
+			if !ok {
+				return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown scope '%s'", name)}
+			}
+			scope = append(scope, refreshable)
+		}
+		self.c.OnWorker(func(gocui.Task) error {
+			self.c.Refresh(types.RefreshOptions{Mode: types.SYNC, Scope: scope})
+			reply(nil, nil)
+			return nil
+		})
+	default:
+		return &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("unknown method '%s'", method)}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/keybindings"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/custom_commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// Client is the entry point to this package. It starts the plugins configured
+// in the user config, and returns keybindings for the commands that they
+// register. See https://github.com/jesseduffield/lazygit/blob/master/docs/Plugins.md
+type Client struct {
+	c                  *helpers.HelperCommon
+	sessionStateLoader *custom_commands.SessionStateLoader
+
+	plugins []*plugin
+	// The commands registered by the plugins that have been initialized so
+	// far. Only accessed on the UI thread.
+	commands []*registeredCommand
+}
+
+type registeredCommand struct {
+	plugin  *plugin
+	command Command
+}
+
+func NewClient(
+	c *helpers.HelperCommon,
+	helpers *helpers.Helpers,
+) *Client {
+	return &Client{
+		c:                  c,
+		sessionStateLoader: custom_commands.NewSessionStateLoader(c, helpers.Refs),
+	}
+}
+
+// Starts the configured plugins and initializes them in the background. Their
+// commands take effect as soon as they have been registered.
+func (self *Client) Start(repoPath string) {
+	for _, pluginConfig := range self.c.UserConfig().Plugins {
+		cmd := self.c.OS().Cmd.NewShell(pluginConfig.Command, self.c.UserConfig().OS.ShellFunctionsFile).GetCmd()
+
+		p, err := startPlugin(pluginConfig, cmd, self.c.Log, self.handleRequest)
+		if err != nil {
+			self.c.ErrorToast(fmt.Sprintf(self.c.Tr.PluginFailedToStart, pluginConfig.GetName(), err))
+			continue
+		}
+		self.plugins = append(self.plugins, p)
+
+		self.c.OnWorker(func(gocui.Task) error {
+			commands, err := p.initialize(repoPath)
+			if err != nil {
+				return err
+			}
+
+			self.c.OnUIThread(func() error {
+				return self.registerCommands(p, commands)
+			})
+			return nil
+		})
+	}
+}
+
+// Stops all plugins. The client can't be used anymore afterwards.
+func (self *Client) Stop() {
+	for _, p := range self.plugins {
+		p.stop()
+	}
+	self.plugins = nil
+}
+
+func (self *Client) registerCommands(p *plugin, commands []Command) error {
+	for _, command := range commands {
+		if err := self.validateCommand(command); err != nil {
+			return fmt.Errorf("Error with command '%s' of plugin '%s': %w", command.ID, p.config.GetName(), err)
+		}
+	}
+
+	self.commands = append(self.commands, lo.Map(commands, func(command Command, _ int) *registeredCommand {
+		return &registeredCommand{plugin: p, command: command}
+	})...)
+
+	return self.c.ResetKeybindings()
+}
+
+func (self *Client) validateCommand(command Command) error {
+	if command.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	for _, key := range config.SplitKeySequence(command.Key) {
+		if utf8.RuneCountInString(key) > 1 && key != "<disabled>" {
+			if _, ok := config.KeyByLabel[strings.ToLower(key)]; !ok {
+				return fmt.Errorf("unrecognized key '%s'", command.Key)
+			}
+		}
+	}
+
+	if _, err := self.viewNameForContext(command.Context); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (self *Client) GetKeybindings() []*types.Binding {
+	return lo.Map(self.commands, func(registered *registeredCommand, _ int) *types.Binding {
+		// we validated the context when registering the command
+		viewName, _ := self.viewNameForContext(registered.command.Context)
+
+		return &types.Binding{
+			ViewName:    viewName,
+			Key:         keybindings.GetKey(registered.command.Key),
+			Modifier:    gocui.ModNone,
+			Handler:     func() error { return self.runCommand(registered) },
+			Description: registered.command.Description,
+		}
+	})
+}
+
+func (self *Client) viewNameForContext(contextKey string) (string, error) {
+	if contextKey == "global" {
+		return "", nil
+	}
+
+	for _, context := range self.c.Contexts().Flatten() {
+		if context.GetKey() == types.ContextKey(contextKey) {
+			return context.GetViewName(), nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown context '%s'", contextKey)
+}
+
+// The plugin does its work while handling the request, possibly asking us to
+// show popups, so we must not block the UI thread while waiting for it.
+func (self *Client) runCommand(registered *registeredCommand) error {
+	self.c.OnWorker(func(task gocui.Task) error {
+		registered.plugin.setTask(task)
+		defer registered.plugin.setTask(nil)
+
+		if err := registered.plugin.runCommand(registered.command.ID); err != nil {
+			return fmt.Errorf("Plugin '%s': %w", registered.plugin.config.GetName(), err)
+		}
+		return nil
+	})
+
+	return nil
+}
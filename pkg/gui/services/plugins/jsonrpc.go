@@ -0,0 +1,215 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// A minimal JSON-RPC 2.0 connection. Each message is a single line of JSON,
+// which keeps plugins easy to write in any language: read a line, parse it,
+// print a line.
+
+type message struct {
+	JsonRpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (self *rpcError) Error() string {
+	return self.Message
+}
+
+const (
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// Replies to a request. It may be called from any goroutine, and at any time
+// after the request was received, which lets us reply once the user has
+// answered a popup. For notifications it does nothing.
+type replyFunc func(result any, err error)
+
+// Handles a request or notification from the other side
+type requestHandler func(method string, params json.RawMessage, reply replyFunc)
+
+var errConnectionClosed = errors.New("connection closed")
+
+type conn struct {
+	writer     io.Writer
+	writeMutex sync.Mutex
+
+	handleRequest requestHandler
+
+	pendingMutex sync.Mutex
+	pending      map[string]chan *message
+	nextID       int
+	closed       bool
+}
+
+func newConn(writer io.Writer, handleRequest requestHandler) *conn {
+	return &conn{
+		writer:        writer,
+		handleRequest: handleRequest,
+		pending:       map[string]chan *message{},
+	}
+}
+
+// Reads messages until the reader is exhausted, dispatching requests to the
+// handler and responses to whoever is waiting for them. Blocks, so call it in a
+// goroutine.
+func (self *conn) run(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	// plugins may send large results, e.g. when we ask for all commits
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			self.close()
+			return fmt.Errorf("invalid message: %w", err)
+		}
+
+		if msg.Method != "" {
+			self.handleRequest(msg.Method, msg.Params, self.replyFuncFor(msg.ID))
+		} else {
+			self.resolve(&msg)
+		}
+	}
+
+	self.close()
+	return scanner.Err()
+}
+
+// Sends a request and waits for the response, decoding its result into
+// `result` (which may be nil if we don't care about the result)
+func (self *conn) call(method string, params any, result any) error {
+	self.pendingMutex.Lock()
+	if self.closed {
+		self.pendingMutex.Unlock()
+		return errConnectionClosed
+	}
+	self.nextID++
+	id := strconv.Itoa(self.nextID)
+	responseChan := make(chan *message, 1)
+	self.pending[id] = responseChan
+	self.pendingMutex.Unlock()
+
+	if err := self.send(method, json.RawMessage(id), params); err != nil {
+		self.pendingMutex.Lock()
+		delete(self.pending, id)
+		self.pendingMutex.Unlock()
+		return err
+	}
+
+	response, ok := <-responseChan
+	if !ok {
+		return errConnectionClosed
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+// Sends a notification, i.e. a request that doesn't get a response
+func (self *conn) notify(method string, params any) error {
+	return self.send(method, nil, params)
+}
+
+func (self *conn) send(method string, id json.RawMessage, params any) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return self.write(&message{JsonRpc: "2.0", ID: id, Method: method, Params: rawParams})
+}
+
+func (self *conn) replyFuncFor(id json.RawMessage) replyFunc {
+	if len(id) == 0 {
+		return func(any, error) {}
+	}
+
+	return func(result any, err error) {
+		response := &message{JsonRpc: "2.0", ID: id}
+		if err != nil {
+			response.Error = toRpcError(err)
+		} else {
+			rawResult, marshalErr := json.Marshal(result)
+			if marshalErr != nil {
+				response.Error = &rpcError{Code: codeInternalError, Message: marshalErr.Error()}
+			} else {
+				response.Result = rawResult
+			}
+		}
+
+		// if this fails the connection is gone, and run() will notice that
+		_ = self.write(response)
+	}
+}
+
+func toRpcError(err error) *rpcError {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	return &rpcError{Code: codeInternalError, Message: err.Error()}
+}
+
+func (self *conn) write(msg *message) error {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	self.writeMutex.Lock()
+	defer self.writeMutex.Unlock()
+
+	_, err = self.writer.Write(append(bytes, '\n'))
+	return err
+}
+
+func (self *conn) resolve(response *message) {
+	self.pendingMutex.Lock()
+	defer self.pendingMutex.Unlock()
+
+	id := string(response.ID)
+	if responseChan, ok := self.pending[id]; ok {
+		delete(self.pending, id)
+		responseChan <- response
+	}
+}
+
+// Fails all calls that are still waiting for a response
+func (self *conn) close() {
+	self.pendingMutex.Lock()
+	defer self.pendingMutex.Unlock()
+
+	self.closed = true
+	for id, responseChan := range self.pending {
+		close(responseChan)
+		delete(self.pending, id)
+	}
+}
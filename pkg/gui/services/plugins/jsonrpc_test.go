@@ -0,0 +1,92 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Sets up a conn whose other end is driven by the test, which reads the lines
+// that the conn writes and writes lines for the conn to read
+func newTestConn(handleRequest requestHandler) (*conn, *bufio.Scanner, io.WriteCloser, chan error) {
+	connReader, peerWriter := io.Pipe()
+	peerReader, connWriter := io.Pipe()
+
+	c := newConn(connWriter, handleRequest)
+	runResult := make(chan error, 1)
+	go func() {
+		runResult <- c.run(connReader)
+	}()
+
+	return c, bufio.NewScanner(peerReader), peerWriter, runResult
+}
+
+func TestConnCall(t *testing.T) {
+	c, peerScanner, peerWriter, _ := newTestConn(nil)
+
+	type result struct {
+		Value string `json:"value"`
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var res result
+		err := c.call("greet", map[string]string{"name": "bob"}, &res)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello bob", res.Value)
+
+		err = c.call("fail", nil, nil)
+		assert.EqualError(t, err, "nope")
+	}()
+
+	assert.True(t, peerScanner.Scan())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":1,"method":"greet","params":{"name":"bob"}}`, peerScanner.Text())
+	_, _ = peerWriter.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"value":"hello bob"}}` + "\n"))
+
+	assert.True(t, peerScanner.Scan())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"method":"fail","params":null}`, peerScanner.Text())
+	_, _ = peerWriter.Write([]byte(`{"jsonrpc":"2.0","id":2,"error":{"code":1,"message":"nope"}}` + "\n"))
+
+	<-done
+}
+
+func TestConnHandleRequest(t *testing.T) {
+	handleRequest := func(method string, params json.RawMessage, reply replyFunc) {
+		switch method {
+		case "echo":
+			var p map[string]string
+			_ = json.Unmarshal(params, &p)
+			reply(p, nil)
+		default:
+			reply(nil, &rpcError{Code: codeMethodNotFound, Message: "unknown method"})
+		}
+	}
+	_, peerScanner, peerWriter, _ := newTestConn(handleRequest)
+
+	_, _ = peerWriter.Write([]byte(`{"jsonrpc":"2.0","id":"a","method":"echo","params":{"x":"y"}}` + "\n"))
+	assert.True(t, peerScanner.Scan())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":"a","result":{"x":"y"}}`, peerScanner.Text())
+
+	_, _ = peerWriter.Write([]byte(`{"jsonrpc":"2.0","id":7,"method":"bogus"}` + "\n"))
+	assert.True(t, peerScanner.Scan())
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":7,"error":{"code":-32601,"message":"unknown method"}}`, peerScanner.Text())
+}
+
+func TestConnClosed(t *testing.T) {
+	c, peerScanner, peerWriter, runResult := newTestConn(nil)
+
+	callResult := make(chan error, 1)
+	go func() {
+		callResult <- c.call("slow", nil, nil)
+	}()
+
+	assert.True(t, peerScanner.Scan())
+	_ = peerWriter.Close()
+
+	assert.NoError(t, <-runResult)
+	assert.ErrorIs(t, <-callResult, errConnectionClosed)
+	assert.ErrorIs(t, c.call("another", nil, nil), errConnectionClosed)
+}
@@ -0,0 +1,175 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// The version of the protocol that we speak. Plugins receive it in the
+// initialize request, so that they can refuse to run if they don't support it.
+const protocolVersion = 1
+
+// A command that a plugin registers when it's initialized. Commands with a key
+// are bound to that key in the given context; all of them are listed in the
+// keybindings menu of that context.
+type Command struct {
+	ID          string `json:"id"`
+	Key         string `json:"key"`
+	Context     string `json:"context"`
+	Description string `json:"description"`
+}
+
+type initializeParams struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	RepoPath        string `json:"repoPath"`
+}
+
+type initializeResult struct {
+	Commands []Command `json:"commands"`
+}
+
+type runCommandParams struct {
+	ID string `json:"id"`
+}
+
+// A running plugin process
+type plugin struct {
+	config config.PluginConfig
+	log    *logrus.Entry
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	conn   *conn
+	// closed when the process has exited
+	exited chan struct{}
+
+	// The task of the command that the plugin is currently running, if any.
+	// We pause it while the user answers a popup that the plugin asked for, so
+	// that lazygit doesn't appear busy in the meantime.
+	taskMutex sync.Mutex
+	task      gocui.Task
+}
+
+// Handles a request or notification that a plugin sends us
+type pluginRequestHandler func(p *plugin, method string, params json.RawMessage, reply replyFunc)
+
+func startPlugin(pluginConfig config.PluginConfig, cmd *exec.Cmd, log *logrus.Entry, handleRequest pluginRequestHandler) (*plugin, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	self := &plugin{
+		config: pluginConfig,
+		log:    log,
+		cmd:    cmd,
+		stdin:  stdin,
+		exited: make(chan struct{}),
+	}
+	self.conn = newConn(stdin, func(method string, params json.RawMessage, reply replyFunc) {
+		handleRequest(self, method, params, reply)
+	})
+
+	go func() {
+		if err := self.conn.run(stdout); err != nil {
+			self.log.Errorf("Plugin '%s': %v", self.config.GetName(), err)
+		}
+		_ = cmd.Wait()
+		close(self.exited)
+		self.log.Infof("Plugin '%s' exited", self.config.GetName())
+	}()
+
+	// anything the plugin prints to stderr ends up in our log, which is where
+	// plugin authors will look when debugging
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			self.log.Infof("Plugin '%s': %s", self.config.GetName(), scanner.Text())
+		}
+	}()
+
+	return self, nil
+}
+
+func (self *plugin) initialize(repoPath string) ([]Command, error) {
+	var result initializeResult
+	err := self.conn.call("initialize", initializeParams{ProtocolVersion: protocolVersion, RepoPath: repoPath}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize plugin '%s': %w", self.config.GetName(), err)
+	}
+
+	return result.Commands, nil
+}
+
+func (self *plugin) runCommand(id string) error {
+	return self.conn.call("runCommand", runCommandParams{ID: id}, nil)
+}
+
+func (self *plugin) setTask(task gocui.Task) {
+	self.taskMutex.Lock()
+	defer self.taskMutex.Unlock()
+
+	self.task = task
+}
+
+// Pauses the task of the running command, if any, and returns a function for
+// continuing it
+func (self *plugin) pauseTask() func() {
+	self.taskMutex.Lock()
+	defer self.taskMutex.Unlock()
+
+	task := self.task
+	if task == nil {
+		return func() {}
+	}
+
+	task.Pause()
+	return task.Continue
+}
+
+// Closing stdin tells the plugin to exit. Plugins that don't do so within a
+// second are killed.
+func (self *plugin) stop() {
+	_ = self.stdin.Close()
+
+	go func() {
+		select {
+		case <-self.exited:
+		case <-time.After(time.Second):
+			_ = self.cmd.Process.Kill()
+		}
+	}()
+}
+
+// Decodes the params of a request, turning failures into the appropriate
+// JSON-RPC error
+func decodeParams(rawParams json.RawMessage, params any) error {
+	if len(rawParams) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(rawParams, params); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// Sends each line of `requests` to the lazygit that listens on the socket, and
+// writes the responses to `out`, one per line. Returns false if any of the
+// requests failed.
+func Send(socketPath string, requests io.Reader, out io.Writer) (bool, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return false, fmt.Errorf("can't connect to lazygit on %s; is it running, with remoteControl.enabled set in its config? (%w)", socketPath, err)
+	}
+	defer conn.Close()
+
+	responses := bufio.NewScanner(conn)
+	requestLines := bufio.NewScanner(requests)
+	succeeded := true
+	for requestLines.Scan() {
+		request := strings.TrimSpace(requestLines.Text())
+		if request == "" {
+			continue
+		}
+
+		if _, err := fmt.Fprintln(conn, request); err != nil {
+			return false, err
+		}
+
+		if !responses.Scan() {
+			if err := responses.Err(); err != nil {
+				return false, err
+			}
+			return false, io.ErrUnexpectedEOF
+		}
+
+		var response Response
+		if err := json.Unmarshal(responses.Bytes(), &response); err != nil {
+			return false, err
+		}
+		if response.Error != "" {
+			succeeded = false
+		}
+
+		if _, err := fmt.Fprintln(out, responses.Text()); err != nil {
+			return false, err
+		}
+	}
+
+	return succeeded, requestLines.Err()
+}
@@ -0,0 +1,186 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/diffing"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/samber/lo"
+)
+
+// The commands that clients can send. See docs/Remote_Control.md for a
+// description of each of them.
+
+type selectCommitResult struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+}
+
+type selectFileResult struct {
+	Path string `json:"path"`
+}
+
+// Replies to a request. Must be called exactly once per request, from any
+// goroutine.
+type replyFunc func(result any, err error)
+
+// Called on the UI thread. Either replies to the request (possibly later, e.g.
+// after loading more commits) or returns an error.
+func (self *Server) dispatch(request *Request, reply replyFunc) error {
+	switch request.Command {
+	case "focus":
+		context, ok := lo.Find(self.c.Contexts().Flatten(), func(context types.Context) bool {
+			return context.GetKind() == types.SIDE_CONTEXT && string(context.GetKey()) == request.Context
+		})
+		if !ok {
+			return fmt.Errorf("unknown context '%s'", request.Context)
+		}
+		self.c.Context().Push(context, types.OnFocusOpts{})
+		reply(nil, nil)
+	case "selectCommit":
+		return self.withCommit(request.Hash, reply, func(index int) {
+			self.selectAndFocus(self.c.Contexts().LocalCommits, index)
+			commit := self.c.Model().Commits[index]
+			reply(selectCommitResult{Hash: commit.Hash(), Subject: commit.Name}, nil)
+		})
+	case "selectFile":
+		path, err := self.repoRelativePath(request.Path)
+		if err != nil {
+			return err
+		}
+		filesContext := self.c.Contexts().Files
+		if !filesContext.SelectPath(path, self.c.UserConfig().Gui.ShowRootItemInFileTree) {
+			return fmt.Errorf("'%s' has no changes", path)
+		}
+		self.selectAndFocus(filesContext, filesContext.GetSelectedLineIdx())
+		reply(selectFileResult{Path: path}, nil)
+	case "diff":
+		if request.From == "" {
+			return errors.New("missing 'from'")
+		}
+		startDiffing := func() {
+			self.c.Modes().Diffing = diffing.New()
+			self.c.Modes().Diffing.Ref = request.From
+			self.c.Refresh(types.RefreshOptions{Mode: types.ASYNC})
+			reply(nil, nil)
+		}
+		if request.To == "" {
+			startDiffing()
+			return nil
+		}
+		// diffing mode diffs against the selection, so select the other ref
+		if _, index, ok := lo.FindIndexOf(self.c.Model().Branches, func(branch *models.Branch) bool {
+			return branch.Name == request.To
+		}); ok {
+			self.selectAndFocus(self.c.Contexts().Branches, index)
+			startDiffing()
+			return nil
+		}
+		return self.withCommit(request.To, reply, func(index int) {
+			self.selectAndFocus(self.c.Contexts().LocalCommits, index)
+			startDiffing()
+		})
+	case "refresh":
+		// nil means everything
+		var scope []types.RefreshableView
+		for _, name := range request.Scope {
+			refreshable, ok := helpers.ScopeFromName(name)
+			if !ok {
+				return fmt.Errorf("unknown scope '%s'", name)
+			}
+			scope = append(scope, refreshable)
+		}
+		self.c.OnWorker(func(gocui.Task) error {
+			self.c.Refresh(types.RefreshOptions{Mode: types.SYNC, Scope: scope})
+			reply(nil, nil)
+			return nil
+		})
+	case "runCustomCommand":
+		// the command may show prompts; we don't wait for the user to answer them
+		if err := self.customCommandsClient.RunByName(request.Name); err != nil {
+			return err
+		}
+		reply(nil, nil)
+	default:
+		return fmt.Errorf("unknown command '%s'", request.Command)
+	}
+
+	return nil
+}
+
+// Calls f with the index of the commit with the given hash (or hash prefix). If
+// the commit is not among the commits loaded so far, loads all commits first.
+func (self *Server) withCommit(hash string, reply replyFunc, f func(index int)) error {
+	if hash == "" {
+		return errors.New("missing commit hash")
+	}
+
+	findCommit := func() (int, bool) {
+		_, index, ok := lo.FindIndexOf(self.c.Model().Commits, func(commit *models.Commit) bool {
+			return strings.HasPrefix(commit.Hash(), hash)
+		})
+		return index, ok
+	}
+	notFoundErr := fmt.Errorf("commit '%s' not found in the current branch", hash)
+
+	if index, ok := findCommit(); ok {
+		f(index)
+		return nil
+	}
+
+	commitsContext := self.c.Contexts().LocalCommits
+	if !commitsContext.GetLimitCommits() {
+		return notFoundErr
+	}
+
+	commitsContext.SetLimitCommits(false)
+	self.c.OnWorker(func(gocui.Task) error {
+		self.c.Refresh(types.RefreshOptions{Mode: types.SYNC, Scope: []types.RefreshableView{types.COMMITS}})
+		self.c.OnUIThread(func() error {
+			if index, ok := findCommit(); ok {
+				f(index)
+			} else {
+				reply(nil, notFoundErr)
+			}
+			return nil
+		})
+		return nil
+	})
+	return nil
+}
+
+func (self *Server) selectAndFocus(context types.IListContext, index int) {
+	context.GetList().SetSelection(index)
+
+	if self.c.Context().Current().GetKey() == context.GetKey() {
+		// pushing the current context does nothing, so we have to render the
+		// new selection ourselves
+		context.HandleFocus(types.OnFocusOpts{})
+	} else {
+		self.c.Context().Push(context, types.OnFocusOpts{})
+	}
+}
+
+// Editors usually know the absolute path of a file, while the files panel
+// works with paths relative to the repo root
+func (self *Server) repoRelativePath(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("missing path")
+	}
+
+	if filepath.IsAbs(path) {
+		relativePath, err := filepath.Rel(self.c.Git().RepoPaths.WorktreePath(), path)
+		if err != nil || strings.HasPrefix(relativePath, "..") {
+			return "", fmt.Errorf("'%s' is not in the repo", path)
+		}
+		path = relativePath
+	}
+
+	return filepath.ToSlash(path), nil
+}
@@ -0,0 +1,195 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/controllers/helpers"
+	"github.com/jesseduffield/lazygit/pkg/gui/services/custom_commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Server is the entry point to this package. If enabled in the user config, it
+// listens on a Unix socket for commands from other programs, e.g. an editor
+// integration, and runs them against the gui. Each line that a client sends is
+// a Request, and gets a Response line in return. See
+// https://github.com/jesseduffield/lazygit/blob/master/docs/Remote_Control.md
+type Server struct {
+	c                    *helpers.HelperCommon
+	customCommandsClient *custom_commands.Client
+
+	listener net.Listener
+}
+
+type Request struct {
+	Command string `json:"command"`
+	// For "focus": the key of a side context, e.g. "localBranches"
+	Context string `json:"context,omitempty"`
+	// For "selectCommit": the hash of the commit, or a prefix of it
+	Hash string `json:"hash,omitempty"`
+	// For "selectFile": the path of the file, relative to the repo root or absolute
+	Path string `json:"path,omitempty"`
+	// For "diff": the refs to diff. If To is empty, the diff is against
+	// whatever is selected, like in diffing mode.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// For "refresh": the names of the things to refresh, e.g. "files". Empty
+	// means everything.
+	Scope []string `json:"scope,omitempty"`
+	// For "runCustomCommand": the description of the custom command
+	Name string `json:"name,omitempty"`
+}
+
+type Response struct {
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func NewServer(
+	c *helpers.HelperCommon,
+	customCommandsClient *custom_commands.Client,
+) *Server {
+	return &Server{
+		c:                    c,
+		customCommandsClient: customCommandsClient,
+	}
+}
+
+// The path of the socket that the server listens on
+func SocketPath(remoteControlConfig config.RemoteControlConfig) (string, error) {
+	if remoteControlConfig.SocketPath != "" {
+		return remoteControlConfig.SocketPath, nil
+	}
+
+	return xdg.RuntimeFile(filepath.Join("lazygit", "remote.sock"))
+}
+
+// Starts listening if remote control is enabled. Failing to listen is not fatal
+// for lazygit, so we only show it in a toast.
+func (self *Server) Start() {
+	if !self.c.UserConfig().RemoteControl.Enabled {
+		return
+	}
+
+	socketPath, err := SocketPath(self.c.UserConfig().RemoteControl)
+	var listener net.Listener
+	if err == nil {
+		listener, err = listen(socketPath)
+	}
+	if err != nil {
+		self.c.ErrorToast(utils.ResolvePlaceholderString(self.c.Tr.RemoteControlFailedToStart, map[string]string{
+			"error": err.Error(),
+		}))
+		return
+	}
+
+	self.listener = listener
+	go utils.Safe(func() { self.serve(listener) })
+}
+
+// Stops listening and removes the socket. The server can't be used anymore
+// afterwards.
+func (self *Server) Stop() {
+	if self.listener != nil {
+		self.listener.Close()
+		self.listener = nil
+	}
+}
+
+func listen(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0o700); err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(socketPath); err == nil {
+		// the socket may be left over from a lazygit that crashed; only if
+		// nobody's listening on it can we take it over
+		if conn, err := net.Dial("unix", socketPath); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another lazygit is already listening on %s", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the user who runs lazygit may control it
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func (self *Server) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				self.c.Log.Error(err)
+			}
+			return
+		}
+
+		go utils.Safe(func() { self.handleConnection(conn) })
+	}
+}
+
+func (self *Server) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var request Request
+		var response Response
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			response.Error = fmt.Sprintf("invalid request: %s", err)
+		} else {
+			response = self.handleRequest(&request)
+		}
+
+		if err := encoder.Encode(response); err != nil {
+			return
+		}
+	}
+}
+
+// Runs the request on the UI thread, because that's where the models and
+// contexts live, and waits for the result
+func (self *Server) handleRequest(request *Request) Response {
+	responseChan := make(chan Response, 1)
+	reply := func(result any, err error) {
+		if err != nil {
+			responseChan <- Response{Error: err.Error()}
+		} else {
+			responseChan <- Response{Result: result}
+		}
+	}
+
+	self.c.OnUIThread(func() error {
+		if err := self.dispatch(request, reply); err != nil {
+			reply(nil, err)
+		}
+		return nil
+	})
+
+	return <-responseChan
+}
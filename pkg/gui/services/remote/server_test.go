@@ -0,0 +1,86 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// socket paths are limited to about 100 characters, which t.TempDir() may
+// exceed on some systems
+func tempSocketPath(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "lg")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "sub", "remote.sock")
+}
+
+func TestListen(t *testing.T) {
+	socketPath := tempSocketPath(t)
+
+	listener, err := listen(socketPath)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(socketPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	_, err = listen(socketPath)
+	assert.EqualError(t, err, fmt.Sprintf("another lazygit is already listening on %s", socketPath))
+
+	listener.Close()
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// a socket left over from a lazygit that crashed is taken over
+	assert.NoError(t, os.WriteFile(socketPath, nil, 0o600))
+	listener, err = listen(socketPath)
+	assert.NoError(t, err)
+	listener.Close()
+}
+
+func TestSend(t *testing.T) {
+	socketPath := tempSocketPath(t)
+	listener, err := listen(socketPath)
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	// fails every request for a command other than "refresh"
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		encoder := json.NewEncoder(conn)
+		for scanner.Scan() {
+			var request Request
+			_ = json.Unmarshal(scanner.Bytes(), &request)
+			if request.Command == "refresh" {
+				_ = encoder.Encode(Response{})
+			} else {
+				_ = encoder.Encode(Response{Error: "unknown command '" + request.Command + "'"})
+			}
+		}
+	}()
+
+	out := &bytes.Buffer{}
+	succeeded, err := Send(socketPath, strings.NewReader("{\"command\": \"refresh\"}\n\n{\"command\": \"fly\"}\n"), out)
+	assert.NoError(t, err)
+	assert.False(t, succeeded)
+	assert.Equal(t, "{}\n{\"error\":\"unknown command 'fly'\"}\n", out.String())
+}
+
+func TestSendWithoutServer(t *testing.T) {
+	_, err := Send(tempSocketPath(t), strings.NewReader("{}"), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "can't connect to lazygit")
+}
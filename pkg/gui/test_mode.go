@@ -51,10 +51,10 @@ func (gui *Gui) handleTestMode() {
 			log.Fatal("gocui should have already exited")
 		}()
 
-		if os.Getenv(components.WAIT_FOR_DEBUGGER_ENV_VAR) == "" {
+		if timeout := test.Timeout(); timeout > 0 && os.Getenv(components.WAIT_FOR_DEBUGGER_ENV_VAR) == "" {
 			go utils.Safe(func() {
-				time.Sleep(time.Second * 40)
-				log.Fatal("40 seconds is up, lazygit recording took too long to complete")
+				time.Sleep(timeout)
+				log.Fatalf("%s is up, lazygit recording took too long to complete", timeout)
 			})
 		}
 	}
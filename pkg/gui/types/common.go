@@ -106,6 +106,10 @@ type IGuiCommon interface {
 
 	ResetKeybindings() error
 
+	// Applies the theme chosen in the app state (or gui.theme if none is
+	// chosen) to all views
+	ApplyTheme()
+
 	// hopefully we can remove this once we've moved all our keybinding stuff out of the gui god struct.
 	GetInitialKeybindingsWithCustomCommands() ([]*Binding, []*gocui.ViewMouseBinding)
 
@@ -156,7 +160,10 @@ type CreateMenuOptions struct {
 	HideCancel                 bool
 	ColumnAlignment            []utils.Alignment
 	AllowFilteringKeybindings  bool
-	KeepConflictingKeybindings bool // if true, the keybindings that match essential bindings such as confirm or return will not be removed from menu items
+	KeepConflictingKeybindings bool   // if true, the keybindings that match essential bindings such as confirm or return will not be removed from menu items
+	OnCancel                   func() // called when the menu is closed without choosing an item
+	StartFiltering             bool   // if true, the filter prompt is opened along with the menu
+	UseFuzzyFilter             bool   // if true, the menu is filtered fuzzily regardless of the gui.filterMode config
 }
 
 type CreatePopupPanelOpts struct {
@@ -254,6 +261,11 @@ type MenuItem struct {
 	// Only applies when Label is used
 	OpensMenu bool
 
+	// If true, the menu stays open when the item is pressed, and is re-rendered
+	// afterwards. Useful for toggling checkboxes; it's up to OnPress to update
+	// the item's Widget.
+	KeepOpen bool
+
 	// If Key is defined it allows the user to press the key to invoke the menu
 	// item, as opposed to having to navigate to it
 	Key Key
@@ -388,6 +400,11 @@ type IRepoStateAccessor interface {
 	GetSearchState() *SearchState
 	SetSplitMainPanel(bool)
 	GetSplitMainPanel() bool
+	// Return the branch/commit to select after loading them, and forget it
+	TakeBranchToSelect() string
+	TakeCommitHashToSelect() string
+	// Overrides git.diffContextSize for the current repo
+	SetDiffContextSize(uint64)
 }
 
 // startup stages so we don't need to load everything at once
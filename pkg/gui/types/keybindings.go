@@ -7,6 +7,10 @@ import (
 
 type Key any // FIXME: find out how to get `gocui.Key | rune`
 
+// A key made up of several keys that have to be pressed one after the other,
+// e.g. "g b". The keys are separated by spaces.
+type KeySequence string
+
 // Binding - a keybinding mapping a key and modifier to a handler. The keypress
 // is only handled if the given view has focus, or handled globally if the view
 // is ""
@@ -49,6 +53,11 @@ type Binding struct {
 	// invoke it. When left nil, the command is always enabled. Note that this
 	// function must not do expensive calls.
 	GetDisabledReason func() *DisabledReason
+
+	// If the binding opens a menu whose items don't depend on the selection,
+	// this returns them, so that the command palette can list them too. The
+	// handler should show the same items.
+	GetMenuItems func() []*MenuItem
 }
 
 func (b *Binding) IsDisabled() bool {
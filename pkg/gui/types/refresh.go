@@ -20,6 +20,7 @@ const (
 	PATCH_BUILDING
 	MERGE_CONFLICTS
 	COMMIT_FILES
+	CUSTOM_PANELS
 	// not actually a view. Will refactor this later
 	BISECT_INFO
 )
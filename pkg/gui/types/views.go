@@ -27,6 +27,12 @@ type Views struct {
 	Confirmation      *gocui.View
 	Prompt            *gocui.View
 	Menu              *gocui.View
+	FilePicker        *gocui.View
+	RepoDashboard     *gocui.View
+	CommandHistory    *gocui.View
+	Hooks             *gocui.View
+	HookOutput        *gocui.View
+	KeySequence       *gocui.View
 	CommitMessage     *gocui.View
 	CommitDescription *gocui.View
 	CommitFiles       *gocui.View
@@ -44,4 +50,7 @@ type Views struct {
 
 	// for playing the easter egg snake game
 	Snake *gocui.View
+
+	// user-defined custom panels, keyed by the panel's key
+	CustomPanels map[string]*gocui.View
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/gui/context"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/samber/lo"
@@ -17,8 +18,18 @@ type viewNameMapping struct {
 }
 
 func (gui *Gui) orderedViews() []*gocui.View {
-	return lo.Map(gui.orderedViewNameMappings(), func(v viewNameMapping, _ int) *gocui.View {
+	// custom panels go first so that the built-in views are initially on top of
+	// them in the windows they share
+	return append(gui.customPanelViews(), lo.Map(gui.orderedViewNameMappings(), func(v viewNameMapping, _ int) *gocui.View {
 		return *v.viewPtr
+	})...)
+}
+
+// the views of the custom panels, in the order in which they are configured
+func (gui *Gui) customPanelViews() []*gocui.View {
+	return lo.FilterMap(gui.c.UserConfig().CustomPanels, func(panel config.CustomPanel, _ int) (*gocui.View, bool) {
+		view, ok := gui.Views.CustomPanels[panel.Key]
+		return view, ok
 	})
 }
 
@@ -66,10 +77,17 @@ func (gui *Gui) orderedViewNameMappings() []viewNameMapping {
 		{viewPtr: &gui.Views.CommitMessage, name: "commitMessage"},
 		{viewPtr: &gui.Views.CommitDescription, name: "commitDescription"},
 		{viewPtr: &gui.Views.Menu, name: "menu"},
+		{viewPtr: &gui.Views.FilePicker, name: "filePicker"},
+		{viewPtr: &gui.Views.RepoDashboard, name: "repoDashboard"},
+		{viewPtr: &gui.Views.CommandHistory, name: "commandHistory"},
+		{viewPtr: &gui.Views.Hooks, name: "hooks"},
+		{viewPtr: &gui.Views.HookOutput, name: "hookOutput"},
 		{viewPtr: &gui.Views.Suggestions, name: "suggestions"},
 		{viewPtr: &gui.Views.Confirmation, name: "confirmation"},
 		{viewPtr: &gui.Views.Prompt, name: "prompt"},
 		{viewPtr: &gui.Views.Tooltip, name: "tooltip"},
+		// shown on top of any other popup, e.g. when typing a key sequence in a menu
+		{viewPtr: &gui.Views.KeySequence, name: "keySequence"},
 
 		// this guy will cover everything else when it appears
 		{viewPtr: &gui.Views.Limit, name: "limit"},
@@ -85,6 +103,10 @@ func (gui *Gui) createAllViews() error {
 		}
 	}
 
+	if err := gui.createCustomPanelViews(); err != nil {
+		return err
+	}
+
 	gui.Views.Options.Frame = false
 
 	gui.Views.SearchPrefix.BgColor = gocui.ColorDefault
@@ -139,6 +161,22 @@ func (gui *Gui) createAllViews() error {
 
 	gui.Views.Menu.Visible = false
 
+	gui.Views.FilePicker.Visible = false
+
+	gui.Views.RepoDashboard.Visible = false
+
+	gui.Views.CommandHistory.Visible = false
+
+	gui.Views.Hooks.Visible = false
+
+	gui.Views.HookOutput.Visible = false
+	gui.Views.HookOutput.Wrap = true
+
+	gui.Views.KeySequence.Visible = false
+	// editable so that its editor receives every key, not just the bound ones
+	gui.Views.KeySequence.Editable = true
+	gui.Views.KeySequence.Editor = gocui.EditorFunc(gui.keySequenceEditor)
+
 	gui.Views.Tooltip.Visible = false
 	gui.Views.Tooltip.AutoRenderHyperLinks = true
 
@@ -155,6 +193,21 @@ func (gui *Gui) createAllViews() error {
 	return nil
 }
 
+// Custom panels are set up from the config at startup; we don't support
+// adding or removing them while lazygit is running.
+func (gui *Gui) createCustomPanelViews() error {
+	gui.Views.CustomPanels = map[string]*gocui.View{}
+	for _, panel := range gui.c.UserConfig().CustomPanels {
+		view, err := gui.prepareView(context.CustomPanelViewName(panel.Key))
+		if err != nil && !errors.Is(err, gocui.ErrUnknownView) {
+			return err
+		}
+		gui.Views.CustomPanels[panel.Key] = view
+	}
+
+	return nil
+}
+
 func (gui *Gui) configureViewProperties() {
 	frameRunes := []rune{'─', '│', '┌', '┐', '└', '┘'}
 	switch gui.c.UserConfig().Gui.Border {
@@ -168,13 +221,13 @@ func (gui *Gui) configureViewProperties() {
 		frameRunes = []rune{'━', '┃', '┏', '┓', '┗', '┛'}
 	}
 
-	for _, mapping := range gui.orderedViewNameMappings() {
-		(*mapping.viewPtr).FrameRunes = frameRunes
-		(*mapping.viewPtr).BgColor = gui.g.BgColor
-		(*mapping.viewPtr).FgColor = theme.GocuiDefaultTextColor
-		(*mapping.viewPtr).SelBgColor = theme.GocuiSelectedLineBgColor
-		(*mapping.viewPtr).SelFgColor = gui.g.SelFgColor
-		(*mapping.viewPtr).InactiveViewSelBgColor = theme.GocuiInactiveViewSelectedLineBgColor
+	for _, view := range gui.orderedViews() {
+		view.FrameRunes = frameRunes
+		view.BgColor = gui.g.BgColor
+		view.FgColor = theme.GocuiDefaultTextColor
+		view.SelBgColor = theme.GocuiSelectedLineBgColor
+		view.SelFgColor = gui.g.SelFgColor
+		view.InactiveViewSelBgColor = theme.GocuiInactiveViewSelectedLineBgColor
 	}
 
 	gui.c.SetViewContent(gui.Views.SearchPrefix, gui.c.Tr.SearchPrefix)
@@ -199,6 +252,12 @@ func (gui *Gui) configureViewProperties() {
 	gui.Views.Extras.Title = gui.c.Tr.CommandLog
 	gui.Views.Snake.Title = gui.c.Tr.SnakeTitle
 
+	for _, panel := range gui.c.UserConfig().CustomPanels {
+		if view, ok := gui.Views.CustomPanels[panel.Key]; ok {
+			view.Title = panel.Title
+		}
+	}
+
 	for _, view := range []*gocui.View{gui.Views.Main, gui.Views.Secondary, gui.Views.Staging, gui.Views.StagingSecondary, gui.Views.PatchBuilding, gui.Views.PatchBuildingSecondary, gui.Views.MergeConflicts} {
 		view.Title = gui.c.Tr.DiffTitle
 		view.CanScrollPastBottom = gui.c.UserConfig().Gui.ScrollPastBottom
@@ -209,6 +268,19 @@ func (gui *Gui) configureViewProperties() {
 	gui.Views.CommitDescription.TextArea.AutoWrap = gui.c.UserConfig().Git.Commit.AutoWrapCommitMessage
 	gui.Views.CommitDescription.TextArea.AutoWrapWidth = gui.c.UserConfig().Git.Commit.AutoWrapWidth
 
+	sideWindowViews := map[string][]*gocui.View{
+		"status":   {gui.Views.Status},
+		"files":    {gui.Views.Files, gui.Views.Worktrees, gui.Views.Submodules},
+		"branches": {gui.Views.Branches, gui.Views.Remotes, gui.Views.Tags},
+		"commits":  {gui.Views.Commits, gui.Views.ReflogCommits},
+		"stash":    {gui.Views.Stash},
+	}
+	for _, panel := range gui.c.UserConfig().CustomPanels {
+		if view, ok := gui.Views.CustomPanels[panel.Key]; ok {
+			sideWindowViews[panel.Window] = append(sideWindowViews[panel.Window], view)
+		}
+	}
+
 	if gui.c.UserConfig().Gui.ShowPanelJumps {
 		keyToTitlePrefix := func(key string) string {
 			if key == "<disabled>" {
@@ -221,37 +293,26 @@ func (gui *Gui) configureViewProperties() {
 			return keyToTitlePrefix(binding)
 		})
 
-		gui.Views.Status.TitlePrefix = jumpLabels[0]
-
-		gui.Views.Files.TitlePrefix = jumpLabels[1]
-		gui.Views.Worktrees.TitlePrefix = jumpLabels[1]
-		gui.Views.Submodules.TitlePrefix = jumpLabels[1]
-
-		gui.Views.Branches.TitlePrefix = jumpLabels[2]
-		gui.Views.Remotes.TitlePrefix = jumpLabels[2]
-		gui.Views.Tags.TitlePrefix = jumpLabels[2]
-
-		gui.Views.Commits.TitlePrefix = jumpLabels[3]
-		gui.Views.ReflogCommits.TitlePrefix = jumpLabels[3]
-
-		gui.Views.Stash.TitlePrefix = jumpLabels[4]
+		// the jump keys go to the shown side windows in order; views shown as
+		// tabs of another window get that window's key
+		layout := gui.c.UserConfig().Gui.SideWindowLayout
+		windowJumpLabels := map[string]string{}
+		for i, windowName := range layout.WindowNames() {
+			windowJumpLabels[windowName] = jumpLabels[i]
+		}
+		for windowName, views := range sideWindowViews {
+			for _, view := range views {
+				view.TitlePrefix = windowJumpLabels[layout.HostWindow(windowName)]
+			}
+		}
 
 		gui.Views.Main.TitlePrefix = keyToTitlePrefix(gui.c.UserConfig().Keybinding.Universal.FocusMainView)
 	} else {
-		gui.Views.Status.TitlePrefix = ""
-
-		gui.Views.Files.TitlePrefix = ""
-		gui.Views.Worktrees.TitlePrefix = ""
-		gui.Views.Submodules.TitlePrefix = ""
-
-		gui.Views.Branches.TitlePrefix = ""
-		gui.Views.Remotes.TitlePrefix = ""
-		gui.Views.Tags.TitlePrefix = ""
-
-		gui.Views.Commits.TitlePrefix = ""
-		gui.Views.ReflogCommits.TitlePrefix = ""
-
-		gui.Views.Stash.TitlePrefix = ""
+		for _, views := range sideWindowViews {
+			for _, view := range views {
+				view.TitlePrefix = ""
+			}
+		}
 
 		gui.Views.Main.TitlePrefix = ""
 	}
@@ -220,6 +220,7 @@ type TranslationSet struct {
 	Apply                                 string
 	StashApplyTooltip                     string
 	NoStashEntries                        string
+	NoItems                               string
 	StashDrop                             string
 	SureDropStashEntry                    string
 	StashPop                              string
@@ -236,9 +237,67 @@ type TranslationSet struct {
 	ForcePush                             string
 	ForcePushPrompt                       string
 	ForcePushDisabled                     string
+	ProtectedBranch                       string
+	ProtectedBranchRestricted             string
+	ProtectedBranchOverridePrompt         string
+	TypeBranchNameToOverride              string
+	ProtectedBranchNameMismatch           string
+	ProtectedBranchForcePush              string
+	ProtectedBranchDirectCommit           string
+	ProtectedBranchReset                  string
+	ProtectedBranchRebase                 string
+	ProtectedBranchDelete                 string
+	HooksTitle                            string
+	HookOutputTitle                       string
+	OpenHooks                             string
+	OpenHooksTooltip                      string
+	NoHooksFound                          string
+	HookEnabled                           string
+	HookDisabled                          string
+	ToggleHookEnabled                     string
+	ToggleHookEnabledTooltip              string
+	EditHook                              string
+	RunHook                               string
+	RunHookTooltip                        string
+	RunningHook                           string
+	HookSucceeded                         string
+	HookFailed                            string
+	HookProducedNoOutput                  string
+	CommandFailedWithHooks                string
+	RetryWithoutHooks                     string
+	RetryWithoutHooksTooltip              string
+	NothingToRetry                        string
 	UpdatesRejected                       string
 	UpdatesRejectedAndForcePushDisabled   string
 	CheckForUpdate                        string
+	SelectTheme                           string
+	ConfiguredTheme                       string
+	ConfiguredThemeTooltip                string
+	OpenRepoDashboard                     string
+	OpenRepoDashboardTooltip              string
+	RepoDashboardTitle                    string
+	LoadingRepoSummary                    string
+	RepoSummaryChangedFiles               string
+	RepoSummaryStashEntries               string
+	OpenRepo                              string
+	FetchAllRepos                         string
+	FetchAllReposTooltip                  string
+	PullAllRepos                          string
+	PullAllReposTooltip                   string
+	NoFastForwardableRepos                string
+	ShowCommandHistory                    string
+	CommandHistoryTitle                   string
+	ViewCommandDetails                    string
+	ViewCommandDetailsTooltip             string
+	CopyCommandToClipboard                string
+	CommandCopiedToClipboard              string
+	RerunCommand                          string
+	RerunCommandTooltip                   string
+	RerunCommandPrompt                    string
+	CommandDetails                        string
+	CommandOutput                         string
+	CommandRanInShell                     string
+	CommandCannotBeRerun                  string
 	CheckingForUpdates                    string
 	UpdateAvailableTitle                  string
 	UpdateAvailable                       string
@@ -341,6 +400,7 @@ type TranslationSet struct {
 	SearchTitle                           string
 	TagsTitle                             string
 	MenuTitle                             string
+	FilePickerTitle                       string
 	CommitMenuTitle                       string
 	RemotesTitle                          string
 	RemoteBranchesTitle                   string
@@ -624,6 +684,9 @@ type TranslationSet struct {
 	NewGitFlowBranchPrompt                string
 	RenameBranchWarning                   string
 	OpenKeybindingsMenu                   string
+	OpenCommandPalette                    string
+	OpenCommandPaletteTooltip             string
+	CommandPalette                        string
 	ResetCherryPick                       string
 	ResetCherryPickShort                  string
 	NextTab                               string
@@ -699,6 +762,9 @@ type TranslationSet struct {
 	ViewCommits                           string
 	MinGitVersionError                    string
 	RunningCustomCommandStatus            string
+	ConfirmSelection                      string
+	PickFile                              string
+	ToggleFileSelection                   string
 	SubmoduleStashAndReset                string
 	AndResetSubmodules                    string
 	EnterSubmoduleTooltip                 string
@@ -782,6 +848,18 @@ type TranslationSet struct {
 	SelectTargetRemote                       string
 	NoValidRemoteName                        string
 	CreatePullRequest                        string
+	PullRequestTitle                         string
+	PullRequestDescription                   string
+	EditPullRequestTitleAndDescription       string
+	PullRequestBaseBranch                    string
+	PullRequestDraft                         string
+	PullRequestReviewers                     string
+	PullRequestReviewersPrompt               string
+	SubmitPullRequest                        string
+	CreatingPullRequestStatus                string
+	PullRequestCreated                       string
+	PullRequestNoBaseBranch                  string
+	NoneValue                                string
 	SelectConfigFile                         string
 	NoConfigFileFoundErr                     string
 	LoadingFileSuggestions                   string
@@ -817,10 +895,23 @@ type TranslationSet struct {
 	CheckoutAutostashPrompt                  string
 	HardResetAutostashPrompt                 string
 	SoftResetPrompt                          string
+	UndoDeleteBranchesPrompt                 string
+	UndoDeleteTagPrompt                      string
+	UndoDropStashesPrompt                    string
+	RedoDeleteBranchesPrompt                 string
+	RedoDeleteTagPrompt                      string
+	RedoDropStashesPrompt                    string
+	StashEntryForRedoNotFound                string
 	UpstreamGone                             string
 	NukeDescription                          string
 	NukeTreeConfirmation                     string
 	DiscardStagedChangesDescription          string
+	RecoverDiscardedChanges                  string
+	RecoverDiscardedChangesTooltip           string
+	NoDiscardSnapshots                       string
+	RestoreAllFiles                          string
+	RestoreFilesFromSnapshotTooltip          string
+	SnapshotHasNoFilesToRestore              string
 	EmptyOutput                              string
 	Patch                                    string
 	CustomPatch                              string
@@ -915,6 +1006,9 @@ type TranslationSet struct {
 	CommandDoesNotSupportOpeningInEditor     string
 	CustomCommands                           string
 	NoApplicableCommandsInThisContext        string
+	CustomCommandConditionNotMet             string
+	PluginFailedToStart                      string
+	RemoteControlFailedToStart               string
 	SelectCommitsOfCurrentBranch             string
 	Actions                                  Actions
 	Bisect                                   Bisect
@@ -1024,6 +1118,12 @@ type Actions struct {
 	Commit                           string
 	Push                             string
 	Pull                             string
+	FetchAllRepos                    string
+	PullAllRepos                     string
+	RerunCommand                     string
+	RunHook                          string
+	EnableHook                       string
+	DisableHook                      string
 	OpenFile                         string
 	StashAllChanges                  string
 	StashAllChangesKeepIndex         string
@@ -1066,6 +1166,7 @@ type Actions struct {
 	DeleteRemoteTag                  string
 	PushTag                          string
 	NukeWorkingTree                  string
+	RestoreDiscardedChanges          string
 	DiscardUnstagedFileChanges       string
 	RemoveUntrackedFiles             string
 	RemoveStagedFiles                string
@@ -1078,6 +1179,7 @@ type Actions struct {
 	OpenMergeTool                    string
 	OpenCommitInBrowser              string
 	OpenPullRequest                  string
+	CreatePullRequest                string
 	StartBisect                      string
 	ResetBisect                      string
 	BisectSkip                       string
@@ -1318,8 +1420,8 @@ func EnglishTranslationSet() *TranslationSet {
 		Undo:                                 "Undo",
 		UndoReflog:                           "Undo",
 		RedoReflog:                           "Redo",
-		UndoTooltip:                          "The reflog will be used to determine what git command to run to undo the last git command. This does not include changes to the working tree; only commits are taken into consideration.",
-		RedoTooltip:                          "The reflog will be used to determine what git command to run to redo the last git command. This does not include changes to the working tree; only commits are taken into consideration.",
+		UndoTooltip:                          "The reflog will be used to determine what git command to run to undo the last git command. This does not include changes to the working tree; only commits, and deletions of branches, tags and stash entries are taken into consideration.",
+		RedoTooltip:                          "The reflog will be used to determine what git command to run to redo the last git command. This does not include changes to the working tree; only commits, and deletions of branches, tags and stash entries are taken into consideration.",
 		UndoMergeResolveTooltip:              "Undo last merge conflict resolution.",
 		DiscardAllTooltip:                    "Discard both staged and unstaged changes in '{{.path}}'.",
 		DiscardUnstagedTooltip:               "Discard unstaged changes in '{{.path}}'.",
@@ -1331,6 +1433,7 @@ func EnglishTranslationSet() *TranslationSet {
 		Apply:                                "Apply",
 		StashApplyTooltip:                    "Apply the stash entry to your working directory.",
 		NoStashEntries:                       "No stash entries",
+		NoItems:                              "No items",
 		StashDrop:                            "Stash drop",
 		SureDropStashEntry:                   "Are you sure you want to drop the selected stash entry(ies)?",
 		StashPop:                             "Stash pop",
@@ -1347,9 +1450,67 @@ func EnglishTranslationSet() *TranslationSet {
 		ForcePush:                            "Force push",
 		ForcePushPrompt:                      "Your branch has diverged from the remote branch. Press {{.cancelKey}} to cancel, or {{.confirmKey}} to force push.",
 		ForcePushDisabled:                    "Your branch has diverged from the remote branch and you've disabled force pushing",
+		ProtectedBranch:                      "Protected branch",
+		ProtectedBranchRestricted:            "{{action}} is not allowed on '{{branch}}' because it matches the protected branch pattern '{{pattern}}'.",
+		ProtectedBranchOverridePrompt:        "Do you want to do it anyway? You will need to type the name of the branch to confirm.",
+		TypeBranchNameToOverride:             "Type '{{branch}}' to confirm",
+		ProtectedBranchNameMismatch:          "The branch name you typed doesn't match, so nothing was done",
+		ProtectedBranchForcePush:             "Force pushing",
+		ProtectedBranchDirectCommit:          "Committing directly",
+		ProtectedBranchReset:                 "Resetting",
+		ProtectedBranchRebase:                "Rebasing",
+		ProtectedBranchDelete:                "Deleting",
+		HooksTitle:                           "Hooks",
+		HookOutputTitle:                      "Hook output",
+		OpenHooks:                            "View hooks",
+		OpenHooksTooltip:                     "List the hooks in the hooks directory of the repo (respecting core.hooksPath), to enable, disable, edit or run them.",
+		NoHooksFound:                         "There are no hooks in {{.hooksPath}}",
+		HookEnabled:                          "enabled",
+		HookDisabled:                         "disabled",
+		ToggleHookEnabled:                    "Enable/disable hook",
+		ToggleHookEnabledTooltip:             "Make the selected hook executable or not executable. Git only runs hooks that are executable.",
+		EditHook:                             "Edit hook",
+		RunHook:                              "Run hook",
+		RunHookTooltip:                       "Run the selected hook against the current state of the repo and show its output.",
+		RunningHook:                          "Running hook",
+		HookSucceeded:                        "Hook '{{.hook}}' succeeded",
+		HookFailed:                           "Hook '{{.hook}}' failed",
+		HookProducedNoOutput:                 "(no output)",
+		CommandFailedWithHooks:               "Failed with hooks enabled: {{.hook}}",
+		RetryWithoutHooks:                    "Retry without hooks",
+		RetryWithoutHooksTooltip:             "Run the command that failed again, skipping the hooks.",
+		NothingToRetry:                       "The hook was run by hand, so there is nothing to retry",
 		UpdatesRejected:                      "Updates were rejected. Please fetch and examine the remote changes before pushing again.",
 		UpdatesRejectedAndForcePushDisabled:  "Updates were rejected and you have disabled force pushing",
 		CheckForUpdate:                       "Check for update",
+		SelectTheme:                          "Select theme",
+		ConfiguredTheme:                      "Configured theme",
+		ConfiguredThemeTooltip:               "The theme from the gui.theme config.",
+		OpenRepoDashboard:                    "Open repo dashboard",
+		OpenRepoDashboardTooltip:             "Show the recent repos and the repos in the configured root directories, with their current branch, changed files, divergence from upstream, stash entries and the time of their last commit.",
+		RepoDashboardTitle:                   "Repositories",
+		LoadingRepoSummary:                   "loading...",
+		RepoSummaryChangedFiles:              "%d changed",
+		RepoSummaryStashEntries:              "%d stashed",
+		OpenRepo:                             "Open repo",
+		FetchAllRepos:                        "Fetch all repos",
+		FetchAllReposTooltip:                 "Fetch in all repos listed in the dashboard. Repos that need credentials for fetching are skipped.",
+		PullAllRepos:                         "Pull all fast-forwardable repos",
+		PullAllReposTooltip:                  "Fast-forward the checked-out branch of every repo whose branch is behind its upstream without being ahead of it.",
+		NoFastForwardableRepos:               "No repo can be fast-forwarded",
+		ShowCommandHistory:                   "Show command history",
+		CommandHistoryTitle:                  "Command history",
+		ViewCommandDetails:                   "View command details",
+		ViewCommandDetailsTooltip:            "Show the time, duration, exit code and (if enabled in the commandHistory config) output of the selected command.",
+		CopyCommandToClipboard:               "Copy command to clipboard",
+		CommandCopiedToClipboard:             "Command copied to clipboard",
+		RerunCommand:                         "Re-run command",
+		RerunCommandTooltip:                  "Run the selected command again with the same arguments and in the same directory.",
+		RerunCommandPrompt:                   "Are you sure you want to run the following command again?\n\n{{.command}}",
+		CommandDetails:                       "Time: {{.time}}\nDuration: {{.duration}}\nExit code: {{.exitCode}}",
+		CommandOutput:                        "Output:",
+		CommandRanInShell:                    "Ran in a shell",
+		CommandCannotBeRerun:                 "This command was saved by an older version of lazygit and can't be re-run.",
 		CheckingForUpdates:                   "Checking for updates...",
 		UpdateAvailableTitle:                 "Update available!",
 		UpdateAvailable:                      "Download and install version {{.newVersion}}?",
@@ -1393,6 +1554,18 @@ func EnglishTranslationSet() *TranslationSet {
 		AllBranchesLogGraphReverse:           `Show/cycle all branch logs (reverse)`,
 		UnsupportedGitService:                `Unsupported git service`,
 		CreatePullRequest:                    `Create pull request`,
+		PullRequestTitle:                     "Pull request title",
+		PullRequestDescription:               "Pull request description",
+		EditPullRequestTitleAndDescription:   "Edit title and description",
+		PullRequestBaseBranch:                "Base branch",
+		PullRequestDraft:                     "Draft",
+		PullRequestReviewers:                 "Reviewers",
+		PullRequestReviewersPrompt:           "Reviewers (comma-separated)",
+		SubmitPullRequest:                    "Submit pull request",
+		CreatingPullRequestStatus:            "Creating pull request",
+		PullRequestCreated:                   "Created pull request %s",
+		PullRequestNoBaseBranch:              "Please select a base branch for the pull request",
+		NoneValue:                            "(none)",
 		CopyPullRequestURL:                   `Copy pull request URL to clipboard`,
 		NoBranchOnRemote:                     `This branch doesn't exist on remote. You need to push it to remote first.`,
 		Fetch:                                `Fetch`,
@@ -1453,6 +1626,7 @@ func EnglishTranslationSet() *TranslationSet {
 		SearchTitle:                          "Search",
 		TagsTitle:                            "Tags",
 		MenuTitle:                            "Menu",
+		FilePickerTitle:                      "File picker",
 		CommitMenuTitle:                      "Commit Menu",
 		RemotesTitle:                         "Remotes",
 		RemoteBranchesTitle:                  "Remote branches",
@@ -1740,6 +1914,9 @@ func EnglishTranslationSet() *TranslationSet {
 		NewBranchNamePrompt:              "Enter new branch name for branch",
 		RenameBranchWarning:              "This branch is tracking a remote. This action will only rename the local branch name, not the name of the remote branch. Continue?",
 		OpenKeybindingsMenu:              "Open keybindings menu",
+		OpenCommandPalette:               "Open command palette",
+		OpenCommandPaletteTooltip:        "Search the actions of all views, and run the chosen one. If it belongs to a different view, that view is focused first.",
+		CommandPalette:                   "Command palette",
 		ResetCherryPick:                  "Reset copied (cherry-picked) commits selection",
 		ResetCherryPickShort:             "Reset copied commits",
 		NextTab:                          "Next tab",
@@ -1816,6 +1993,9 @@ func EnglishTranslationSet() *TranslationSet {
 		ViewCommits:                              "View commits",
 		MinGitVersionError:                       "Git version must be at least %s. Please upgrade your git version.",
 		RunningCustomCommandStatus:               "Running custom command",
+		ConfirmSelection:                         "Confirm selection",
+		PickFile:                                 "Pick file / toggle directory",
+		ToggleFileSelection:                      "Toggle file selection",
 		SubmoduleStashAndReset:                   "Stash uncommitted submodule changes and update",
 		AndResetSubmodules:                       "And reset submodules",
 		Enter:                                    "Enter",
@@ -1930,11 +2110,24 @@ func EnglishTranslationSet() *TranslationSet {
 		RewordInEditorPrompt:                     "Are you sure you want to reword this commit in your editor?",
 		HardResetAutostashPrompt:                 "Are you sure you want to hard reset to '%s'? An auto-stash will be performed if necessary.",
 		SoftResetPrompt:                          "Are you sure you want to soft reset to '%s'?",
+		UndoDeleteBranchesPrompt:                 "Are you sure you want to restore the deleted branch(es) {{branches}}?",
+		UndoDeleteTagPrompt:                      "Are you sure you want to restore the deleted tag {{tag}}?",
+		UndoDropStashesPrompt:                    "Are you sure you want to restore the dropped stash entries?\n\n{{stashEntries}}",
+		RedoDeleteBranchesPrompt:                 "Are you sure you want to delete the branch(es) {{branches}} again?",
+		RedoDeleteTagPrompt:                      "Are you sure you want to delete the tag {{tag}} again?",
+		RedoDropStashesPrompt:                    "Are you sure you want to drop the restored stash entries again?\n\n{{stashEntries}}",
+		StashEntryForRedoNotFound:                "Can't redo dropping the stash entry because it no longer exists",
 		CheckoutAutostashPrompt:                  "Are you sure you want to checkout '%s'? An auto-stash will be performed if necessary.",
 		UpstreamGone:                             "(upstream gone)",
 		NukeDescription:                          "If you want to make all the changes in the worktree go away, this is the way to do it. If there are dirty submodule changes this will stash those changes in the submodule(s).",
 		NukeTreeConfirmation:                     "Are you sure you want to nuke the working tree? This will discard all changes in the worktree (staged, unstaged and untracked), which is not undoable.",
 		DiscardStagedChangesDescription:          "This will create a new stash entry containing only staged files and then drop it, so that the working tree is left with only unstaged changes",
+		RecoverDiscardedChanges:                  "Recover discarded changes",
+		RecoverDiscardedChangesTooltip:           "Restore files from one of the snapshots that are taken automatically before changes are discarded or the working tree is reset. Snapshots are kept according to the git.discardSnapshots config.",
+		NoDiscardSnapshots:                       "There are no snapshots of discarded changes",
+		RestoreAllFiles:                          "Restore all files",
+		RestoreFilesFromSnapshotTooltip:          "Overwrite the files in the working tree with their content from the snapshot. The index is left alone. A snapshot of the current content is taken first, so this can be undone in the same way.",
+		SnapshotHasNoFilesToRestore:              "The snapshot has no files to restore; the changes that were discarded only deleted files.",
 		EmptyOutput:                              "<Empty output>",
 		Patch:                                    "Patch",
 		CustomPatch:                              "Custom patch",
@@ -2027,6 +2220,9 @@ func EnglishTranslationSet() *TranslationSet {
 		CommandDoesNotSupportOpeningInEditor:     "This command doesn't support switching to the editor",
 		CustomCommands:                           "Custom commands",
 		NoApplicableCommandsInThisContext:        "(No applicable commands in this context)",
+		CustomCommandConditionNotMet:             "The condition of this custom command is not met",
+		PluginFailedToStart:                      "Failed to start plugin '%s': %v",
+		RemoteControlFailedToStart:               "Failed to start remote control: {{.error}}",
 		SelectCommitsOfCurrentBranch:             "Select commits of current branch",
 		ViewMergeConflictOptions:                 "View merge conflict options",
 		ViewMergeConflictOptionsTooltip:          "View options for resolving merge conflicts.",
@@ -2101,6 +2297,12 @@ func EnglishTranslationSet() *TranslationSet {
 			Commit:                           "Commit",
 			Push:                             "Push",
 			Pull:                             "Pull",
+			FetchAllRepos:                    "Fetch all repos",
+			PullAllRepos:                     "Pull all fast-forwardable repos",
+			RerunCommand:                     "Re-run command",
+			RunHook:                          "Run hook",
+			EnableHook:                       "Enable hook",
+			DisableHook:                      "Disable hook",
 			OpenFile:                         "Open file",
 			StashAllChanges:                  "Stash all changes",
 			StashAllChangesKeepIndex:         "Stash all changes and keep index",
@@ -2141,6 +2343,7 @@ func EnglishTranslationSet() *TranslationSet {
 			DeleteRemoteTag:                  "Delete remote tag",
 			PushTag:                          "Push tag",
 			NukeWorkingTree:                  "Nuke working tree",
+			RestoreDiscardedChanges:          "Restore discarded changes",
 			DiscardUnstagedFileChanges:       "Discard unstaged file changes",
 			RemoveUntrackedFiles:             "Remove untracked files",
 			RemoveStagedFiles:                "Remove staged files",
@@ -2155,6 +2358,7 @@ func EnglishTranslationSet() *TranslationSet {
 			OpenMergeTool:                    "Open merge tool",
 			OpenCommitInBrowser:              "Open commit in browser",
 			OpenPullRequest:                  "Open pull request in browser",
+			CreatePullRequest:                "Create pull request",
 			StartBisect:                      "Start bisect",
 			ResetBisect:                      "Reset bisect",
 			BisectSkip:                       "Bisect skip",
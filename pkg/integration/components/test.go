@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/git_commands"
 	"github.com/jesseduffield/lazygit/pkg/config"
@@ -21,6 +22,7 @@ const unitTestDescription = "test test"
 const (
 	defaultWidth  = 150
 	defaultHeight = 100
+	timeout       = time.Second * 40
 )
 
 type IntegrationTest struct {
@@ -219,6 +221,10 @@ func (self *IntegrationTest) HeadlessDimensions() (int, int) {
 	return self.width, self.height
 }
 
+func (self *IntegrationTest) Timeout() time.Duration {
+	return timeout
+}
+
 func (self *IntegrationTest) RequiresHeadless() bool {
 	return self.width != 0 && self.height != 0
 }
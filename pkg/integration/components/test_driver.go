@@ -117,6 +117,22 @@ func (self *TestDriver) ExpectToast(matcher *TextMatcher) *TestDriver {
 	return self
 }
 
+// Sends a request to lazygit's remote control socket, like an editor
+// integration would, and checks the response. Remote control must be enabled
+// in the test's config.
+func (self *TestDriver) RemoteControl(request string, matcher *TextMatcher) *TestDriver {
+	self.SetCaption("Sending remote control request")
+	response := self.gui.SendRemoteControlRequest(request)
+	self.matchString(matcher, "Unexpected remote control response",
+		func() string {
+			return response
+		},
+	)
+	self.Wait(self.inputDelay)
+
+	return self
+}
+
 func (self *TestDriver) ExpectClipboard(matcher *TextMatcher) {
 	self.assertWithRetries(func() (bool, string) {
 		text, err := clipboard.ReadAll()
@@ -124,6 +124,30 @@ func (self *Views) Menu() *ViewDriver {
 	return self.regularView("menu")
 }
 
+func (self *Views) FilePicker() *ViewDriver {
+	return self.regularView("filePicker")
+}
+
+func (self *Views) KeySequence() *ViewDriver {
+	return self.regularView("keySequence")
+}
+
+func (self *Views) RepoDashboard() *ViewDriver {
+	return self.regularView("repoDashboard")
+}
+
+func (self *Views) CommandHistory() *ViewDriver {
+	return self.regularView("commandHistory")
+}
+
+func (self *Views) Hooks() *ViewDriver {
+	return self.regularView("hooks")
+}
+
+func (self *Views) HookOutput() *ViewDriver {
+	return self.regularView("hookOutput")
+}
+
 func (self *Views) Confirmation() *ViewDriver {
 	return self.regularView("confirmation")
 }
@@ -155,3 +179,7 @@ func (self *Views) Tooltip() *ViewDriver {
 func (self *Views) Options() *ViewDriver {
 	return self.regularView("options")
 }
+
+func (self *Views) CustomPanel(key string) *ViewDriver {
+	return self.regularView("customPanel:" + key)
+}
@@ -0,0 +1,335 @@
+package script
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/integration/components"
+	integrationTypes "github.com/jesseduffield/lazygit/pkg/integration/types"
+	"github.com/samber/lo"
+	"gopkg.in/yaml.v3"
+)
+
+// A Script is a list of high-level actions that is run against the lazygit gui
+// without a terminal, like an integration test but against the user's own repo
+// and config. It's loaded from a yaml file that is passed via `--script`.
+type Script struct {
+	Steps []Step `yaml:"steps"`
+}
+
+// A Step performs exactly one of the actions below
+type Step struct {
+	// Focuses the side view with the given name, e.g. "localBranches"
+	Focus string `yaml:"focus,omitempty"`
+	// Selects the line of the focused view that contains the given text
+	Select string `yaml:"select,omitempty"`
+	// Presses a key, either given literally (e.g. "c" or "<space>") or as the
+	// name of a keybinding from the config (e.g. "files.commitChanges")
+	Press string `yaml:"press,omitempty"`
+	// Types the given text into the open prompt and confirms it
+	Prompt *PromptStep `yaml:"prompt,omitempty"`
+	// Confirms the open confirmation popup
+	Confirm *PopupStep `yaml:"confirm,omitempty"`
+	// Selects an item of the open menu
+	Menu *MenuStep `yaml:"menu,omitempty"`
+	// Types the given summary into the commit message panel and confirms it
+	CommitMessage string `yaml:"commitMessage,omitempty"`
+	// Asserts on the content of a view
+	Assert *AssertStep `yaml:"assert,omitempty"`
+	// Asserts that a toast containing the given text was shown since the
+	// previous step
+	Toast string `yaml:"toast,omitempty"`
+	// Waits for the given number of milliseconds
+	Wait int `yaml:"wait,omitempty"`
+}
+
+type PopupStep struct {
+	// Text that the title of the popup must contain
+	Title string `yaml:"title"`
+}
+
+type PromptStep struct {
+	Title string `yaml:"title"`
+	Text  string `yaml:"text"`
+}
+
+type MenuStep struct {
+	Title string `yaml:"title"`
+	// Text that the item to select must contain
+	Item string `yaml:"item"`
+}
+
+type AssertStep struct {
+	// The view to assert on; defaults to the focused view
+	View        string `yaml:"view"`
+	Contains    string `yaml:"contains"`
+	NotContains string `yaml:"notContains"`
+	// Text that the selected line must contain
+	Selected string `yaml:"selected"`
+}
+
+// The views that a step can refer to by name
+var views = map[string]func(*components.Views) *components.ViewDriver{
+	"status":         (*components.Views).Status,
+	"files":          (*components.Views).Files,
+	"worktrees":      (*components.Views).Worktrees,
+	"submodules":     (*components.Views).Submodules,
+	"localBranches":  (*components.Views).Branches,
+	"remotes":        (*components.Views).Remotes,
+	"remoteBranches": (*components.Views).RemoteBranches,
+	"tags":           (*components.Views).Tags,
+	"commits":        (*components.Views).Commits,
+	"reflogCommits":  (*components.Views).ReflogCommits,
+	"subCommits":     (*components.Views).SubCommits,
+	"commitFiles":    (*components.Views).CommitFiles,
+	"stash":          (*components.Views).Stash,
+	"main":           (*components.Views).Main,
+	"secondary":      (*components.Views).Secondary,
+}
+
+// The views that can be focused directly; the others are reached by pressing
+// keys in one of these
+var focusableViews = []string{
+	"status", "files", "worktrees", "submodules", "localBranches", "remotes",
+	"tags", "commits", "reflogCommits", "stash",
+}
+
+var _ integrationTypes.IntegrationTest = &Script{}
+
+func Load(path string) (*Script, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return script, nil
+}
+
+func Parse(content []byte) (*Script, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	// catch typos in action names, which would otherwise be silently ignored
+	decoder.KnownFields(true)
+
+	script := &Script{}
+	if err := decoder.Decode(script); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if len(script.Steps) == 0 {
+		return nil, errors.New("script has no steps")
+	}
+
+	defaultKeys := config.GetDefaultConfig().Keybinding
+	for i, step := range script.Steps {
+		if err := step.validate(defaultKeys); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	return script, nil
+}
+
+func (self *Step) validate(keys config.KeybindingConfig) error {
+	actionCount := lo.Count([]bool{
+		self.Focus != "",
+		self.Select != "",
+		self.Press != "",
+		self.Prompt != nil,
+		self.Confirm != nil,
+		self.Menu != nil,
+		self.CommitMessage != "",
+		self.Assert != nil,
+		self.Toast != "",
+		self.Wait != 0,
+	}, true)
+	if actionCount != 1 {
+		return fmt.Errorf("expected exactly one action, got %d", actionCount)
+	}
+
+	switch {
+	case self.Focus != "":
+		if !lo.Contains(focusableViews, self.Focus) {
+			return fmt.Errorf("can't focus view '%s'. Valid views: %s", self.Focus, strings.Join(focusableViews, ", "))
+		}
+	case self.Press != "":
+		if _, err := resolveKey(keys, self.Press); err != nil {
+			return err
+		}
+	case self.Menu != nil:
+		if self.Menu.Item == "" {
+			return errors.New("menu step needs an item")
+		}
+	case self.Assert != nil:
+		if self.Assert.View != "" {
+			if _, ok := views[self.Assert.View]; !ok {
+				return fmt.Errorf("unknown view '%s'. Valid views: %s", self.Assert.View, strings.Join(lo.Keys(views), ", "))
+			}
+		}
+		if self.Assert.Contains == "" && self.Assert.NotContains == "" && self.Assert.Selected == "" {
+			return errors.New("assert step needs one of contains, notContains or selected")
+		}
+	case self.Wait < 0:
+		return errors.New("wait must be positive")
+	}
+
+	return nil
+}
+
+// Returns the key for a keybinding name like "files.commitChanges", or the key
+// itself if it's not a keybinding name
+func resolveKey(keys config.KeybindingConfig, keyOrBinding string) (string, error) {
+	if utf8.RuneCountInString(keyOrBinding) == 1 || strings.HasPrefix(keyOrBinding, "<") {
+		return keyOrBinding, nil
+	}
+
+	section, name, ok := strings.Cut(keyOrBinding, ".")
+	if ok {
+		if sectionValue, ok := fieldByYamlTag(reflect.ValueOf(keys), section); ok {
+			if value, ok := fieldByYamlTag(sectionValue, name); ok {
+				switch value.Kind() {
+				case reflect.String:
+					return value.String(), nil
+				case reflect.Slice:
+					if value.Len() > 0 {
+						return value.Index(0).String(), nil
+					}
+				}
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unknown key or keybinding '%s'", keyOrBinding)
+}
+
+func fieldByYamlTag(value reflect.Value, tag string) (reflect.Value, bool) {
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for i := range value.NumField() {
+		if value.Type().Field(i).Tag.Get("yaml") == tag {
+			return value.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func (self *Script) Run(gui integrationTypes.GuiDriver) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+
+	shell := components.NewShell(pwd, os.Environ(), func(errorMsg string) { gui.Fail(errorMsg) })
+	keys := gui.Keys()
+	t := components.NewTestDriver(gui, shell, keys, components.InputDelay())
+
+	for i, step := range self.Steps {
+		if step.Toast == "" {
+			// Unlike tests, scripts don't need to acknowledge every toast
+			for gui.NextToast() != nil {
+			}
+		}
+
+		t.Log(fmt.Sprintf("Running script step %d", i+1))
+		step.run(t, gui, keys)
+	}
+}
+
+func (self *Step) run(t *components.TestDriver, gui integrationTypes.GuiDriver, keys config.KeybindingConfig) {
+	switch {
+	case self.Focus != "":
+		views[self.Focus](t.Views()).Focus()
+	case self.Select != "":
+		focusedView(t, gui).NavigateToLine(components.Contains(self.Select))
+	case self.Press != "":
+		key, err := resolveKey(keys, self.Press)
+		if err != nil {
+			t.Fail(err.Error())
+		}
+		t.GlobalPress(key)
+	case self.Prompt != nil:
+		t.ExpectPopup().Prompt().
+			Title(components.Contains(self.Prompt.Title)).
+			Clear().
+			Type(self.Prompt.Text).
+			Confirm()
+	case self.Confirm != nil:
+		t.ExpectPopup().Confirmation().
+			Title(components.Contains(self.Confirm.Title)).
+			Confirm()
+	case self.Menu != nil:
+		t.ExpectPopup().Menu().
+			Title(components.Contains(self.Menu.Title)).
+			Select(components.Contains(self.Menu.Item)).
+			Confirm()
+	case self.CommitMessage != "":
+		t.ExpectPopup().CommitMessagePanel().
+			Clear().
+			Type(self.CommitMessage).
+			Confirm()
+	case self.Assert != nil:
+		view := focusedView(t, gui)
+		if self.Assert.View != "" {
+			view = views[self.Assert.View](t.Views())
+		}
+		if self.Assert.Contains != "" {
+			view.Content(components.Contains(self.Assert.Contains))
+		}
+		if self.Assert.NotContains != "" {
+			view.Content(components.DoesNotContain(self.Assert.NotContains))
+		}
+		if self.Assert.Selected != "" {
+			view.SelectedLine(components.Contains(self.Assert.Selected))
+		}
+	case self.Toast != "":
+		t.ExpectToast(components.Contains(self.Toast))
+	case self.Wait != 0:
+		t.Wait(self.Wait)
+	}
+}
+
+func focusedView(t *components.TestDriver, gui integrationTypes.GuiDriver) *components.ViewDriver {
+	viewName := gui.CurrentContext().GetViewName()
+	if getView, ok := views[viewName]; ok {
+		return getView(t.Views())
+	}
+
+	t.Fail(fmt.Sprintf("Can't use the focused view '%s' in a script", viewName))
+	return nil
+}
+
+// Scripts run with the user's config as it is
+func (self *Script) SetupConfig(config *config.AppConfig) {}
+
+// Scripts always run without a terminal
+func (self *Script) RequiresHeadless() bool {
+	return true
+}
+
+func (self *Script) HeadlessDimensions() (int, int) {
+	return 150, 100
+}
+
+func (self *Script) IsDemo() bool {
+	return false
+}
+
+// Scripts can take as long as they need
+func (self *Script) Timeout() time.Duration {
+	return 0
+}
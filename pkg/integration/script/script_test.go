@@ -0,0 +1,97 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		content       string
+		expectedSteps []Step
+		expectedErr   string
+	}{
+		{
+			name: "valid script",
+			content: `
+steps:
+  - focus: localBranches
+  - select: feature
+  - press: universal.remove
+  - menu: { title: Delete, item: Delete local branch }
+  - assert: { view: commits, selected: one }
+  - wait: 100
+`,
+			expectedSteps: []Step{
+				{Focus: "localBranches"},
+				{Select: "feature"},
+				{Press: "universal.remove"},
+				{Menu: &MenuStep{Title: "Delete", Item: "Delete local branch"}},
+				{Assert: &AssertStep{View: "commits", Selected: "one"}},
+				{Wait: 100},
+			},
+		},
+		{
+			name:        "empty script",
+			content:     "",
+			expectedErr: "script has no steps",
+		},
+		{
+			name:        "unknown action",
+			content:     "steps:\n  - click: files\n",
+			expectedErr: "field click not found",
+		},
+		{
+			name:        "several actions in one step",
+			content:     "steps:\n  - focus: files\n    press: c\n",
+			expectedErr: "step 1: expected exactly one action, got 2",
+		},
+		{
+			name:        "view that can't be focused",
+			content:     "steps:\n  - focus: main\n",
+			expectedErr: "step 1: can't focus view 'main'",
+		},
+		{
+			name:        "unknown keybinding",
+			content:     "steps:\n  - wait: 10\n  - press: files.doTheThing\n",
+			expectedErr: "step 2: unknown key or keybinding 'files.doTheThing'",
+		},
+		{
+			name:        "assert without condition",
+			content:     "steps:\n  - assert: { view: files }\n",
+			expectedErr: "step 1: assert step needs one of contains, notContains or selected",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			script, err := Parse([]byte(s.content))
+			if s.expectedErr != "" {
+				assert.ErrorContains(t, err, s.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, s.expectedSteps, script.Steps)
+		})
+	}
+}
+
+func TestResolveKey(t *testing.T) {
+	keys := config.GetDefaultConfig().Keybinding
+	keys.Files.CommitChanges = "C"
+
+	for keyOrBinding, expected := range map[string]string{
+		"c":                   "c",
+		"<space>":             "<space>",
+		"files.commitChanges": "C",
+		// the first of several keys
+		"universal.jumpToBlock": "1",
+	} {
+		key, err := resolveKey(keys, keyOrBinding)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, key)
+	}
+}
@@ -0,0 +1,112 @@
+package branch
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ProtectedBranches = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Try restricted actions on protected branches, one that can't be overridden and one that can after typing the branch name",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().Git.ProtectedBranches = []config.ProtectedBranchConfig{
+			{Pattern: "release/*", NoDelete: true},
+			{Pattern: "master", NoDirectCommit: true, NoReset: true, NoRebase: true, AllowOverride: true},
+		}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.NewBranch("release/1.0")
+		shell.Checkout("master")
+		shell.CreateFileAndAdd("file", "content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Branches().
+			Focus().
+			Lines(
+				Contains("master").IsSelected(),
+				Contains("release/1.0"),
+			).
+			NavigateToLine(Contains("release/1.0")).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Delete branch 'release/1.0'?")).
+					Select(Contains("Delete local branch")).
+					Confirm()
+				t.ExpectPopup().Alert().
+					Title(Equals("Error")).
+					Content(Equals("Deleting is not allowed on 'release/1.0' because it matches the protected branch pattern 'release/*'.")).
+					Confirm()
+			}).
+			Lines(
+				Contains("master"),
+				Contains("release/1.0").IsSelected(),
+			)
+
+		confirmOverride := func() {
+			t.ExpectPopup().Confirmation().
+				Title(Equals("Protected branch")).
+				Content(Equals("Committing directly is not allowed on 'master' because it matches the protected branch pattern 'master'.\n\nDo you want to do it anyway? You will need to type the name of the branch to confirm.")).
+				Confirm()
+		}
+
+		t.Views().Files().
+			Focus().
+			Press(keys.Files.CommitChanges).
+			Tap(func() {
+				confirmOverride()
+				t.ExpectPopup().Prompt().
+					Title(Equals("Type 'master' to confirm")).
+					Type("main").
+					Confirm()
+				t.ExpectPopup().Alert().
+					Title(Equals("Error")).
+					Content(Equals("The branch name you typed doesn't match, so nothing was done")).
+					Confirm()
+			}).
+			Press(keys.Files.CommitChanges).
+			Tap(func() {
+				confirmOverride()
+				t.ExpectPopup().Prompt().
+					Title(Equals("Type 'master' to confirm")).
+					Type("master").
+					Confirm()
+				t.ExpectPopup().CommitMessagePanel().
+					Type("two").
+					Confirm()
+			}).
+			IsEmpty()
+
+		t.Views().Commits().
+			Lines(
+				Contains("two"),
+				Contains("one"),
+			)
+
+		// amending rewrites the head commit, so it counts as rebasing
+		t.Shell().CreateFileAndAdd("file2", "content")
+		t.Views().Files().
+			Press(keys.Universal.Refresh).
+			Lines(
+				Contains("file2"),
+			).
+			Press(keys.Files.AmendLastCommit).
+			Tap(func() {
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Protected branch")).
+					Content(Equals("Rebasing is not allowed on 'master' because it matches the protected branch pattern 'master'.\n\nDo you want to do it anyway? You will need to type the name of the branch to confirm.")).
+					Cancel()
+			}).
+			Lines(
+				Contains("file2"),
+			)
+
+		t.Views().Commits().
+			Lines(
+				Contains("two"),
+				Contains("one"),
+			)
+	},
+})
@@ -27,7 +27,10 @@ var FailHooksThenCommitNoHooks = NewIntegrationTest(NewIntegrationTestArgs{
 			Tap(func() {
 				t.ExpectPopup().CommitMessagePanel().Type("my message").Confirm()
 
-				t.ExpectPopup().Alert().Title(Equals("Error")).Content(Contains("Git command failed")).Confirm()
+				t.Views().HookOutput().
+					IsFocused().
+					Title(Equals("Failed with hooks enabled: pre-commit")).
+					PressEscape()
 			}).
 			Press(keys.Files.CommitChangesWithoutHook).
 			Tap(func() {
@@ -0,0 +1,50 @@
+package commit
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var lintingHook = `#!/bin/sh
+
+echo "lint: line 1 is too long"
+echo "lint: 1 problem found"
+exit 1
+`
+
+var RetryWithoutHooksAfterHookFailure = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Commit with a failing pre-commit hook, see the hook's output and retry without hooks",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFile(".git/hooks/pre-commit", lintingHook)
+		shell.MakeExecutable(".git/hooks/pre-commit")
+
+		shell.CreateFileAndAdd("one", "one")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Files.CommitChanges)
+
+		t.ExpectPopup().CommitMessagePanel().
+			Type("my message").
+			Confirm()
+
+		t.Views().HookOutput().
+			IsFocused().
+			Title(Equals("Failed with hooks enabled: pre-commit")).
+			Content(Equals("lint: line 1 is too long\nlint: 1 problem found")).
+			PressEnter()
+
+		t.Views().Commits().
+			Lines(
+				Contains("my message"),
+			)
+
+		t.Views().Files().
+			IsEmpty()
+	},
+})
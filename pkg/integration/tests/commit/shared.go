@@ -89,10 +89,10 @@ func checkBlockingHook(t *TestDriver, keys config.KeybindingConfig) {
 		Type("Commit should fail").
 		Confirm()
 
-	t.ExpectPopup().Alert().
-		Title(Equals("Error")).
-		Content(Contains("Git command failed.")).
-		Confirm()
+	t.Views().HookOutput().
+		IsFocused().
+		Title(Equals("Failed with hooks enabled: pre-commit")).
+		PressEscape()
 
 	// Clear the message
 	t.Views().Files().
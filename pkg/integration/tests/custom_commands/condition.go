@@ -0,0 +1,89 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var Condition = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Using a custom command that is only available when its condition is met",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.NewBranch("release/1.0")
+		shell.NewBranch("feature")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:            "X",
+				Context:        "localBranches",
+				Command:        "touch published",
+				Description:    "Publish release",
+				Condition:      `{{.SelectedLocalBranch.Name | hasPrefix "release/"}}`,
+				DisabledReason: "{{.SelectedLocalBranch.Name}} is not a release branch",
+			},
+			{
+				Key:         "x",
+				Description: "My Custom Commands",
+				CommandMenu: []config.CustomCommand{
+					{
+						Key:       "1",
+						Context:   "localBranches",
+						Command:   "touch myfile-rebasing",
+						Condition: "{{.WorkingTreeState.Rebasing}}",
+					},
+				},
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Branches().
+			Focus().
+			NavigateToLine(Contains("feature")).
+			Press("X")
+
+		t.ExpectToast(Equals("Disabled: feature is not a release branch"))
+
+		t.Views().Branches().
+			Press(keys.Universal.OptionMenu)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Keybindings")).
+			Filter("Publish release").
+			Select(Contains("Publish release")).
+			Confirm()
+
+		t.ExpectToast(Equals("Disabled: feature is not a release branch"))
+
+		// the first escape clears the filter, the second one closes the menu
+		t.ExpectPopup().Menu().
+			Title(Equals("Keybindings")).
+			Cancel()
+		t.ExpectPopup().Menu().
+			Title(Equals("Keybindings")).
+			Cancel()
+
+		t.Views().Branches().
+			NavigateToLine(Contains("release/1.0")).
+			Press("X")
+
+		t.Views().Files().
+			Lines(
+				Contains("published"),
+			)
+
+		t.Views().Branches().
+			Press("x")
+
+		t.ExpectPopup().Menu().
+			Title(Equals("My Custom Commands")).
+			Lines(
+				Contains("touch myfile-rebasing"),
+			).
+			Confirm()
+
+		t.ExpectToast(Equals("Disabled: The condition of this custom command is not met"))
+	},
+})
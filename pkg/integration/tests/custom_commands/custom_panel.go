@@ -0,0 +1,58 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CustomPanel = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show the output of a command in a custom panel and run a custom command on its items",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("blah")
+		shell.CreateFile("issues.txt", "12 Fix the frobnicator\nnot an issue\n34 Add a widget\n")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomPanels = []config.CustomPanel{
+			{
+				Key:         "issues",
+				Title:       "Issues",
+				Window:      "branches",
+				Command:     "cat issues.txt",
+				Filter:      `^(?P<id>\d+) (?P<title>.*)$`,
+				ValueFormat: "{{ .id }}",
+				LabelFormat: "#{{ .id }}: {{ .title }}",
+				Preview:     "echo issue {{.SelectedCustomItem.Value}}",
+			},
+		}
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:     "X",
+				Context: "issues",
+				Command: `echo "{{.SelectedCustomItem.Value}} {{.SelectedCustomItem.Fields.title}}" > result.txt`,
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Tags().Focus().
+			Press(keys.Universal.NextTab)
+
+		t.Views().CustomPanel("issues").
+			IsFocused().
+			Title(Contains("Issues")).
+			Lines(
+				Equals("#12: Fix the frobnicator").IsSelected(),
+				Equals("#34: Add a widget"),
+			)
+
+		t.Views().Main().Content(Contains("issue 12"))
+
+		t.Views().CustomPanel("issues").
+			NavigateToLine(Contains("#34")).
+			Press("X")
+
+		t.Views().Main().Content(Contains("issue 34"))
+		t.FileSystem().FileContent("result.txt", Equals("34 Add a widget\n"))
+	},
+})
@@ -0,0 +1,115 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var FilePicker = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Using a custom command with file picker prompts",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("dir/a.txt", "a")
+		shell.CreateFileAndAdd("dir/b.txt", "b")
+		shell.CreateFileAndAdd("top.txt", "top")
+		shell.Commit("first")
+		shell.CreateFileAndAdd("later.txt", "later")
+		shell.Commit("second")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:     "a",
+				Context: "files",
+				Command: `echo {{.Form.File | quote}} > picked`,
+				Prompts: []config.CustomCommandPrompt{
+					{
+						Type:  "filePicker",
+						Title: "Pick a file",
+						Key:   "File",
+					},
+				},
+			},
+			{
+				Key:     "b",
+				Context: "files",
+				Command: `echo {{.Form.Files | join " "}} > picked`,
+				Prompts: []config.CustomCommandPrompt{
+					{
+						Type:          "filePicker",
+						Title:         "Pick files",
+						Key:           "Files",
+						Ref:           "HEAD~1",
+						AllowMultiple: true,
+					},
+				},
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press("a")
+
+		t.Views().FilePicker().
+			IsFocused().
+			Title(Equals("Pick a file")).
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  ▼ dir"),
+				Equals("      a.txt"),
+				Equals("      b.txt"),
+				Equals("    later.txt"),
+				Equals("    top.txt"),
+			).
+			NavigateToLine(Contains("dir")).
+			// collapse the directory
+			PressEnter().
+			Lines(
+				Equals("▼ /"),
+				Equals("  ▶ dir").IsSelected(),
+				Equals("    later.txt"),
+				Equals("    top.txt"),
+			).
+			NavigateToLine(Contains("later.txt")).
+			PressEnter()
+
+		t.FileSystem().FileContent("picked", Equals("later.txt\n"))
+
+		t.Views().Files().
+			IsFocused().
+			Press("b")
+
+		t.Views().FilePicker().
+			IsFocused().
+			Title(Equals("Pick files")).
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  ▼ dir"),
+				Equals("      a.txt"),
+				Equals("      b.txt"),
+				Equals("    top.txt"),
+			).
+			NavigateToLine(Contains("dir")).
+			PressPrimaryAction().
+			Lines(
+				Equals("▼ /"),
+				Equals("  ▼ dir").IsSelected(),
+				Equals("    ● a.txt"),
+				Equals("    ● b.txt"),
+				Equals("    top.txt"),
+			).
+			// deselect one of them again
+			NavigateToLine(Contains("a.txt")).
+			PressPrimaryAction().
+			FilterOrSearch("top").
+			Lines(
+				Equals("  top.txt").IsSelected(),
+			).
+			PressPrimaryAction().
+			PressEnter()
+
+		t.FileSystem().FileContent("picked", Equals("dir/b.txt top.txt\n"))
+	},
+})
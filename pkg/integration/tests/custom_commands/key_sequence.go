@@ -0,0 +1,95 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var KeySequence = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Invoke custom commands that are bound to key sequences",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:         "X a",
+				Context:     "global",
+				Command:     "touch a.txt",
+				Description: "Create a",
+			},
+			{
+				Key:         "X b b",
+				Context:     "global",
+				Command:     "touch b.txt",
+				Description: "Create b",
+			},
+			{
+				Key:         "X c",
+				Context:     "files",
+				Command:     "touch c.txt",
+				Description: "Create c",
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press("X")
+
+		t.Views().KeySequence().
+			IsFocused().
+			Title(Equals("X")).
+			Lines(
+				Contains("a").Contains("Create a"),
+				Contains("b b").Contains("Create b"),
+				Contains("c").Contains("Create c"),
+			).
+			Press("b")
+
+		t.Views().KeySequence().
+			IsFocused().
+			Title(Equals("X b")).
+			Lines(
+				Contains("b").Contains("Create b"),
+			).
+			Press("b")
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("b.txt"),
+			)
+
+		t.Views().Commits().
+			Focus().
+			Press("X")
+
+		// the files-only sequence isn't offered here
+		t.Views().KeySequence().
+			IsFocused().
+			Lines(
+				Contains("a").Contains("Create a"),
+				Contains("b b").Contains("Create b"),
+			).
+			// a key that doesn't continue any of the sequences cancels
+			Press("z")
+
+		t.Views().Commits().
+			IsFocused().
+			Press("X")
+
+		t.Views().KeySequence().
+			IsFocused().
+			Press("a")
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Contains("a.txt"),
+				Contains("b.txt"),
+			)
+	},
+})
@@ -0,0 +1,96 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var MultiSelect = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Using the multiSelect and multiSelectFromCommand prompt types",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.
+			EmptyCommit("initial commit").
+			NewBranch("feature/one").
+			NewBranch("feature/two").
+			NewBranch("feature/three")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:     "a",
+				Context: "files",
+				Command: `echo "{{range .Form.Branches}}<{{.}}>{{end}} {{.Form.Flavours | join ","}}" > result.txt`,
+				Prompts: []config.CustomCommandPrompt{
+					{
+						Key:     "Branches",
+						Type:    "multiSelectFromCommand",
+						Title:   "Choose branches",
+						Command: `git branch --format='%(refname:short)' --list 'feature/*'`,
+					},
+					{
+						Key:   "Flavours",
+						Type:  "multiSelect",
+						Title: "Choose flavours",
+						Options: []config.CustomCommandMenuOption{
+							{Value: "vanilla"},
+							{Value: "chocolate", Key: "c"},
+							{Value: "strawberry"},
+						},
+					},
+				},
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsEmpty().
+			IsFocused().
+			Press("a")
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Choose branches")).
+			Lines(
+				Contains("Confirm selection"),
+				Contains("[ ] feature/one"),
+				Contains("[ ] feature/three"),
+				Contains("[ ] feature/two"),
+				Contains("Cancel"),
+			).
+			Select(Contains("feature/two")).
+			Confirm().
+			Select(Contains("feature/one")).
+			Confirm().
+			Lines(
+				Contains("Confirm selection"),
+				Contains("[✓] feature/one").IsSelected(),
+				Contains("[ ] feature/three"),
+				Contains("[✓] feature/two"),
+				Contains("Cancel"),
+			).
+			Select(Contains("Confirm selection")).
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Choose flavours"))
+
+		t.Views().Menu().Press("c")
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Choose flavours")).
+			Select(Contains("strawberry")).
+			Confirm().
+			Lines(
+				Contains("Confirm selection"),
+				Contains("[ ] vanilla"),
+				Contains("[✓] chocolate"),
+				Contains("[✓] strawberry").IsSelected(),
+				Contains("Cancel"),
+			).
+			Select(Contains("Confirm selection")).
+			Confirm()
+
+		t.FileSystem().FileContent("result.txt", Equals("<feature/one><feature/two> chocolate,strawberry\n"))
+	},
+})
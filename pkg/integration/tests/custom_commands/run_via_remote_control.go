@@ -0,0 +1,57 @@
+package custom_commands
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RunViaRemoteControl = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Run a custom command via remote control, which is refused when its condition isn't met",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.NewBranch("release/1.0")
+		shell.NewBranch("feature")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		socketPath, _ := filepath.Abs("../remote.sock")
+		cfg.GetUserConfig().RemoteControl.Enabled = true
+		cfg.GetUserConfig().RemoteControl.SocketPath = socketPath
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:            "X",
+				Context:        "localBranches",
+				Command:        "touch published",
+				Description:    "Publish release",
+				Condition:      `{{.SelectedLocalBranch.Name | hasPrefix "release/"}}`,
+				DisabledReason: "{{.SelectedLocalBranch.Name}} is not a release branch",
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.RemoteControl(`{"command": "focus", "context": "localBranches"}`, Equals(`{}`))
+
+		t.Views().Branches().
+			IsFocused().
+			Lines(
+				Contains("feature").IsSelected(),
+				Contains("master"),
+				Contains("release/1.0"),
+			)
+
+		t.RemoteControl(`{"command": "runCustomCommand", "name": "Publish release"}`,
+			Equals(`{"error":"feature is not a release branch"}`))
+
+		t.FileSystem().PathNotPresent("published")
+
+		t.Views().Branches().
+			NavigateToLine(Contains("release/1.0"))
+
+		t.RemoteControl(`{"command": "runCustomCommand", "name": "Publish release"}`, Equals(`{}`))
+
+		t.FileSystem().PathPresent("published")
+	},
+})
@@ -0,0 +1,86 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SelectedItems = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Use the lists of selected items in a range selection",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.CreateNCommits(3)
+		shell.NewBranch("branch-a")
+		shell.NewBranch("branch-b")
+		shell.CreateDir("dir")
+		shell.CreateFile("dir/file1", "")
+		shell.CreateFile("dir/file2", "")
+		shell.CreateFile("file3", "")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:     "X",
+				Context: "commits",
+				Command: `echo "{{range .SelectedCommits}}{{.Name}},{{end}} {{(first .SelectedCommits).Name}} {{(last .SelectedCommits).Name}}" > result.txt`,
+			},
+			{
+				Key:     "X",
+				Context: "files",
+				Command: `echo "{{range .SelectedFiles}}{{.Name}},{{end}} {{.SelectedPaths | join ","}}" > result.txt`,
+			},
+			{
+				Key:     "X",
+				Context: "localBranches",
+				Command: `echo "{{range .SelectedLocalBranches}}{{.Name}},{{end}}" > result.txt`,
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().Focus().
+			Lines(
+				Contains("commit 03").IsSelected(),
+				Contains("commit 02"),
+				Contains("commit 01"),
+			).
+			Press("X")
+
+		t.FileSystem().FileContent("result.txt", Equals("commit 03, commit 03 commit 03\n"))
+
+		t.Views().Commits().
+			Press(keys.Universal.RangeSelectDown).
+			Press(keys.Universal.RangeSelectDown).
+			Press("X")
+
+		t.FileSystem().FileContent("result.txt", Equals("commit 03,commit 02,commit 01, commit 03 commit 01\n"))
+
+		t.Views().Files().Focus().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("  ▼ dir"),
+				Equals("    ?? file1"),
+				Equals("    ?? file2"),
+				Equals("  ?? file3"),
+				Equals("  ?? result.txt"),
+			).
+			NavigateToLine(Contains("dir")).
+			Press(keys.Universal.RangeSelectDown).
+			Press(keys.Universal.RangeSelectDown).
+			Press(keys.Universal.RangeSelectDown).
+			Press("X")
+
+		t.FileSystem().FileContent("result.txt", Equals("dir/file1,dir/file2,file3, dir,dir/file1,dir/file2,file3\n"))
+
+		t.Views().Branches().Focus().
+			Lines(
+				Contains("branch-b").IsSelected(),
+				Contains("branch-a"),
+				Contains("master"),
+			).
+			Press(keys.Universal.RangeSelectDown).
+			Press("X")
+
+		t.FileSystem().FileContent("result.txt", Equals("branch-b,branch-a,\n"))
+	},
+})
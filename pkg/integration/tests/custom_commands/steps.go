@@ -0,0 +1,95 @@
+package custom_commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var Steps = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Using a custom command with multiple steps, one of which fails",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("blah")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CustomCommands = []config.CustomCommand{
+			{
+				Key:     "a",
+				Context: "files",
+				Steps: []config.CustomCommandStep{
+					{
+						Key:     "FileName",
+						Command: "echo '  myfile  '",
+					},
+					{
+						Prompt: &config.CustomCommandPrompt{
+							Type:         "input",
+							Title:        "Content of {{.Steps.FileName.Output}}",
+							Key:          "Content",
+							InitialValue: "hello",
+						},
+					},
+					{
+						Command: `echo "{{.Form.Content}}" > {{.Steps.FileName.Output}}`,
+					},
+				},
+			},
+			{
+				Key:     "b",
+				Context: "files",
+				Steps: []config.CustomCommandStep{
+					{
+						Key:     "FileName",
+						Command: "echo otherfile",
+					},
+					{
+						Command: "exit 1",
+						OnError: &config.CustomCommandStep{
+							Command: "touch {{.Steps.FileName.Output}}",
+						},
+						ErrorMessage: "Could not finish {{.Steps.FileName.Output}}",
+					},
+					{
+						Command: "touch unreachable",
+					},
+				},
+			},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsEmpty().
+			IsFocused().
+			Press("a")
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Content of myfile")).
+			InitialText(Equals("hello")).
+			Clear().
+			Type("from steps").
+			Confirm()
+
+		t.Views().Files().
+			Lines(
+				Contains("myfile").IsSelected(),
+			)
+
+		t.Views().Main().Content(Contains("from steps"))
+
+		t.Views().Files().
+			Press("b")
+
+		t.ExpectPopup().Alert().
+			Title(Equals("Error")).
+			Content(Equals("Could not finish otherfile")).
+			Confirm()
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Contains("myfile").IsSelected(),
+				Contains("otherfile"),
+			)
+	},
+})
@@ -0,0 +1,99 @@
+package file
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RecoverDiscardedChanges = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Recover changes from the snapshot that was taken when nuking the working tree, first a single file and then all of them",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+		config.GetUserConfig().Gui.AnimateExplosion = false
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file", "original content")
+		shell.Commit("first commit")
+
+		shell.UpdateFile("file", "new content")
+		shell.CreateFile("untracked", "untracked content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /").IsSelected(),
+				Equals("   M file"),
+				Equals("  ?? untracked"),
+			).
+			Press(keys.Files.ViewResetOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("")).Select(Contains("Nuke working tree")).Confirm()
+		t.ExpectPopup().Confirmation().Title(Equals("Nuke working tree")).Content(Contains("Are you sure")).Confirm()
+
+		t.Views().Files().
+			IsEmpty().
+			Press(keys.Files.ViewResetOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("")).Select(Contains("Recover discarded changes")).Confirm()
+		t.ExpectPopup().Menu().Title(Equals("Recover discarded changes")).
+			Lines(
+				Contains("nuke working tree").IsSelected(),
+				Contains("Cancel"),
+			).
+			Confirm()
+		t.ExpectPopup().Menu().Title(Equals("nuke working tree")).
+			Lines(
+				Contains("Restore all files").IsSelected(),
+				Contains("file"),
+				Contains("untracked"),
+				Contains("Cancel"),
+			).
+			Select(Contains("untracked")).
+			Confirm()
+
+		t.Views().Files().
+			Lines(
+				Equals("?? untracked"),
+			)
+		t.FileSystem().FileContent("untracked", Equals("untracked content"))
+
+		t.Views().Files().
+			Press(keys.Files.ViewResetOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("")).Select(Contains("Recover discarded changes")).Confirm()
+		// the working tree was clean when the file was restored, so there was
+		// nothing to take a snapshot of
+		t.ExpectPopup().Menu().Title(Equals("Recover discarded changes")).
+			Lines(
+				Contains("nuke working tree").IsSelected(),
+				Contains("Cancel"),
+			).
+			Confirm()
+		t.ExpectPopup().Menu().Title(Equals("nuke working tree")).
+			Select(Contains("Restore all files")).
+			Confirm()
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Equals("   M file"),
+				Equals("  ?? untracked").IsSelected(),
+			)
+		t.FileSystem().FileContent("file", Equals("new content"))
+
+		// restoring all files took another snapshot, since it could have
+		// overwritten the untracked file
+		t.Views().Files().
+			Press(keys.Files.ViewResetOptions)
+
+		t.ExpectPopup().Menu().Title(Equals("")).Select(Contains("Recover discarded changes")).Confirm()
+		t.ExpectPopup().Menu().Title(Equals("Recover discarded changes")).
+			Lines(
+				Contains("restore files from snapshot").IsSelected(),
+				Contains("nuke working tree"),
+				Contains("Cancel"),
+			)
+	},
+})
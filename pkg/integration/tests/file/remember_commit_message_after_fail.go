@@ -39,7 +39,10 @@ var RememberCommitMessageAfterFail = NewIntegrationTest(NewIntegrationTestArgs{
 			Tap(func() {
 				t.ExpectPopup().CommitMessagePanel().Type("my message").Confirm()
 
-				t.ExpectPopup().Alert().Title(Equals("Error")).Content(Contains("Git command failed")).Confirm()
+				t.Views().HookOutput().
+					IsFocused().
+					Title(Equals("Failed with hooks enabled: pre-commit")).
+					PressEscape()
 			}).
 			NavigateToLine(Contains("bad")).
 			Press(keys.Universal.Remove). // remove file that triggers pre-commit hook to fail
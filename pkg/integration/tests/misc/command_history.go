@@ -0,0 +1,80 @@
+package misc
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// We're emulating the clipboard by writing to a file called clipboard
+
+var CommandHistory = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "View the commands that were run in the command history, copy one of them and run it again",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().CommandHistory.SaveOutput = true
+		cfg.GetUserConfig().OS.CopyToClipboardCmd = "printf '%s' {{text}} > clipboard"
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.CreateFile("file", "content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("?? file"),
+			).
+			PressPrimaryAction().
+			Lines(
+				Equals("A  file"),
+			).
+			PressPrimaryAction().
+			Lines(
+				Equals("?? file"),
+			)
+
+		t.GlobalPress(keys.Universal.ExtrasMenu)
+		t.ExpectPopup().Menu().Title(Equals("Command log")).
+			Select(Contains("Show command history")).
+			Confirm()
+
+		t.Views().CommandHistory().
+			IsFocused().
+			Title(Equals("Command history")).
+			TopLines(
+				Contains("✓").Contains("git rm --cached --force -- file").IsSelected(),
+				Contains("✓").Contains("git add -- file"),
+			).
+			NavigateToLine(Contains("git add -- file")).
+			PressEnter()
+
+		t.ExpectPopup().Alert().
+			Title(Equals("git add -- file")).
+			Content(Contains("Exit code: 0")).
+			Confirm()
+
+		t.Views().CommandHistory().
+			IsFocused().
+			Press(keys.Universal.CopyToClipboard)
+
+		t.ExpectToast(Equals("Command copied to clipboard"))
+		t.FileSystem().FileContent("clipboard", Equals("git add -- file"))
+
+		t.Views().CommandHistory().
+			Press(keys.Universal.Select)
+
+		t.ExpectPopup().Confirmation().
+			Title(Equals("Re-run command")).
+			Content(Contains("git add -- file")).
+			Confirm()
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("▼ /"),
+				Equals("  ?? clipboard"),
+				Equals("  A  file"),
+			)
+	},
+})
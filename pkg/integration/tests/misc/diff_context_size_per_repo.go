@@ -0,0 +1,58 @@
+package misc
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var DiffContextSizePerRepo = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "The diff context size that was changed in one repo doesn't affect other repos, and is restored when returning to the repo",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		repo, _ := filepath.Abs(".")
+		otherRepo, _ := filepath.Abs("../other")
+		diffContextSize := uint64(5)
+		appState := cfg.GetAppState()
+		appState.RecentRepos = []string{otherRepo}
+		appState.RepoSessions = map[string]*config.RepoSession{
+			repo: {
+				DiffContextSize: &diffContextSize,
+			},
+		}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.CloneNonBare("other")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Universal.IncreaseContextInDiffView)
+		t.ExpectToast(Equals("Changed diff context size to 6"))
+
+		t.GlobalPress(keys.Universal.OpenRecentRepos)
+		t.ExpectPopup().Menu().Title(Equals("Recent repositories")).
+			Select(Contains("other")).
+			Confirm()
+
+		t.Views().Status().Content(Contains("other → master"))
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Universal.IncreaseContextInDiffView)
+		t.ExpectToast(Equals("Changed diff context size to 4"))
+
+		t.GlobalPress(keys.Universal.OpenRecentRepos)
+		t.ExpectPopup().Menu().Title(Equals("Recent repositories")).
+			Select(Contains("repo")).
+			Confirm()
+
+		t.Views().Status().Content(Contains("repo → master"))
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Universal.DecreaseContextInDiffView)
+		t.ExpectToast(Equals("Changed diff context size to 5"))
+	},
+})
@@ -0,0 +1,64 @@
+package misc
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RestoreRepoSession = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Restore the state of the gui that was saved when a repo was last left, on startup and when switching repos",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		repo, _ := filepath.Abs(".")
+		otherRepo, _ := filepath.Abs("../other")
+		appState := cfg.GetAppState()
+		appState.RecentRepos = []string{otherRepo}
+		appState.RepoSessions = map[string]*config.RepoSession{
+			repo: {
+				Context:        "localBranches",
+				SelectedBranch: "branch-b",
+				CollapsedPaths: []string{"./dir"},
+			},
+			otherRepo: {
+				Context: "stash",
+			},
+		}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.NewBranch("branch-a")
+		shell.NewBranch("branch-b")
+		shell.NewBranch("branch-c")
+		shell.CloneNonBare("other")
+		shell.CreateFile("dir/file", "content")
+		shell.CreateFile("file", "content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Branches().
+			IsFocused().
+			Lines(
+				Contains("branch-c"),
+				Contains("branch-a"),
+				Contains("branch-b").IsSelected(),
+				Contains("master"),
+			)
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Equals("  ▶ dir"),
+				Equals("  ?? file"),
+			)
+
+		t.GlobalPress(keys.Universal.OpenRecentRepos)
+		t.ExpectPopup().Menu().Title(Equals("Recent repositories")).
+			Select(Contains("other")).
+			Confirm()
+
+		t.Views().Status().Content(Contains("other → branch-c"))
+		t.Views().Stash().IsFocused()
+	},
+})
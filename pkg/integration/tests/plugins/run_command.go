@@ -0,0 +1,66 @@
+package plugins
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+// A plugin written in plain sh: it registers a command, and when that command
+// is run it asks for the session state, prompts the user, writes a file, and
+// asks lazygit to refresh the files.
+var pluginScript = `
+read -r line
+printf '%s\n' '{"jsonrpc":"2.0","id":1,"result":{"commands":[{"id":"greet","key":"X","context":"files","description":"Greet"}]}}'
+while read -r line; do
+  id=$(printf '%s' "$line" | sed -n 's/^{"jsonrpc":"2.0","id":\([0-9]*\),"method":"runCommand".*/\1/p')
+  [ -z "$id" ] && continue
+  printf '%s\n' '{"jsonrpc":"2.0","id":"s","method":"getSessionState"}'
+  read -r state
+  path=$(printf '%s' "$state" | sed -n 's/.*"SelectedPath":"\([^"]*\)".*/\1/p')
+  printf '%s\n' '{"jsonrpc":"2.0","id":"p","method":"prompt","params":{"title":"Name"}}'
+  read -r answer
+  name=$(printf '%s' "$answer" | sed -n 's/.*"value":"\([^"]*\)".*/\1/p')
+  echo "hello $name from $path" > greeting.txt
+  printf '%s\n' '{"jsonrpc":"2.0","id":"r","method":"refresh","params":{"scope":["files"]}}'
+  read -r ignored
+  printf '{"jsonrpc":"2.0","id":%s,"result":null}\n' "$id"
+done
+`
+
+var RunCommand = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Run a command registered by a plugin, which talks to lazygit via JSON-RPC",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("blah")
+		shell.CreateFile(".git/plugin.sh", pluginScript)
+		shell.CreateFile("myfile", "")
+	},
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().Plugins = []config.PluginConfig{
+			{Name: "greeter", Command: "sh .git/plugin.sh"},
+		}
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("myfile").IsSelected(),
+			).
+			Press("X")
+
+		t.ExpectPopup().Prompt().
+			Title(Equals("Name")).
+			Type("world").
+			Confirm()
+
+		t.Views().Files().
+			Lines(
+				Equals("▼ /"),
+				Contains("greeting.txt"),
+				Contains("myfile"),
+			)
+
+		t.FileSystem().FileContent("greeting.txt", Equals("hello world from myfile\n"))
+	},
+})
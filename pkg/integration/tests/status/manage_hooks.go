@@ -0,0 +1,69 @@
+package status
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var ManageHooks = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "List the hooks in the directory configured by core.hooksPath, enable, disable and run them",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.SetConfig("core.hooksPath", ".githooks")
+		shell.CreateFile(".githooks/pre-commit", "#!/bin/sh\necho \"checking $(pwd | xargs basename)\"\n")
+		shell.MakeExecutable(".githooks/pre-commit")
+		shell.CreateFile(".githooks/commit-msg", "#!/bin/sh\necho \"message file: $(basename $1)\"\nexit 1\n")
+		shell.CreateFile(".githooks/pre-commit.sample", "#!/bin/sh\n")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Status().
+			Focus().
+			Press(keys.Status.Hooks)
+
+		t.Views().Hooks().
+			IsFocused().
+			Title(Equals("Hooks")).
+			Lines(
+				Equals("✓ enabled  pre-commit").IsSelected(),
+				Equals("✗ disabled commit-msg"),
+			).
+			PressEnter()
+
+		t.Views().HookOutput().
+			IsFocused().
+			Title(Equals("Hook 'pre-commit' succeeded")).
+			Content(Equals("checking repo")).
+			PressEscape()
+
+		t.Views().Hooks().
+			IsFocused().
+			PressPrimaryAction().
+			Lines(
+				Equals("✗ disabled pre-commit").IsSelected(),
+				Equals("✗ disabled commit-msg"),
+			).
+			NavigateToLine(Contains("commit-msg")).
+			PressPrimaryAction().
+			Lines(
+				Equals("✗ disabled pre-commit"),
+				Equals("✓ enabled  commit-msg").IsSelected(),
+			).
+			PressEnter()
+
+		t.Views().HookOutput().
+			IsFocused().
+			Title(Equals("Hook 'commit-msg' failed")).
+			Content(Equals("message file: COMMIT_EDITMSG")).
+			PressEscape()
+
+		t.Views().Hooks().
+			IsFocused().
+			PressEscape()
+
+		t.Views().Status().
+			IsFocused()
+	},
+})
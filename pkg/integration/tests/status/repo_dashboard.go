@@ -0,0 +1,82 @@
+package status
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var RepoDashboard = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Show the state of several repos in the repo dashboard, fetch and fast-forward them, and open one of them, which has these commands in its command history",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		projectsDir, _ := filepath.Abs("../projects")
+		cfg.GetUserConfig().RepoDashboard.RootDirectories = []string{projectsDir}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.CloneNonBare("projects/behind")
+		shell.CloneNonBare("projects/service")
+		shell.EmptyCommit("two")
+
+		shell.Chdir("../projects/service")
+		shell.CreateFileAndAdd("stashed", "content")
+		shell.Stash("wip")
+		shell.CreateFile("untracked", "content")
+		shell.Chdir("../../repo")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Status().
+			Focus().
+			Press(keys.Status.RepoDashboard)
+
+		t.Views().RepoDashboard().
+			IsFocused().
+			Title(Equals("Repositories")).
+			Lines(
+				Contains("repo").Contains("master").IsSelected(),
+				Contains("behind").Contains("master").Contains("✓"),
+				Contains("service").Contains("master").Contains("✓ 1 changed 1 stashed"),
+			).
+			Press(keys.Files.Fetch).
+			Lines(
+				Contains("repo").Contains("master").IsSelected(),
+				Contains("behind").Contains("master").Contains("↓1"),
+				Contains("service").Contains("master").Contains("↓1 1 changed 1 stashed"),
+			).
+			Press(keys.Universal.Pull).
+			Lines(
+				Contains("repo").Contains("master").IsSelected(),
+				Contains("behind").Contains("master").Contains("✓"),
+				Contains("service").Contains("master").Contains("✓ 1 changed 1 stashed"),
+			).
+			Press(keys.Universal.Pull)
+
+		t.ExpectToast(Equals("Disabled: No repo can be fast-forwarded"))
+
+		t.Views().RepoDashboard().
+			NavigateToLine(Contains("service")).
+			PressEnter()
+
+		t.Views().Status().Content(Contains("service → master"))
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Contains("untracked"),
+			)
+
+		t.GlobalPress(keys.Universal.ExtrasMenu)
+		t.ExpectPopup().Menu().Title(Equals("Command log")).
+			Select(Contains("Show command history")).
+			Confirm()
+
+		t.Views().CommandHistory().
+			IsFocused().
+			Lines(
+				Contains("service pull --ff-only").IsSelected(),
+				Contains("service fetch"),
+			)
+	},
+})
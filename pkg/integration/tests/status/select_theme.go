@@ -0,0 +1,51 @@
+package status
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SelectTheme = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Select a theme from the theme menu in the status panel",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().Gui.Themes = map[string]config.ThemeConfig{
+			"custom": {ActiveBorderColor: []string{"magenta"}},
+		}
+	},
+	SetupRepo: func(shell *Shell) {},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Status().
+			Focus().
+			Press(keys.Status.SelectTheme)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Select theme")).
+			Lines(
+				Contains("(•) Configured theme").IsSelected(),
+				Contains("( ) custom"),
+				Contains("( ) dark"),
+				Contains("( ) high-contrast"),
+				Contains("( ) light"),
+				Contains("Cancel"),
+			).
+			Select(Contains("custom")).
+			Confirm()
+
+		t.Views().Status().
+			IsFocused().
+			Press(keys.Status.SelectTheme)
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Select theme")).
+			Lines(
+				Contains("( ) Configured theme").IsSelected(),
+				Contains("(•) custom"),
+				Contains("( ) dark"),
+				Contains("( ) high-contrast"),
+				Contains("( ) light"),
+				Contains("Cancel"),
+			)
+	},
+})
@@ -0,0 +1,45 @@
+package sync
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var failingPrePushHook = `#!/bin/sh
+
+echo "refusing to push to $1"
+exit 1
+`
+
+var PushRetryWithoutHooks = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Push with a failing pre-push hook, see the hook's output and retry without hooks",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(config *config.AppConfig) {
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+
+		shell.CloneIntoRemote("origin")
+
+		shell.SetBranchUpstream("master", "origin/master")
+
+		shell.EmptyCommit("two")
+
+		shell.CreateFile(".git/hooks/pre-push", failingPrePushHook)
+		shell.MakeExecutable(".git/hooks/pre-push")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Universal.Push)
+
+		t.Views().HookOutput().
+			IsFocused().
+			Title(Equals("Failed with hooks enabled: pre-push")).
+			Content(Contains("refusing to push to origin")).
+			PressEnter()
+
+		assertSuccessfullyPushed(t)
+	},
+})
@@ -20,6 +20,7 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/interactive_rebase"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/misc"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/patch_building"
+	"github.com/jesseduffield/lazygit/pkg/integration/tests/plugins"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/reflog"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/remote"
 	"github.com/jesseduffield/lazygit/pkg/integration/tests/shell_commands"
@@ -64,6 +65,7 @@ var tests = []*components.IntegrationTest{
 	branch.OpenPullRequestNoUpstream,
 	branch.OpenPullRequestSelectRemoteAndTargetBranch,
 	branch.OpenWithCliArg,
+	branch.ProtectedBranches,
 	branch.Rebase,
 	branch.RebaseAbortOnConflict,
 	branch.RebaseAndDrop,
@@ -143,6 +145,7 @@ var tests = []*components.IntegrationTest{
 	commit.PreserveCommitMessage,
 	commit.ResetAuthor,
 	commit.ResetAuthorRange,
+	commit.RetryWithoutHooksAfterHookFailure,
 	commit.Revert,
 	commit.RevertMerge,
 	commit.RevertWithConflictMultipleCommits,
@@ -171,21 +174,29 @@ var tests = []*components.IntegrationTest{
 	custom_commands.AccessCommitProperties,
 	custom_commands.BasicCommand,
 	custom_commands.CheckForConflicts,
+	custom_commands.Condition,
 	custom_commands.CustomCommandsSubmenu,
 	custom_commands.CustomCommandsSubmenuWithSpecialKeybindings,
+	custom_commands.CustomPanel,
+	custom_commands.FilePicker,
 	custom_commands.FormPrompts,
 	custom_commands.GlobalContext,
+	custom_commands.KeySequence,
 	custom_commands.MenuFromCommand,
 	custom_commands.MenuFromCommandsOutput,
 	custom_commands.MenuPromptWithKeys,
+	custom_commands.MultiSelect,
 	custom_commands.MultipleContexts,
 	custom_commands.MultiplePrompts,
 	custom_commands.RunCommand,
+	custom_commands.RunViaRemoteControl,
 	custom_commands.SelectedCommit,
 	custom_commands.SelectedCommitRange,
+	custom_commands.SelectedItems,
 	custom_commands.SelectedPath,
 	custom_commands.SelectedSubmodule,
 	custom_commands.ShowOutputInPanel,
+	custom_commands.Steps,
 	custom_commands.SuggestionsCommand,
 	custom_commands.SuggestionsPreset,
 	demo.AmendOldCommit,
@@ -224,6 +235,7 @@ var tests = []*components.IntegrationTest{
 	file.ExcludeWithoutInfoDir,
 	file.Gitignore,
 	file.GitignoreSpecialCharacters,
+	file.RecoverDiscardedChanges,
 	file.RememberCommitMessageAfterFail,
 	file.RenameSimilarityThresholdChange,
 	file.RenamedFiles,
@@ -322,12 +334,15 @@ var tests = []*components.IntegrationTest{
 	interactive_rebase.SwapInRebaseWithConflictAndEdit,
 	interactive_rebase.SwapWithConflict,
 	interactive_rebase.ViewFilesOfTodoEntries,
+	misc.CommandHistory,
 	misc.ConfirmOnQuit,
 	misc.CopyConfirmationMessageToClipboard,
 	misc.CopyToClipboard,
+	misc.DiffContextSizePerRepo,
 	misc.DisabledKeybindings,
 	misc.InitialOpen,
 	misc.RecentReposOnLaunch,
+	misc.RestoreRepoSession,
 	patch_building.Apply,
 	patch_building.ApplyInReverse,
 	patch_building.ApplyInReverseWithConflict,
@@ -361,6 +376,7 @@ var tests = []*components.IntegrationTest{
 	patch_building.StartNewPatch,
 	patch_building.ToggleDirectory,
 	patch_building.ToggleRange,
+	plugins.RunCommand,
 	reflog.Checkout,
 	reflog.CherryPick,
 	reflog.DoNotShowBranchMarkersInReflogSubcommits,
@@ -403,6 +419,9 @@ var tests = []*components.IntegrationTest{
 	status.ClickWorkingTreeStateToOpenRebaseOptionsMenu,
 	status.LogCmd,
 	status.LogCmdStatusPanelAllBranchesLog,
+	status.ManageHooks,
+	status.RepoDashboard,
+	status.SelectTheme,
 	submodule.Add,
 	submodule.Enter,
 	submodule.EnterNested,
@@ -434,6 +453,7 @@ var tests = []*components.IntegrationTest{
 	sync.PushAndSetUpstream,
 	sync.PushFollowTags,
 	sync.PushNoFollowTags,
+	sync.PushRetryWithoutHooks,
 	sync.PushTag,
 	sync.PushWithCredentialPrompt,
 	sync.RenameBranchAndPull,
@@ -450,16 +470,21 @@ var tests = []*components.IntegrationTest{
 	tag.Reset,
 	tag.ResetToDuplicateNamedBranch,
 	ui.Accordion,
+	ui.CommandPalette,
+	ui.CommandPaletteMenuItem,
 	ui.DisableSwitchTabWithPanelJumpKeys,
 	ui.EmptyMenu,
 	ui.KeybindingSuggestionsWhenSwitchingRepos,
 	ui.ModeSpecificKeybindingSuggestions,
 	ui.OpenLinkFailure,
 	ui.RangeSelect,
+	ui.SideWindowLayout,
+	ui.SideWindowLayoutWithoutFiles,
 	ui.SwitchTabFromMenu,
 	ui.SwitchTabWithPanelJumpKeys,
 	undo.UndoCheckoutAndDrop,
 	undo.UndoCommit,
+	undo.UndoDeleteRefs,
 	undo.UndoDrop,
 	worktree.AddFromBranch,
 	worktree.AddFromBranchDetached,
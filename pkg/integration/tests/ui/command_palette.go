@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CommandPalette = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Search for an action of another view in the command palette and run it",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().
+			IsFocused().
+			Press(keys.Universal.CommandPalette)
+
+		t.ExpectSearch().
+			Type("newbranch").
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Command palette")).
+			Select(Contains("New branch").Contains("Local branches")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().
+			Title(Contains("New branch name")).
+			Type("feature").
+			Confirm()
+
+		t.Views().Branches().
+			IsFocused().
+			Lines(
+				Contains("feature").IsSelected(),
+				Contains("master"),
+			)
+	},
+})
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var CommandPaletteMenuItem = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Run an item of another view's menu from the command palette",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.CreateFileAndAdd("file-staged", "content")
+		shell.CreateFileAndAdd("file-unstaged", "content")
+		shell.EmptyCommit("initial commit")
+		shell.UpdateFileAndAdd("file-staged", "new content")
+		shell.UpdateFile("file-unstaged", "new content")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			Focus().
+			Press(keys.Universal.CommandPalette)
+
+		t.ExpectSearch().
+			Type("keep index").
+			Confirm()
+
+		t.ExpectPopup().Menu().
+			Title(Equals("Command palette")).
+			Select(Contains("View stash options: Stash all changes and keep index").Contains("Files")).
+			Confirm()
+
+		t.ExpectPopup().Prompt().Title(Equals("Stash changes")).Type("my stash").Confirm()
+
+		t.Views().Files().
+			IsFocused().
+			Lines(
+				Equals("M  file-staged"),
+			)
+
+		t.Views().Stash().
+			Lines(
+				Contains("my stash"),
+			)
+	},
+})
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SideWindowLayout = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Reorder the side windows and show the stash as a tab of the commits window",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().Gui.SideWindowLayout.Windows = []config.SideWindowConfig{
+			{Window: "status"},
+			{Window: "files"},
+			{Window: "commits", Tabs: []string{"stash"}},
+			{Window: "branches"},
+		}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+		shell.CreateFileAndAdd("file", "content")
+		shell.Stash("my stash")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Files().Focus().
+			Press(keys.Universal.JumpToBlock[2])
+
+		t.Views().Commits().IsFocused().
+			Lines(
+				Contains("initial commit").IsSelected(),
+			).
+			Press(keys.Universal.PrevTab)
+
+		t.Views().Stash().IsFocused().
+			Lines(
+				Contains("my stash").IsSelected(),
+			).
+			Press(keys.Universal.JumpToBlock[3])
+
+		t.Views().Branches().IsFocused().
+			Press(keys.Universal.JumpToBlock[4])
+
+		// there's no fifth side window
+		t.Views().Branches().IsFocused().
+			Press(keys.Universal.JumpToBlock[2])
+
+		// jumping back to the commits window keeps its current tab
+		t.Views().Stash().IsFocused()
+	},
+})
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var SideWindowLayoutWithoutFiles = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Start with the topmost side window focused when the layout hides the files window",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig: func(cfg *config.AppConfig) {
+		cfg.GetUserConfig().Gui.SideWindowLayout.Windows = []config.SideWindowConfig{
+			{Window: "commits"},
+			{Window: "branches"},
+		}
+	},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("initial commit")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		t.Views().Commits().
+			IsFocused().
+			Lines(
+				Contains("initial commit").IsSelected(),
+			).
+			Press(keys.Universal.JumpToBlock[1])
+
+		t.Views().Branches().IsFocused()
+	},
+})
@@ -0,0 +1,165 @@
+package undo
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/config"
+	. "github.com/jesseduffield/lazygit/pkg/integration/components"
+)
+
+var UndoDeleteRefs = NewIntegrationTest(NewIntegrationTestArgs{
+	Description:  "Drop a stash entry, delete a tag, check out a branch and delete another branch, then undo and redo the actions in the right order",
+	ExtraCmdArgs: []string{},
+	Skip:         false,
+	SetupConfig:  func(config *config.AppConfig) {},
+	SetupRepo: func(shell *Shell) {
+		shell.EmptyCommit("one")
+		shell.CreateAnnotatedTag("v1.0", "release", "HEAD")
+		shell.NewBranch("other")
+		shell.NewBranch("obsolete")
+		shell.Checkout("master")
+		shell.CreateFileAndAdd("file", "content")
+		shell.Stash("wip")
+	},
+	Run: func(t *TestDriver, keys config.KeybindingConfig) {
+		confirmUndo := func(content *TextMatcher) {
+			t.ExpectPopup().Confirmation().
+				Title(Equals("Undo")).
+				Content(content).
+				Confirm()
+		}
+
+		confirmRedo := func(content *TextMatcher) {
+			t.ExpectPopup().Confirmation().
+				Title(Equals("Redo")).
+				Content(content).
+				Confirm()
+		}
+
+		t.Views().Stash().
+			Focus().
+			Lines(
+				Contains("wip").IsSelected(),
+			).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Confirmation().
+					Title(Equals("Stash drop")).
+					Content(Contains("Are you sure you want to drop the selected stash entry(ies)?")).
+					Confirm()
+			}).
+			IsEmpty()
+
+		t.Views().Tags().
+			Focus().
+			Lines(
+				Contains("v1.0").IsSelected(),
+			).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Delete tag 'v1.0'?")).
+					Select(Contains("Delete local tag")).
+					Confirm()
+			}).
+			IsEmpty()
+
+		t.Views().Branches().
+			Focus().
+			Lines(
+				Contains("master").IsSelected(),
+				Contains("obsolete"),
+				Contains("other"),
+			).
+			NavigateToLine(Contains("other")).
+			PressPrimaryAction().
+			Lines(
+				Contains("other").IsSelected(),
+				Contains("master"),
+				Contains("obsolete"),
+			).
+			NavigateToLine(Contains("obsolete")).
+			Press(keys.Universal.Remove).
+			Tap(func() {
+				t.ExpectPopup().Menu().
+					Title(Equals("Delete branch 'obsolete'?")).
+					Select(Contains("Delete local branch")).
+					Confirm()
+			}).
+			Lines(
+				Contains("other"),
+				Contains("master").IsSelected(),
+			).
+			Press(keys.Universal.Undo).
+			Tap(func() {
+				confirmUndo(Equals("Are you sure you want to restore the deleted branch(es) 'obsolete'?"))
+			}).
+			Lines(
+				Contains("other"),
+				Contains("master").IsSelected(),
+				Contains("obsolete"),
+			).
+			Press(keys.Universal.Undo).
+			Tap(func() {
+				confirmUndo(Contains("Are you sure you want to checkout 'master'?"))
+			}).
+			Lines(
+				Contains("master").IsSelected(),
+				Contains("obsolete"),
+				Contains("other"),
+			).
+			Press(keys.Universal.Undo).
+			Tap(func() {
+				confirmUndo(Equals("Are you sure you want to restore the deleted tag 'v1.0'?"))
+			})
+
+		t.Views().Tags().
+			Lines(
+				Contains("v1.0"),
+			)
+
+		t.Views().Branches().
+			Press(keys.Universal.Undo).
+			Tap(func() {
+				confirmUndo(Equals("Are you sure you want to restore the dropped stash entries?\n\nOn master: wip"))
+			})
+
+		t.Views().Stash().
+			Lines(
+				Contains("wip"),
+			)
+
+		t.Views().Branches().
+			Press(keys.Universal.Redo).
+			Tap(func() {
+				confirmRedo(Equals("Are you sure you want to drop the restored stash entries again?\n\nOn master: wip"))
+			})
+
+		t.Views().Stash().IsEmpty()
+
+		t.Views().Branches().
+			Press(keys.Universal.Redo).
+			Tap(func() {
+				confirmRedo(Equals("Are you sure you want to delete the tag 'v1.0' again?"))
+			})
+
+		t.Views().Tags().IsEmpty()
+
+		t.Views().Branches().
+			Press(keys.Universal.Redo).
+			Tap(func() {
+				confirmRedo(Contains("Are you sure you want to checkout 'other'?"))
+			}).
+			Lines(
+				Contains("other").IsSelected(),
+				Contains("master"),
+				Contains("obsolete"),
+			).
+			Press(keys.Universal.Redo).
+			Tap(func() {
+				confirmRedo(Equals("Are you sure you want to delete the branch(es) 'obsolete' again?"))
+			}).
+			Lines(
+				Contains("other").IsSelected(),
+				Contains("master"),
+			)
+	},
+})
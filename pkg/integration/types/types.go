@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/config"
@@ -18,6 +20,8 @@ type IntegrationTest interface {
 	HeadlessDimensions() (int, int)
 	// If true, we are recording/replaying a demo
 	IsDemo() bool
+	// how long the test may take before we abort it; zero means no limit
+	Timeout() time.Duration
 }
 
 // this is the interface through which our integration tests interact with the lazygit gui
@@ -47,4 +51,7 @@ type GuiDriver interface {
 	NextToast() *string
 	CheckAllToastsAcknowledged()
 	Headless() bool
+	// Sends a request to the remote control socket and waits until lazygit is
+	// idle; returns the response
+	SendRemoteControlRequest(request string) string
 }
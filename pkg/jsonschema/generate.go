@@ -51,8 +51,12 @@ func getSubSchema(rootSchema, parentSchema *jsonschema.Schema, key string) *json
 	return subSchema
 }
 
+func newReflector() *jsonschema.Reflector {
+	return &jsonschema.Reflector{FieldNameTag: "yaml", RequiredFromJSONSchemaTags: true}
+}
+
 func customReflect(v *config.UserConfig) *jsonschema.Schema {
-	r := &jsonschema.Reflector{FieldNameTag: "yaml", RequiredFromJSONSchemaTags: true}
+	r := newReflector()
 	if err := r.AddGoComments("github.com/jesseduffield/lazygit/pkg/config", "../config"); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,240 @@
+package jsonschema
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/utils/yaml_utils"
+	"github.com/karimkhaleel/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// A problem found when validating a config file against the schema
+type ValidationError struct {
+	// The line in the config file where the problem is
+	Line int
+	// The dotted path of the offending key, e.g. "gui.theme.activeBorderColor"
+	Path    string
+	Message string
+}
+
+func (self ValidationError) Error() string {
+	if self.Path == "" {
+		return self.Message
+	}
+	return self.Path + ": " + self.Message
+}
+
+// Validates the content of a user config file against the JSON schema of the
+// user config. The schema is reflected from the config structs, the same way as
+// the one in the schema directory is generated, so that it always matches the
+// running version of lazygit. Only the subset of JSON schema that the reflector
+// generates is supported.
+func ValidateUserConfig(content []byte) ([]ValidationError, error) {
+	var rootNode yaml.Node
+	if err := yaml.Unmarshal(content, &rootNode); err != nil {
+		return nil, err
+	}
+
+	// an empty file is a valid config
+	if rootNode.Kind == 0 || len(rootNode.Content) == 0 {
+		return nil, nil
+	}
+
+	schema := newReflector().Reflect(&config.UserConfig{})
+	validator := &schemaValidator{rootSchema: schema}
+	validator.validate(schema, rootNode.Content[0], "")
+	return validator.errors, nil
+}
+
+type schemaValidator struct {
+	rootSchema *jsonschema.Schema
+	errors     []ValidationError
+}
+
+func (self *schemaValidator) addError(node *yaml.Node, path string, format string, args ...any) {
+	self.errors = append(self.errors, ValidationError{
+		Line:    node.Line,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (self *schemaValidator) resolve(schema *jsonschema.Schema) *jsonschema.Schema {
+	for schema.Ref != "" {
+		key, _ := strings.CutPrefix(schema.Ref, "#/$defs/")
+		refSchema, ok := self.rootSchema.Definitions[key]
+		if !ok {
+			panic(fmt.Sprintf("Failed to find #/$defs/%s", key))
+		}
+		schema = refSchema
+	}
+	return schema
+}
+
+func (self *schemaValidator) validate(schema *jsonschema.Schema, node *yaml.Node, path string) {
+	schema = self.resolve(schema)
+
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	// a null value leaves the default in place when the config is loaded
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return
+	}
+
+	if !self.validateType(schema, node, path) {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		self.validateMapping(schema, node, path)
+	case yaml.SequenceNode:
+		self.validateSequence(schema, node, path)
+	case yaml.ScalarNode:
+		self.validateScalar(schema, node, path)
+	}
+}
+
+func (self *schemaValidator) validateType(schema *jsonschema.Schema, node *yaml.Node, path string) bool {
+	var ok bool
+	switch schema.Type {
+	case "object":
+		ok = node.Kind == yaml.MappingNode
+	case "array":
+		ok = node.Kind == yaml.SequenceNode
+	case "string":
+		// yaml happily unmarshals any scalar into a string, e.g. `1` for a
+		// keybinding
+		ok = node.Kind == yaml.ScalarNode
+	case "boolean":
+		ok = node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool"
+	case "integer":
+		ok = node.Kind == yaml.ScalarNode && node.ShortTag() == "!!int"
+	case "number":
+		ok = node.Kind == yaml.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float")
+	default:
+		ok = true
+	}
+
+	if !ok {
+		self.addError(node, path, "expected %s %s, got %s", article(schema.Type), schema.Type, describeNode(node))
+	}
+	return ok
+}
+
+func (self *schemaValidator) validateMapping(schema *jsonschema.Schema, node *yaml.Node, path string) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		childPath := joinPath(path, key)
+
+		if schema.Properties != nil {
+			if propertySchema, ok := schema.Properties.Get(key); ok {
+				self.validate(propertySchema, valueNode, childPath)
+				continue
+			}
+		}
+
+		switch schema.AdditionalProperties {
+		case nil, jsonschema.TrueSchema:
+			if schema.Properties != nil {
+				self.addError(keyNode, path, "unknown key '%s'", key)
+			}
+		case jsonschema.FalseSchema:
+			self.addError(keyNode, path, "unknown key '%s'", key)
+		default:
+			self.validate(schema.AdditionalProperties, valueNode, childPath)
+		}
+	}
+
+	for _, required := range schema.Required {
+		if keyNode, _ := yaml_utils.LookupKey(node, required); keyNode == nil {
+			self.addError(node, path, "missing required key '%s'", required)
+		}
+	}
+}
+
+func (self *schemaValidator) validateSequence(schema *jsonschema.Schema, node *yaml.Node, path string) {
+	if len(node.Content) < schema.MinItems {
+		self.addError(node, path, "expected at least %d items, got %d", schema.MinItems, len(node.Content))
+	}
+
+	seen := []string{}
+	for i, item := range node.Content {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+		if schema.Items != nil {
+			self.validate(schema.Items, item, itemPath)
+		}
+
+		if schema.UniqueItems {
+			itemContent, err := yaml_utils.YamlMarshal(item)
+			if err != nil {
+				continue
+			}
+			if slices.Contains(seen, string(itemContent)) {
+				self.addError(item, itemPath, "duplicate item")
+			}
+			seen = append(seen, string(itemContent))
+		}
+	}
+}
+
+func (self *schemaValidator) validateScalar(schema *jsonschema.Schema, node *yaml.Node, path string) {
+	if len(schema.Enum) > 0 {
+		allowedValues := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			allowedValues = append(allowedValues, fmt.Sprint(value))
+		}
+		if !slices.Contains(allowedValues, node.Value) {
+			self.addError(node, path, "unexpected value '%s'. Allowed values: %s", node.Value, strings.Join(allowedValues, ", "))
+		}
+	}
+
+	if schema.Type == "string" && len([]rune(node.Value)) < schema.MinLength {
+		self.addError(node, path, "expected at least %d characters", schema.MinLength)
+	}
+
+	if schema.Type == "integer" || schema.Type == "number" {
+		value, ok := new(big.Float).SetString(node.Value)
+		if !ok {
+			return
+		}
+		if limit, ok := new(big.Float).SetString(schema.Minimum.String()); ok && value.Cmp(limit) < 0 {
+			self.addError(node, path, "value %s is less than the minimum of %s", node.Value, schema.Minimum)
+		}
+		if limit, ok := new(big.Float).SetString(schema.Maximum.String()); ok && value.Cmp(limit) > 0 {
+			self.addError(node, path, "value %s is greater than the maximum of %s", node.Value, schema.Maximum)
+		}
+	}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "an array"
+	default:
+		return fmt.Sprintf("'%s'", node.Value)
+	}
+}
+
+func article(typeName string) string {
+	if strings.ContainsAny(typeName[:1], "aeiou") {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,107 @@
+package jsonschema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidateUserConfig(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		content        string
+		expectedErrors []string
+	}{
+		{
+			name:           "empty config",
+			content:        "",
+			expectedErrors: nil,
+		},
+		{
+			name: "valid config",
+			content: `gui:
+  theme:
+    activeBorderColor:
+      - green
+      - bold
+  authorColors:
+    John Smith: red
+keybinding:
+  universal:
+    quit: 1
+    jumpToBlock: [1, 2, 3, 4, 5]
+customCommands:
+  - key: X
+    context: files
+    command: echo hello
+`,
+			expectedErrors: nil,
+		},
+		{
+			name: "unknown keys",
+			content: `gui:
+  foo: true
+customCommands:
+  - key: X
+    commnd: echo hello
+`,
+			expectedErrors: []string{
+				"line 2: gui: unknown key 'foo'",
+				"line 5: customCommands[0]: unknown key 'commnd'",
+			},
+		},
+		{
+			name: "type errors",
+			content: `gui:
+  showIcons: yes please
+  scrollHeight: "2"
+  theme:
+    activeBorderColor: green
+`,
+			expectedErrors: []string{
+				"line 2: gui.showIcons: expected a boolean, got 'yes please'",
+				"line 3: gui.scrollHeight: expected an integer, got '2'",
+				"line 5: gui.theme.activeBorderColor: expected an array, got 'green'",
+			},
+		},
+		{
+			name: "invalid values",
+			content: `gui:
+  language: xx
+  sidePanelWidth: 1.5
+git:
+  mainBranches: [main, main]
+`,
+			expectedErrors: []string{
+				"line 2: gui.language: unexpected value 'xx'. Allowed values: auto, en, zh-TW, zh-CN, pl, nl, ja, ko, ru",
+				"line 3: gui.sidePanelWidth: value 1.5 is greater than the maximum of 1",
+				"line 5: git.mainBranches[1]: duplicate item",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			validationErrors, err := ValidateUserConfig([]byte(s.content))
+			assert.NoError(t, err)
+
+			var actualErrors []string
+			for _, validationError := range validationErrors {
+				actualErrors = append(actualErrors, fmt.Sprintf("line %d: %s", validationError.Line, validationError.Error()))
+			}
+			assert.Equal(t, s.expectedErrors, actualErrors)
+		})
+	}
+}
+
+func TestValidateUserConfig_defaultConfig(t *testing.T) {
+	content, err := yaml.Marshal(config.GetDefaultConfig())
+	assert.NoError(t, err)
+
+	validationErrors, err := ValidateUserConfig(content)
+	assert.NoError(t, err)
+	assert.Empty(t, validationErrors)
+}